@@ -15,7 +15,7 @@ var (
 
 func main() {
 	version.PrintVersion(buildVersion, buildDate, buildCommit)
-	if err := app.Run(); err != nil {
+	if err := app.Run(buildVersion); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -16,11 +16,12 @@ import (
 	"github.com/4aleksei/gokeeper/internal/common/logger"
 )
 
-func Run() error {
+func Run(buildVersion string) error {
 	cfg, err := config.New()
 	if err != nil {
 		return err
 	}
+	cfg.Version = buildVersion
 
 	l, err := logger.New(logger.Config{Level: cfg.Level})
 	if err != nil {
@@ -31,15 +32,56 @@ func Run() error {
 	if err != nil {
 		return err
 	}
-	srvV := service.New(client)
+	srvV := service.New(client, cfg.EndToEnd)
 
 	pr := prompt.New(
-		prompt.AddCommand(command.New(srvV, "Login", "Login name password ", commands.CommandLogin)),
-		prompt.AddCommand(command.New(srvV, "Register", "Register name password ", commands.CommandRegister)),
-		prompt.AddCommand(command.New(srvV, "AddData", "AddData type{'login','card'} 'userdata' 'metadata'", commands.CommandData)),
+		prompt.AddCommand(command.New(srvV, "Login", "Login name password [code] , code is asked if two-factor login is enabled", commands.CommandLogin)),
+		prompt.AddCommand(command.New(srvV, "Register", "Register name password [--recovery] , recovery key is shown once", commands.CommandRegister)),
+		prompt.AddCommand(command.New(srvV, "ChangePassword", "ChangePassword old new , other sessions are logged out", commands.CommandChangePassword)),
+		prompt.AddCommand(command.New(srvV, "Recover", "Recover name 'recovery key' new-password [code] , forgotten password is reset by recovery key, code of second factor if it is enabled", commands.CommandRecover)),
+		prompt.AddCommand(command.New(srvV, "ExportAccount", "ExportAccount [dir] , all items are decrypted to dir/items.json, blobs to dir/uuid.data", commands.CommandExportAccount)),
+		prompt.AddCommand(command.New(srvV, "DeleteAccount", "DeleteAccount password , user is deleted with all items, it can not be undone, last owner of organization adds owner before", commands.CommandDeleteAccount)),
+		prompt.AddCommand(command.New(srvV, "Logout", "Logout , token of this session is revoked", commands.CommandLogout)),
+		prompt.AddCommand(command.New(srvV, "LogoutAll", "LogoutAll , all sessions of user are revoked", commands.CommandLogoutAll)),
+		prompt.AddCommand(command.New(srvV, "Enroll2FA", "Enroll2FA [--code code | --password password] , secret and otpauth URI of authenticator app, code or password if enabled", commands.CommandEnroll2FA)),
+		prompt.AddCommand(command.New(srvV, "Verify2FA", "Verify2FA code , enable two-factor login, shows recovery codes", commands.CommandVerify2FA)),
+		prompt.AddCommand(command.New(srvV, "Sessions", "Sessions , devices logged in, * - this session", commands.CommandSessions)),
+		prompt.AddCommand(command.New(srvV, "RevokeSession", "RevokeSession id , log out lost device", commands.CommandRevokeSession)),
+		prompt.AddCommand(command.New(srvV, "CreateToken", "CreateToken name ro|rw [--ttl 720h] [--items uuid,uuid] [--collections uuid,uuid] [--tags word,word] , api token for automation, tag is word of metadata of item, all items by default", commands.CommandCreateToken)),
+		prompt.AddCommand(command.New(srvV, "Tokens", "Tokens , api tokens", commands.CommandTokens)),
+		prompt.AddCommand(command.New(srvV, "RevokeToken", "RevokeToken name , api token is rejected", commands.CommandRevokeToken)),
+		prompt.AddCommand(command.New(srvV, "AddData", "AddData type{'login','card'} 'userdata' 'metadata' [--ttl 24h] [--collection uuid]", commands.CommandData)),
 		prompt.AddCommand(command.New(srvV, "GetData", "GetData uuid", commands.CommandGetData)),
-		prompt.AddCommand(command.New(srvV, "UploadData", "UploadData type{'text','binary'} 'metadata' 'filename of data'", commands.CommandUploadData)),
-		prompt.AddCommand(command.New(srvV, "DownloadData", "DownloadData uuid", commands.CommandDownloadData)),
+		prompt.AddCommand(command.New(srvV, "Delete", "Delete uuid , with attachments", commands.CommandDeleteData)),
+		prompt.AddCommand(command.New(srvV, "UploadData", "UploadData type{'text','binary'} 'metadata' 'filename of data' [compression{'auto','none','gzip','gzip-fast','lz'}] [--ttl 24h] [--collection uuid]", commands.CommandUploadData)),
+		prompt.AddCommand(command.New(srvV, "Attach", "Attach uuid 'filename of data'", commands.CommandAttachData)),
+		prompt.AddCommand(command.New(srvV, "DownloadData", "DownloadData uuid [length] , partial file uuid.data is resumed", commands.CommandDownloadData)),
+		prompt.AddCommand(command.New(srvV, "Search", "Search query", commands.CommandSearch)),
+		prompt.AddCommand(command.New(srvV, "Update", "Update uuid 'userdata' , new password restarts rotation", commands.CommandUpdateData)),
+		prompt.AddCommand(command.New(srvV, "Rotation", "Rotation uuid|collection|default days , rotation policy of login item, of login items of collection or of all login items, 0 - none", commands.CommandRotation)),
+		prompt.AddCommand(command.New(srvV, "Due", "Due [days ahead] , login items due for password rotation", commands.CommandDue)),
+		prompt.AddCommand(command.New(srvV, "Usage", "Usage , stored items and bytes of quota", commands.CommandUsage)),
+		prompt.AddCommand(command.New(srvV, "Share", "Share uuid user [ro|rw] , item with attachments is shared, read-only by default", commands.CommandShare)),
+		prompt.AddCommand(command.New(srvV, "Unshare", "Unshare uuid user , access of user is revoked", commands.CommandUnshare)),
+		prompt.AddCommand(command.New(srvV, "Shared", "Shared , items shared with me", commands.CommandShared)),
+		prompt.AddCommand(command.New(srvV, "GetShared", "GetShared uuid , item shared with me, opened by its key wrapped for me", commands.CommandGetShared)),
+		prompt.AddCommand(command.New(srvV, "Org", "Org name , new organization, I am owner", commands.CommandOrg)),
+		prompt.AddCommand(command.New(srvV, "Member", "Member org user owner|admin|member|readonly , add member or change role", commands.CommandMember)),
+		prompt.AddCommand(command.New(srvV, "RemoveMember", "RemoveMember org user , keys of collections and items are rotated", commands.CommandRemoveMember)),
+		prompt.AddCommand(command.New(srvV, "Members", "Members org", commands.CommandMembers)),
+		prompt.AddCommand(command.New(srvV, "Collection", "Collection org name , new team vault", commands.CommandCollection)),
+		prompt.AddCommand(command.New(srvV, "Collections", "Collections , team vaults of my organizations", commands.CommandCollections)),
+		prompt.AddCommand(command.New(srvV, "Team", "Team uuid , items of team vault", commands.CommandTeam)),
+		prompt.AddCommand(command.New(srvV, "Secret", "Secret 'secret' [views] [--ttl 1h] , one-time link, key of secret is only in link", commands.CommandSecret)),
+		prompt.AddCommand(command.New(srvV, "Redeem", "Redeem link , secret of one-time link, no login needed", commands.CommandRedeem)),
+		prompt.AddCommand(command.New(srvV, "Invite", "Invite contact [--wait 168h] , trusted contact may request access to my vault", commands.CommandInvite)),
+		prompt.AddCommand(command.New(srvV, "RequestAccess", "RequestAccess id , access is granted after wait unless owner denies", commands.CommandRequestAccess)),
+		prompt.AddCommand(command.New(srvV, "Approve", "Approve id , grant emergency access before end of wait", commands.CommandApprove)),
+		prompt.AddCommand(command.New(srvV, "Deny", "Deny id , deny request of emergency access", commands.CommandDeny)),
+		prompt.AddCommand(command.New(srvV, "RevokeAccess", "RevokeAccess id , delete emergency access", commands.CommandRevokeAccess)),
+		prompt.AddCommand(command.New(srvV, "Emergency", "Emergency , emergency access of my contacts and to other vaults", commands.CommandEmergency)),
+		prompt.AddCommand(command.New(srvV, "ViewAccess", "ViewAccess id , items of vault of approved access, read by ViewItem", commands.CommandViewAccess)),
+		prompt.AddCommand(command.New(srvV, "ViewItem", "ViewItem id uuid , item of vault of approved access, opened by its key wrapped for me", commands.CommandViewItem)),
 	)
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
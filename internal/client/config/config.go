@@ -1,6 +1,11 @@
 // Package config -  Config with command arguments
 package config
 
+import (
+	"flag"
+	"os"
+)
+
 type Config struct {
 	Address        string
 	Level          string
@@ -10,6 +15,9 @@ type Config struct {
 	ContentBatch   int64
 	RateLimit      int64
 	CertKeyFile    string
+	EndToEnd       bool
+	Device         string
+	Version        string
 }
 
 const (
@@ -22,6 +30,7 @@ const (
 	RateLimitDefault      int64  = 10
 	GrpcDefault           bool   = false
 	CertKeyFileDefault    string = ""
+	EndToEndDefault       bool   = false
 )
 
 func initDefaultCfg() *Config {
@@ -35,11 +44,18 @@ func initDefaultCfg() *Config {
 	cfg.ContentBatch = ContentBatchDefault
 
 	cfg.CertKeyFile = CertKeyFileDefault
+	cfg.EndToEnd = EndToEndDefault
+	cfg.Device, _ = os.Hostname()
 	return cfg
 }
 
 func New() (*Config, error) {
 	cfg := initDefaultCfg()
 
+	flag.BoolVar(&cfg.EndToEnd, "e2e", cfg.EndToEnd, "end-to-end mode, data and metadata encrypted by client")
+	flag.StringVar(&cfg.Device, "device", cfg.Device, "Device name of session, default - host name")
+
+	flag.Parse()
+
 	return cfg, nil
 }
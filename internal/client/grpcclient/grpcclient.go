@@ -4,6 +4,7 @@ package grpcclient
 import (
 	"context"
 	"crypto/x509"
+	"encoding/hex"
 	"io"
 	"net"
 	"os"
@@ -30,6 +31,9 @@ type (
 		client     pb.KeeperServiceClient
 		connection *grpc.ClientConn
 		localAddr  string
+		sessions   *sessions
+		device     string
+		version    string
 	}
 )
 
@@ -52,7 +56,7 @@ func loadCert(cfg *config.Config) (grpc.DialOption, error) {
 }
 
 func newClient(cfg *config.Config) (*agentClient, error) {
-	agclient := &agentClient{}
+	agclient := &agentClient{sessions: newSessions(), device: cfg.Device, version: cfg.Version}
 	myDialer := net.Dialer{Timeout: 30 * time.Second,
 		KeepAlive: 30 * time.Second}
 
@@ -67,13 +71,16 @@ func newClient(cfg *config.Config) (*agentClient, error) {
 				agclient.localAddr = conn.LocalAddr().(*net.TCPAddr).IP.String()
 			}
 			return conn, err
-		}))
+		}),
+		grpc.WithChainUnaryInterceptor(agclient.sessions.unary),
+		grpc.WithChainStreamInterceptor(agclient.sessions.stream))
 
 	if err != nil {
 		return nil, err
 	}
 	c := pb.NewKeeperServiceClient(conn)
 	agclient.client = c
+	agclient.sessions.client = c
 	agclient.connection = conn
 	return agclient, nil
 }
@@ -112,12 +119,15 @@ func sendStream(ctx context.Context, client *agentClient, req *transaction.Reque
 	switch v := req.Command.(type) {
 	case transaction.StreamData:
 		md := metadata.New(map[string]string{"authorization": v.Token.Token})
-		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		ctxReqMd, cancel := context.WithCancel(metadata.NewOutgoingContext(ctxReq, md))
+		// broken stream is not committed by server
+		defer cancel()
 		stream, err := client.client.UploadData(ctxReqMd)
 		if err != nil {
 			return nil, err
 		}
 		var fsend bool
+		offset := v.Offset
 		for res := range v.Output {
 			select {
 			case <-ctxReq.Done():
@@ -126,16 +136,40 @@ func sendStream(ctx context.Context, client *agentClient, req *transaction.Reque
 			default:
 
 				if !fsend {
-					err = stream.Send(&pb.DataChunk{Data: res, Type: pb.TypeData(v.TypeData), Metadata: v.MetaData})
+					err = stream.Send(&pb.DataChunk{Data: res, Type: pb.TypeData(v.TypeData), Metadata: v.MetaData, Tokens: v.Tokens, Parent: v.Parent,
+						Session: v.Session, Offset: offset, Size: v.Size, Compression: v.Compression, ExpiresAt: expiryUnix(v.ExpiresAt),
+						Collection: v.Collection})
 					fsend = true
 				} else {
-					err = stream.Send(&pb.DataChunk{Data: res})
+					err = stream.Send(&pb.DataChunk{Data: res, Offset: offset})
 				}
 				if err != nil {
 					return nil, err
 				}
+				if v.Hash != nil {
+					v.Hash.Write(res)
+				}
+				offset += int64(len(res))
 			}
 		}
+		if v.Size > 0 && offset != v.Size {
+			return nil, transaction.ErrSizeMismatch
+		}
+		var checksum string
+		if v.Hash != nil {
+			checksum = hex.EncodeToString(v.Hash.Sum(nil))
+		}
+		switch {
+		case !fsend && v.Session != "":
+			// resumed upload with all data sent, commit only
+			err = stream.Send(&pb.DataChunk{Type: pb.TypeData(v.TypeData), Session: v.Session, Offset: offset, Size: v.Size,
+				Checksum: checksum})
+		case fsend && checksum != "":
+			err = stream.Send(&pb.DataChunk{Offset: offset, Checksum: checksum})
+		}
+		if err != nil {
+			return nil, err
+		}
 
 		resp, err := stream.CloseAndRecv()
 		if err != nil {
@@ -145,16 +179,16 @@ func sendStream(ctx context.Context, client *agentClient, req *transaction.Reque
 		return &transaction.Response{Resp: transaction.UUIDData{UUID: resp.GetUuid()}}, nil
 
 	case transaction.GetStreamData:
+		defer close(v.Input)
 
 		md := metadata.New(map[string]string{"authorization": v.Token.Token})
 		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
-		stream, err := client.client.DownloadData(ctxReqMd, &pb.DownloadRequest{Uuid: v.UUID.UUID})
+		stream, err := client.client.DownloadData(ctxReqMd, &pb.DownloadRequest{Uuid: v.UUID.UUID, Offset: v.Offset, Length: v.Length})
 		if err != nil {
 			return nil, err
 		}
 		var tx transaction.UserData
 		var firstP bool
-		defer close(v.Input)
 		for {
 			chunk, err := stream.Recv()
 			if err == io.EOF {
@@ -168,16 +202,119 @@ func sendStream(ctx context.Context, client *agentClient, req *transaction.Reque
 			if !firstP {
 				tx.MetaData = chunk.Metadata
 				tx.TypeData = int(chunk.GetType())
+				tx.Size = chunk.GetSize()
+				tx.Checksum = chunk.GetChecksum()
 				firstP = true
 			}
 		}
 
 		return &transaction.Response{Resp: tx}, nil
+
+	case transaction.ExportData:
+		defer close(v.Input)
+
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		stream, err := client.client.ExportAccount(ctxReqMd, &pb.ExportRequest{})
+		if err != nil {
+			return nil, err
+		}
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			v.Input <- transaction.ExportChunk{
+				UUID:       chunk.GetUuid(),
+				TypeData:   int(chunk.GetType()),
+				Data:       chunk.GetData(),
+				MetaData:   chunk.GetMetadata(),
+				Parent:     chunk.GetParent(),
+				Collection: chunk.GetCollection(),
+				ExpiresAt:  unixTime(chunk.GetExpiresAt()),
+				IsBlob:     chunk.GetIsBlob(),
+				Blob:       chunk.GetBlob(),
+				Offset:     chunk.GetOffset(),
+				Size:       chunk.GetSize(),
+				Checksum:   chunk.GetChecksum(),
+			}
+		}
+		return &transaction.Response{Resp: v.Token}, nil
+
+	case transaction.SearchData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		stream, err := client.client.Search(ctxReqMd, &pb.SearchRequest{Query: v.Query, Tokens: v.Tokens})
+		if err != nil {
+			return nil, err
+		}
+		var tx transaction.SearchResults
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			tx.Items = append(tx.Items, transaction.SearchItem{
+				UUID:     res.GetUuid(),
+				TypeData: int(res.GetType()),
+				MetaData: res.GetMetadata(),
+				Score:    int(res.GetScore()),
+				Fields:   res.GetFields(),
+			})
+		}
+		return &transaction.Response{Resp: tx}, nil
 	}
 
 	return nil, transaction.ErrBadTypeCommand
 }
 
+// expiryUnix - unix time of expiry, zero - never
+func expiryUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// unixTime - time of unix seconds, zero - not set
+func unixTime(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+func apiToken(t *pb.APIToken) transaction.APIToken {
+	return transaction.APIToken{
+		Name:        t.GetName(),
+		Permission:  int(t.GetPermission()),
+		Items:       t.GetItems(),
+		Collections: t.GetCollections(),
+		Tags:        t.GetTags(),
+		ExpiresAt:   unixTime(t.GetExpiresAt()),
+		CreatedAt:   unixTime(t.GetCreatedAt()),
+		LastUsed:    unixTime(t.GetLastUsed()),
+	}
+}
+
+func emergency(e *pb.Emergency) transaction.Emergency {
+	return transaction.Emergency{
+		ID:          e.GetId(),
+		Owner:       e.GetOwner(),
+		Contact:     e.GetContact(),
+		State:       int(e.GetState()),
+		Wait:        time.Duration(e.GetWaitSeconds()) * time.Second,
+		RequestedAt: unixTime(e.GetRequestedAt()),
+		GrantedAt:   unixTime(e.GetGrantedAt()),
+	}
+}
+
 func sendTransaction(ctx context.Context, client *agentClient, req *transaction.Request) (*transaction.Response, error) {
 
 	md := metadata.New(map[string]string{"X-Real-IP": client.localAddr})
@@ -185,25 +322,130 @@ func sendTransaction(ctx context.Context, client *agentClient, req *transaction.
 
 	switch v := req.Command.(type) {
 	case transaction.UserLogin:
-		resp, err := client.client.LoginUser(ctxReq, &pb.LoginRequest{Name: v.User.Name, Password: v.User.Password})
+		resp, err := client.client.LoginUser(ctxReq, &pb.LoginRequest{Name: v.User.Name, Password: v.User.Password,
+			Device: client.device, ClientVersion: client.version, Otp: v.User.OTP, Challenge: v.User.Challenge})
 		if err != nil {
 			return nil, err
 		}
-		return &transaction.Response{Resp: transaction.TokenUser{Token: resp.GetToken()}}, nil
+		client.sessions.add(resp.GetToken(), resp.GetRefreshToken(), unixTime(resp.GetExpiresAt()))
+		return &transaction.Response{Resp: transaction.TokenUser{Token: resp.GetToken(), VaultKey: resp.GetVaultKey(),
+			PublicKey: resp.GetPublicKey(), PrivateKey: resp.GetPrivateKey(), Challenge: resp.GetChallenge()}}, nil
 
 	case transaction.UserRegister:
 
-		resp, err := client.client.RegisterUser(ctxReq, &pb.LoginRequest{Name: v.User.Name, Password: v.User.Password})
+		resp, err := client.client.RegisterUser(ctxReq, &pb.LoginRequest{Name: v.User.Name, Password: v.User.Password, VaultKey: v.User.VaultKey,
+			Device: client.device, ClientVersion: client.version})
 		if err != nil {
 			return nil, err
 		}
+		client.sessions.add(resp.GetToken(), resp.GetRefreshToken(), unixTime(resp.GetExpiresAt()))
 
-		return &transaction.Response{Resp: transaction.TokenUser{Token: resp.GetToken()}}, nil
+		return &transaction.Response{Resp: transaction.TokenUser{Token: resp.GetToken(), VaultKey: resp.GetVaultKey()}}, nil
+
+	case transaction.LogoutData:
+		access, refresh, err := client.sessions.take(ctxReq, v.Token.Token)
+		if err != nil {
+			return nil, err
+		}
+		md := metadata.New(map[string]string{"authorization": access})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		if v.All {
+			_, err = client.client.RevokeAllSessions(ctxReqMd, &pb.RevokeAllRequest{})
+		} else {
+			_, err = client.client.Logout(ctxReqMd, &pb.LogoutRequest{RefreshToken: refresh})
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: transaction.TokenUser{}}, nil
+
+	case transaction.APITokenData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.CreateAPIToken(ctxReqMd, &pb.APITokenRequest{Name: v.Name, Permission: pb.Permission(v.Permission),
+			Items: v.Items, Collections: v.Collections, Tags: v.Tags, ExpiresIn: int64(v.TTL / time.Second)})
+		if err != nil {
+			return nil, err
+		}
+		t := apiToken(resp.GetInfo())
+		t.Secret = resp.GetToken()
+		return &transaction.Response{Resp: t}, nil
+
+	case transaction.APITokensData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.ListAPITokens(ctxReqMd, &pb.APITokensRequest{})
+		if err != nil {
+			return nil, err
+		}
+		var tx transaction.APITokens
+		for _, t := range resp.GetTokens() {
+			tx.Items = append(tx.Items, apiToken(t))
+		}
+		return &transaction.Response{Resp: tx}, nil
+
+	case transaction.RevokeAPITokenData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		_, err := client.client.RevokeAPIToken(ctxReqMd, &pb.RevokeAPITokenRequest{Name: v.Name})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: transaction.APIToken{Name: v.Name}}, nil
+
+	case transaction.EnrollData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.EnrollTOTP(ctxReqMd, &pb.EnrollRequest{Code: v.Code, Password: v.Password})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: transaction.Enrollment{Secret: resp.GetSecret(), URI: resp.GetUri()}}, nil
+
+	case transaction.VerifyTOTPData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.VerifyTOTP(ctxReqMd, &pb.VerifyTOTPRequest{Code: v.Code})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: transaction.RecoveryCodes{Codes: resp.GetRecoveryCodes()}}, nil
+
+	case transaction.SessionsData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.ListSessions(ctxReqMd, &pb.SessionsRequest{})
+		if err != nil {
+			return nil, err
+		}
+		var tx transaction.Sessions
+		for _, session := range resp.GetSessions() {
+			tx.Items = append(tx.Items, transaction.Session{
+				ID:            session.GetId(),
+				Device:        session.GetDevice(),
+				ClientVersion: session.GetClientVersion(),
+				Address:       session.GetAddress(),
+				CreatedAt:     unixTime(session.GetCreatedAt()),
+				LastSeen:      unixTime(session.GetLastSeen()),
+				Current:       session.GetCurrent(),
+			})
+		}
+		return &transaction.Response{Resp: tx}, nil
+
+	case transaction.RevokeSessionData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		_, err := client.client.RevokeSession(ctxReqMd, &pb.RevokeSessionRequest{Id: v.ID})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: transaction.Session{ID: v.ID}}, nil
 
 	case transaction.UserData:
 		md := metadata.New(map[string]string{"authorization": v.Token.Token})
 		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
-		resp, err := client.client.AddData(ctxReqMd, &pb.UserData{Data: v.Data, Metadata: v.MetaData, Type: pb.TypeData(v.TypeData)})
+		resp, err := client.client.AddData(ctxReqMd, &pb.UserData{Data: v.Data, Metadata: v.MetaData, Type: pb.TypeData(v.TypeData), Tokens: v.Tokens,
+			ExpiresAt: expiryUnix(v.ExpiresAt), Collection: v.Collection})
 		if err != nil {
 			return nil, err
 		}
@@ -216,7 +458,351 @@ func sendTransaction(ctx context.Context, client *agentClient, req *transaction.
 		if err != nil {
 			return nil, err
 		}
-		return &transaction.Response{Resp: transaction.UserData{Data: resp.GetData(), MetaData: resp.Metadata, TypeData: int(resp.GetType())}}, nil
+		tx := transaction.UserData{Data: resp.GetData(), MetaData: resp.Metadata, TypeData: int(resp.GetType())}
+		for _, a := range resp.GetAttachments() {
+			tx.Attachments = append(tx.Attachments, transaction.Attachment{UUID: a.GetUuid(), Name: a.GetName(), Size: a.GetSize()})
+		}
+		return &transaction.Response{Resp: tx}, nil
+
+	case transaction.QueryUploadData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.QueryUpload(ctxReqMd, &pb.QueryUploadRequest{Session: v.Session})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: transaction.UploadOffset{Offset: resp.GetOffset(), Size: resp.GetSize()}}, nil
+
+	case transaction.DeleteUserData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.DeleteData(ctxReqMd, &pb.DownloadRequest{Uuid: v.UUID.UUID})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: transaction.DeletedData{UUIDs: resp.GetUuids()}}, nil
+
+	case transaction.UpdateUserData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		_, err := client.client.UpdateData(ctxReqMd, &pb.UpdateRequest{Uuid: v.UUID.UUID, Data: v.Data, Metadata: v.MetaData, Tokens: v.Tokens})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: v.UUID}, nil
+
+	case transaction.RotationData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		_, err := client.client.SetRotation(ctxReqMd, &pb.RotationRequest{Uuid: v.UUID.UUID, Days: int32(v.Days)})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: v.UUID}, nil
+
+	case transaction.DueData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.DueRotation(ctxReqMd, &pb.DueRequest{At: expiryUnix(v.At)})
+		if err != nil {
+			return nil, err
+		}
+		var tx transaction.DueItems
+		for _, item := range resp.GetItems() {
+			tx.Items = append(tx.Items, transaction.DueItem{
+				UUID:      item.GetUuid(),
+				MetaData:  item.GetMetadata(),
+				ChangedAt: time.Unix(item.GetChangedAt(), 0),
+				DueAt:     time.Unix(item.GetDueAt(), 0),
+				Days:      int(item.GetDays()),
+			})
+		}
+		return &transaction.Response{Resp: tx}, nil
+
+	case transaction.UsageData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.GetUsage(ctxReqMd, &pb.UsageRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: transaction.Usage{
+			Items:      resp.GetItems(),
+			Bytes:      resp.GetBytes(),
+			QuotaItems: resp.GetQuotaItems(),
+			QuotaBytes: resp.GetQuotaBytes(),
+			MaxUpload:  resp.GetMaxUpload(),
+		}}, nil
+
+	case transaction.ChangePasswordData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.ChangePassword(ctxReqMd, &pb.ChangePasswordRequest{OldPassword: v.Old, Password: v.New,
+			VaultKey: v.VaultKey, PrivateKey: v.PrivateKey, Device: client.device, ClientVersion: client.version})
+		if err != nil {
+			return nil, err
+		}
+		client.sessions.drop(v.Token.Token)
+		client.sessions.add(resp.GetToken(), resp.GetRefreshToken(), unixTime(resp.GetExpiresAt()))
+		return &transaction.Response{Resp: transaction.TokenUser{Token: resp.GetToken()}}, nil
+
+	case transaction.DeleteAccountData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		_, err := client.client.DeleteAccount(ctxReqMd, &pb.DeleteAccountRequest{Password: v.Password})
+		if err != nil {
+			return nil, err
+		}
+		client.sessions.drop(v.Token.Token)
+		return &transaction.Response{Resp: transaction.TokenUser{}}, nil
+
+	case transaction.SetRecoveryData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		_, err := client.client.SetRecovery(ctxReqMd, &pb.SetRecoveryRequest{Recovery: v.Recovery, VaultKey: v.VaultKey,
+			PrivateKey: v.PrivateKey})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: v.Token}, nil
+
+	case transaction.OpenRecoveryData:
+		resp, err := client.client.OpenRecovery(ctxReq, &pb.RecoveryRequest{Name: v.Name, Recovery: v.Recovery})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: transaction.RecoveryKeys{VaultKey: resp.GetVaultKey(), PrivateKey: resp.GetPrivateKey()}}, nil
+
+	case transaction.RecoverData:
+		resp, err := client.client.RecoverAccount(ctxReq, &pb.RecoveryRequest{Name: v.Name, Recovery: v.Recovery, Password: v.Password,
+			VaultKey: v.VaultKey, PrivateKey: v.PrivateKey, Device: client.device, ClientVersion: client.version, Otp: v.OTP})
+		if err != nil {
+			return nil, err
+		}
+		client.sessions.add(resp.GetToken(), resp.GetRefreshToken(), unixTime(resp.GetExpiresAt()))
+		return &transaction.Response{Resp: transaction.TokenUser{Token: resp.GetToken(), VaultKey: resp.GetVaultKey(),
+			PublicKey: resp.GetPublicKey(), PrivateKey: resp.GetPrivateKey()}}, nil
+
+	case transaction.KeysData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		_, err := client.client.SetKeys(ctxReqMd, &pb.SetKeysRequest{PublicKey: v.PublicKey, PrivateKey: v.PrivateKey})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: v.Token}, nil
+
+	case transaction.ShareData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.ShareItem(ctxReqMd, &pb.ShareRequest{Uuid: v.UUID.UUID, Recipient: v.Recipient,
+			Permission: pb.Permission(v.Permission)})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: transaction.SharedKey{WrappedKey: resp.GetWrappedKey()}}, nil
+
+	case transaction.UnshareData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		_, err := client.client.Unshare(ctxReqMd, &pb.UnshareRequest{Uuid: v.UUID.UUID, Recipient: v.Recipient})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: v.UUID}, nil
+
+	case transaction.SharedData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.SharedWithMe(ctxReqMd, &pb.SharedRequest{})
+		if err != nil {
+			return nil, err
+		}
+		var tx transaction.SharedItems
+		for _, item := range resp.GetItems() {
+			tx.Items = append(tx.Items, transaction.SharedItem{
+				UUID:       item.GetUuid(),
+				Owner:      item.GetOwner(),
+				TypeData:   int(item.GetType()),
+				MetaData:   item.GetMetadata(),
+				Permission: int(item.GetPermission()),
+				WrappedKey: item.GetWrappedKey(),
+			})
+		}
+		return &transaction.Response{Resp: tx}, nil
+
+	case transaction.GetSealedData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.GetSealedData(ctxReqMd, &pb.DownloadRequest{Uuid: v.UUID.UUID})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: transaction.SealedItem{UUID: resp.GetUuid(), TypeData: int(resp.GetType()),
+			Data: resp.GetData(), MetaData: resp.GetMetadata()}}, nil
+
+	case transaction.SecretData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.CreateSecretShare(ctxReqMd, &pb.SecretRequest{Ciphertext: v.Ciphertext, MaxViews: int32(v.MaxViews),
+			ExpiresAt: expiryUnix(v.ExpiresAt)})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: transaction.SecretID{ID: resp.GetId(), ExpiresAt: time.Unix(resp.GetExpiresAt(), 0)}}, nil
+
+	case transaction.RedeemData:
+		resp, err := client.client.RedeemShare(ctxReq, &pb.RedeemRequest{Id: v.ID})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: transaction.RedeemedSecret{Ciphertext: resp.GetCiphertext(), ViewsLeft: int(resp.GetViewsLeft())}}, nil
+
+	case transaction.EmergencyInviteData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.InviteEmergency(ctxReqMd, &pb.EmergencyInvite{Contact: v.Contact,
+			WaitSeconds: int64(v.Wait / time.Second)})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: emergency(resp)}, nil
+
+	case transaction.EmergencyStepData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		var resp *pb.Emergency
+		var err error
+		switch v.Step {
+		case transaction.EmergencyRequest:
+			resp, err = client.client.RequestEmergency(ctxReqMd, &pb.EmergencyRequest{Id: v.ID})
+		case transaction.EmergencyApprove, transaction.EmergencyDeny:
+			resp, err = client.client.ApproveEmergency(ctxReqMd, &pb.EmergencyRequest{Id: v.ID,
+				Approve: v.Step == transaction.EmergencyApprove})
+		case transaction.EmergencyRevoke:
+			_, err = client.client.RevokeEmergency(ctxReqMd, &pb.EmergencyRequest{Id: v.ID})
+			resp = &pb.Emergency{Id: v.ID}
+		default:
+			return nil, transaction.ErrBadTypeCommand
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: emergency(resp)}, nil
+
+	case transaction.EmergencyListData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.ListEmergency(ctxReqMd, &pb.EmergencyListRequest{})
+		if err != nil {
+			return nil, err
+		}
+		var tx transaction.Emergencies
+		for _, e := range resp.GetEmergency() {
+			tx.Items = append(tx.Items, emergency(e))
+		}
+		return &transaction.Response{Resp: tx}, nil
+
+	case transaction.EmergencyAccessData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.EmergencyAccess(ctxReqMd, &pb.EmergencyRequest{Id: v.ID})
+		if err != nil {
+			return nil, err
+		}
+		var tx transaction.EmergencyItems
+		for _, item := range resp.GetItems() {
+			tx.Items = append(tx.Items, transaction.EmergencyItem{
+				UUID:       item.GetUuid(),
+				TypeData:   int(item.GetType()),
+				MetaData:   item.GetMetadata(),
+				WrappedKey: item.GetWrappedKey(),
+			})
+		}
+		return &transaction.Response{Resp: tx}, nil
+
+	case transaction.OrgData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.CreateOrg(ctxReqMd, &pb.OrgRequest{Name: v.Name})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: transaction.OrgID{ID: resp.GetId()}}, nil
+
+	case transaction.MemberData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		var err error
+		if v.Remove {
+			_, err = client.client.RemoveMember(ctxReqMd, &pb.MemberRequest{Org: v.Org, User: v.User})
+		} else {
+			_, err = client.client.AddMember(ctxReqMd, &pb.MemberRequest{Org: v.Org, User: v.User, Role: pb.Role(v.Role)})
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: transaction.OrgID{ID: v.Org}}, nil
+
+	case transaction.MembersData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.ListMembers(ctxReqMd, &pb.MembersRequest{Org: v.Org})
+		if err != nil {
+			return nil, err
+		}
+		var tx transaction.OrgMembers
+		for _, member := range resp.GetMembers() {
+			tx.Members = append(tx.Members, transaction.OrgMember{Name: member.GetName(), Role: int(member.GetRole())})
+		}
+		return &transaction.Response{Resp: tx}, nil
+
+	case transaction.CollectionData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.CreateCollection(ctxReqMd, &pb.CollectionRequest{Org: v.Org, Name: v.Name})
+		if err != nil {
+			return nil, err
+		}
+		return &transaction.Response{Resp: transaction.UUIDData{UUID: resp.GetUuid()}}, nil
+
+	case transaction.CollectionsData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.ListCollections(ctxReqMd, &pb.CollectionsRequest{})
+		if err != nil {
+			return nil, err
+		}
+		var tx transaction.Collections
+		for _, c := range resp.GetCollections() {
+			tx.Items = append(tx.Items, transaction.Collection{
+				UUID:       c.GetUuid(),
+				Org:        c.GetOrg(),
+				Name:       c.GetName(),
+				Role:       int(c.GetRole()),
+				Version:    int(c.GetVersion()),
+				WrappedKey: c.GetWrappedKey(),
+			})
+		}
+		return &transaction.Response{Resp: tx}, nil
+
+	case transaction.CollectionItemsData:
+		md := metadata.New(map[string]string{"authorization": v.Token.Token})
+		ctxReqMd := metadata.NewOutgoingContext(ctxReq, md)
+		resp, err := client.client.CollectionItems(ctxReqMd, &pb.CollectionItemsRequest{Uuid: v.UUID.UUID})
+		if err != nil {
+			return nil, err
+		}
+		var tx transaction.CollectionItems
+		for _, item := range resp.GetItems() {
+			tx.Items = append(tx.Items, transaction.CollectionItem{
+				UUID:     item.GetUuid(),
+				Owner:    item.GetOwner(),
+				TypeData: int(item.GetType()),
+				MetaData: item.GetMetadata(),
+			})
+		}
+		return &transaction.Response{Resp: tx}, nil
 
 	}
 
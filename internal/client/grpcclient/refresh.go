@@ -0,0 +1,150 @@
+package grpcclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pb "github.com/4aleksei/gokeeper/pkg/api/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// refreshMargin - access token is refreshed before its expiry
+const refreshMargin = 30 * time.Second
+
+type (
+	// sessions - current tokens of token of login, commands keep token of login,
+	// calls are sent with current access token, it is refreshed transparently
+	sessions struct {
+		lock   sync.Mutex
+		tokens map[string]*session
+		client pb.KeeperServiceClient
+	}
+
+	session struct {
+		access    string
+		refresh   string
+		expiresAt time.Time
+	}
+)
+
+func newSessions() *sessions {
+	return &sessions{tokens: make(map[string]*session)}
+}
+
+// add - session of login token
+func (s *sessions) add(login string, refresh string, expiresAt time.Time) {
+	if refresh == "" {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.tokens[login] = &session{access: login, refresh: refresh, expiresAt: expiresAt}
+}
+
+// drop - session of login token is removed, its tokens are revoked
+func (s *sessions) drop(login string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.tokens, login)
+}
+
+// current - access token of session, it is refreshed if it expires soon or force
+func (s *sessions) current(ctx context.Context, login string, force bool) (string, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	ses, ok := s.tokens[login]
+	if !ok {
+		return login, false, nil
+	}
+	if !force && (ses.expiresAt.IsZero() || time.Now().Add(refreshMargin).Before(ses.expiresAt)) {
+		return ses.access, true, nil
+	}
+	resp, err := s.client.RefreshToken(metadata.NewOutgoingContext(ctx, metadata.MD{}),
+		&pb.RefreshRequest{RefreshToken: ses.refresh})
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			delete(s.tokens, login)
+		}
+		return ses.access, true, err
+	}
+	ses.access = resp.GetToken()
+	ses.refresh = resp.GetRefreshToken()
+	ses.expiresAt = unixTime(resp.GetExpiresAt())
+	return ses.access, true, nil
+}
+
+// take - current tokens of session, session is removed
+func (s *sessions) take(ctx context.Context, login string) (string, string, error) {
+	access, _, err := s.current(ctx, login, false)
+	if err != nil {
+		return "", "", err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var refresh string
+	if ses, ok := s.tokens[login]; ok {
+		refresh = ses.refresh
+		delete(s.tokens, login)
+	}
+	return access, refresh, nil
+}
+
+// login - token of login in outgoing metadata
+func login(ctx context.Context) string {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		return ""
+	}
+	v := md.Get("authorization")
+	if len(v) == 0 {
+		return ""
+	}
+	return v[0]
+}
+
+func withToken(ctx context.Context, token string) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md.Set("authorization", token)
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// unary - call with current access token, call failed as unauthenticated is sent again after refresh
+func (s *sessions) unary(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	token := login(ctx)
+	if token == "" || method == pb.KeeperService_RefreshToken_FullMethodName {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	access, ok, err := s.current(ctx, token, false)
+	if err != nil && status.Code(err) != codes.Unauthenticated {
+		return err
+	}
+	err = invoker(withToken(ctx, access), method, req, reply, cc, opts...)
+	if !ok || status.Code(err) != codes.Unauthenticated {
+		return err
+	}
+	access, _, rerr := s.current(ctx, token, true)
+	if rerr != nil {
+		return err
+	}
+	return invoker(withToken(ctx, access), method, req, reply, cc, opts...)
+}
+
+// stream - stream with current access token, stream can not be sent again, token is refreshed before expiry
+func (s *sessions) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+	streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	token := login(ctx)
+	if token == "" {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+	access, _, err := s.current(ctx, token, false)
+	if err != nil && status.Code(err) != codes.Unauthenticated {
+		return nil, err
+	}
+	return streamer(withToken(ctx, access), desc, cc, method, opts...)
+}
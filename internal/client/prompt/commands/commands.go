@@ -4,14 +4,26 @@ package commands
 import (
 	"context"
 	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/4aleksei/gokeeper/internal/client/prompt/responses"
 	"github.com/4aleksei/gokeeper/internal/client/service"
+	"github.com/4aleksei/gokeeper/internal/client/transaction"
 	"github.com/4aleksei/gokeeper/internal/common/store"
+	"github.com/pterm/pterm"
 )
 
 var (
 	ErrParamsNotEnough = errors.New("error parameters not enough")
+	ErrBadTTL          = errors.New("error ttl is not positive duration, e.g. 24h")
+	ErrBadPermission   = errors.New("error permission is not ro or rw")
+	ErrBadRole         = errors.New("error role is not owner, admin, member or readonly")
+	ErrBadWait         = errors.New("error wait is not positive duration, e.g. 48h")
 )
 
 func CommandLogin(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
@@ -22,6 +34,15 @@ func CommandLogin(ctx context.Context, srv *service.HandleService, s ...string)
 	}
 
 	token, err := srv.SendLogin(ctx, s[1], s[2])
+	if errors.Is(err, transaction.ErrOTPRequired) {
+		var code string
+		if len(s) > 3 {
+			code = s[3]
+		} else {
+			code = askCode()
+		}
+		token, err = srv.SendCode(ctx, code)
+	}
 	if err != nil {
 		return responses.New(
 			responses.AddError(err),
@@ -33,7 +54,67 @@ func CommandLogin(ctx context.Context, srv *service.HandleService, s ...string)
 	)
 }
 
+// askCode - code of second factor asked from user
+var askCode = func() string {
+	code, _ := pterm.DefaultInteractiveTextInput.WithMask("*").Show("Code of authenticator app or recovery code")
+	return strings.TrimSpace(code)
+}
+
+// CommandEnroll2FA - with --code or --password enabled second factor is enrolled again
+func CommandEnroll2FA(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	s, code, err := option(s, "code")
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	s, password, err := option(s, "password")
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	if len(s) < 1 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	enrollment, err := srv.EnrollTOTP(ctx, s[0], code, password)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddList([]string{"secret " + enrollment.Secret, enrollment.URI, "confirm with Verify2FA code"}),
+	)
+}
+
+func CommandVerify2FA(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 2 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	codes, err := srv.VerifyTOTP(ctx, s[0], s[1])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddList(append([]string{"two-factor login enabled, recovery codes, each is used once:"}, codes...)),
+	)
+}
+
+// CommandRegister - with --recovery recovery key is shown once
 func CommandRegister(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	i := slices.Index(s, "--recovery")
+	if i >= 0 {
+		s = slices.Delete(s, i, i+1)
+	}
 	if len(s) < 3 {
 		return responses.New(
 			responses.AddError(ErrParamsNotEnough),
@@ -45,54 +126,347 @@ func CommandRegister(ctx context.Context, srv *service.HandleService, s ...strin
 			responses.AddError(err),
 		)
 	}
+	if i < 0 {
+		return responses.New(
+			responses.AddUserName(s[1]),
+			responses.AddToken(token),
+		)
+	}
+	recovery, err := srv.SetRecovery(ctx, token)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
 	return responses.New(
 		responses.AddUserName(s[1]),
 		responses.AddToken(token),
+		responses.AddNotes([]string{"recovery key " + recovery, "it is shown once, keep it offline, Recover resets password by it"}),
 	)
 }
 
-func CommandData(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+func CommandChangePassword(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 3 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+	token, err := srv.ChangePassword(ctx, s[0], s[1], s[2])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddUserName(srv.Name()),
+		responses.AddToken(token),
+	)
+}
+
+// CommandExportAccount - items of user to directory, export by default
+func CommandExportAccount(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 1 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+	dir := "export"
+	if len(s) > 1 {
+		dir = s[1]
+	}
+	items, err := srv.ExportAccount(ctx, s[0], dir)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	list := make([]string, 0, len(items)+1)
+	for _, item := range items {
+		line := fmt.Sprintf("%s %s %s", item.UUID, item.Type, item.MetaData)
+		if item.File != "" {
+			line += " -> " + item.File
+		}
+		list = append(list, line)
+	}
+	list = append(list, fmt.Sprintf("%d items exported to %s", len(items), filepath.Join(dir, "items.json")))
+	return responses.New(
+		responses.AddList(list),
+	)
+}
+
+// CommandDeleteAccount - token of prompt is cleared
+func CommandDeleteAccount(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 2 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+	err := srv.DeleteAccount(ctx, s[0], s[1])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddUserName(""),
+		responses.AddToken(""),
+	)
+}
+
+func CommandRecover(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
 	if len(s) < 4 {
 		return responses.New(
 			responses.AddError(ErrParamsNotEnough),
 		)
 	}
-	t, err := store.GetType(s[1])
+	var code string
+	if len(s) > 4 {
+		code = s[4]
+	}
+	token, err := srv.Recover(ctx, s[1], s[2], s[3], code)
 	if err != nil {
 		return responses.New(
 			responses.AddError(err),
 		)
 	}
+	return responses.New(
+		responses.AddUserName(s[1]),
+		responses.AddToken(token),
+	)
+}
+
+func CommandLogout(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	return commandLogout(ctx, srv, false, s...)
+}
+
+func CommandLogoutAll(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	return commandLogout(ctx, srv, true, s...)
+}
+
+// commandLogout - token of prompt is cleared
+func commandLogout(ctx context.Context, srv *service.HandleService, all bool, s ...string) *responses.Respond {
+	if len(s) < 1 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
 
-	uuid, err := srv.SendData(ctx, s[0], t, s[2], s[3])
+	err := srv.Logout(ctx, s[0], all)
 	if err != nil {
 		return responses.New(
 			responses.AddError(err),
 		)
 	}
 	return responses.New(
-		responses.AddUUID(uuid),
+		responses.AddUserName(""),
+		responses.AddToken(""),
 	)
 }
 
-func CommandGetData(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+// listOption - arguments without option --name a,b,c , values of option
+func listOption(s []string, name string) ([]string, []string, error) {
+	s, value, err := option(s, name)
+	if err != nil || value == "" {
+		return s, nil, err
+	}
+	return s, strings.Split(value, ","), nil
+}
+
+func CommandCreateToken(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	s, ttl, err := ttlOption(s)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	s, items, err := listOption(s, "items")
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	s, collections, err := listOption(s, "collections")
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	s, tags, err := listOption(s, "tags")
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	if len(s) < 3 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+	perm, ok := permissions[s[2]]
+	if !ok {
+		return responses.New(
+			responses.AddError(ErrBadPermission),
+		)
+	}
+
+	t, err := srv.CreateAPIToken(ctx, s[0], transaction.APITokenData{Name: s[1], Permission: perm, Items: items,
+		Collections: collections, Tags: tags, TTL: ttl})
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddList([]string{t.Secret, "token is shown once, keep it secret"}),
+	)
+}
+
+func CommandTokens(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 1 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	tokens, err := srv.APITokens(ctx, s[0])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	list := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		perm := "ro"
+		if t.Permission == store.PermReadWrite {
+			perm = "rw"
+		}
+		scope := "all items"
+		if len(t.Items) > 0 || len(t.Collections) > 0 || len(t.Tags) > 0 {
+			scope = fmt.Sprintf("items %v collections %v tags %v", t.Items, t.Collections, t.Tags)
+		}
+		expires, used := "never", "never"
+		if !t.ExpiresAt.IsZero() {
+			expires = t.ExpiresAt.Format(time.DateTime)
+		}
+		if !t.LastUsed.IsZero() {
+			used = t.LastUsed.Format(time.DateTime)
+		}
+		list = append(list, fmt.Sprintf("%s %s %s, expires %s, last used %s", t.Name, perm, scope, expires, used))
+	}
+	return responses.New(
+		responses.AddList(list),
+	)
+}
+
+func CommandRevokeToken(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
 	if len(s) < 2 {
 		return responses.New(
 			responses.AddError(ErrParamsNotEnough),
 		)
 	}
-	data, err := srv.GetData(ctx, s[0], s[1])
+
+	err := srv.RevokeAPIToken(ctx, s[0], s[1])
 	if err != nil {
 		return responses.New(
 			responses.AddError(err),
 		)
 	}
 	return responses.New(
-		responses.AddData(data.TypeData, data.Data, data.MetaData),
+		responses.AddList([]string{"api token " + s[1] + " is revoked"}),
 	)
 }
 
-func CommandUploadData(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+func CommandSessions(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 1 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	sessions, err := srv.Sessions(ctx, s[0])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	list := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		mark := ""
+		if session.Current {
+			mark = " *"
+		}
+		list = append(list, fmt.Sprintf("%s %s client %s from %s, login %s, last seen %s%s", session.ID, session.Device,
+			session.ClientVersion, session.Address, session.CreatedAt.Format(time.DateTime), session.LastSeen.Format(time.DateTime), mark))
+	}
+	return responses.New(
+		responses.AddList(list),
+	)
+}
+
+func CommandRevokeSession(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 2 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	err := srv.RevokeSession(ctx, s[0], s[1])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddList([]string{s[1] + " revoked"}),
+	)
+}
+
+// option - arguments without option --name value (or --name=value), empty - no option
+func option(s []string, name string) ([]string, string, error) {
+	for i, arg := range s {
+		value, ok := strings.CutPrefix(arg, "--"+name+"=")
+		next := i + 1
+		if !ok {
+			if arg != "--"+name {
+				continue
+			}
+			if next >= len(s) {
+				return nil, "", ErrParamsNotEnough
+			}
+			value = s[next]
+			next++
+		}
+		return append(s[:i:i], s[next:]...), value, nil
+	}
+	return s, "", nil
+}
+
+// ttlOption - arguments without option --ttl duration (or --ttl=duration), 0 - no option
+func ttlOption(s []string) ([]string, time.Duration, error) {
+	s, value, err := option(s, "ttl")
+	if err != nil || value == "" {
+		return s, 0, err
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return nil, 0, ErrBadTTL
+	}
+	return s, ttl, nil
+}
+
+func CommandData(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	s, ttl, err := ttlOption(s)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	s, collection, err := option(s, "collection")
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
 	if len(s) < 4 {
 		return responses.New(
 			responses.AddError(ErrParamsNotEnough),
@@ -105,7 +479,7 @@ func CommandUploadData(ctx context.Context, srv *service.HandleService, s ...str
 		)
 	}
 
-	uuid, err := srv.UploadData(ctx, s[0], t, s[2], s[3])
+	uuid, err := srv.SendData(ctx, s[0], t, s[2], s[3], ttl, collection)
 	if err != nil {
 		return responses.New(
 			responses.AddError(err),
@@ -116,14 +490,703 @@ func CommandUploadData(ctx context.Context, srv *service.HandleService, s ...str
 	)
 }
 
-func CommandDownloadData(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+func CommandGetData(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
 	if len(s) < 2 {
 		return responses.New(
 			responses.AddError(ErrParamsNotEnough),
 		)
 	}
+	data, err := srv.GetData(ctx, s[0], s[1])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	attachments := make([]string, 0, len(data.Attachments))
+	for _, a := range data.Attachments {
+		attachments = append(attachments, fmt.Sprintf("%s %s %d bytes", a.UUID, a.Name, a.Size))
+	}
+	return responses.New(
+		responses.AddData(data.TypeData, data.Data, data.MetaData),
+		responses.AddAttachments(attachments),
+	)
+}
+
+func CommandDeleteData(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 2 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+	uuids, err := srv.DeleteData(ctx, s[0], s[1])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	list := make([]string, 0, len(uuids))
+	for _, uuid := range uuids {
+		list = append(list, "Deleted "+uuid)
+	}
+	return responses.New(
+		responses.AddList(list),
+	)
+}
 
-	data, err := srv.DownloadData(ctx, s[0], s[1])
+func CommandUploadData(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	s, ttl, err := ttlOption(s)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	s, collection, err := option(s, "collection")
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	if len(s) < 4 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+	t, err := store.GetType(s[1])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+
+	var compression string
+	if len(s) > 4 {
+		compression = s[4]
+	}
+	uuid, err := srv.UploadData(ctx, s[0], t, s[2], s[3], compression, ttl, collection)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddUUID(uuid),
+	)
+}
+
+func CommandAttachData(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 3 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	uuid, err := srv.AttachData(ctx, s[0], s[1], s[2])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddUUID(uuid),
+	)
+}
+
+func CommandDownloadData(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 2 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	var length int64
+	if len(s) > 2 {
+		var err error
+		length, err = strconv.ParseInt(s[2], 10, 64)
+		if err != nil {
+			return responses.New(
+				responses.AddError(err),
+			)
+		}
+	}
+
+	data, err := srv.DownloadData(ctx, s[0], s[1], length)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddData(data.TypeData, data.Data, data.MetaData),
+	)
+}
+
+func CommandSearch(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 2 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	items, err := srv.Search(ctx, s[0], strings.Join(s[1:], " "))
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		list = append(list, fmt.Sprintf("%s [%s] score %d %s: %s", item.UUID, store.GetStringType(item.TypeData),
+			item.Score, strings.Join(item.Fields, ","), item.MetaData))
+	}
+	return responses.New(
+		responses.AddList(list),
+	)
+}
+
+func CommandUsage(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 1 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	usage, err := srv.Usage(ctx, s[0])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddList([]string{
+			"Items " + usageLimit(usage.Items, usage.QuotaItems),
+			"Bytes " + usageLimit(usage.Bytes, usage.QuotaBytes),
+			"Max upload " + usageLimit(usage.MaxUpload, 0),
+		}),
+	)
+}
+
+// usageLimit - used of limit, zero limit - unlimited
+func usageLimit(used int64, limit int64) string {
+	if limit == 0 {
+		if used == 0 {
+			return "unlimited"
+		}
+		return strconv.FormatInt(used, 10)
+	}
+	return fmt.Sprintf("%d of %d", used, limit)
+}
+
+func CommandUpdateData(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 3 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	err := srv.UpdateData(ctx, s[0], s[1], s[2])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddUUID(s[1]),
+	)
+}
+
+func CommandRotation(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 3 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+	days, err := strconv.Atoi(s[2])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	uuid := s[1]
+	if uuid == "default" {
+		uuid = ""
+	}
+
+	err = srv.SetRotation(ctx, s[0], uuid, days)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddList([]string{fmt.Sprintf("Rotation of %s every %d days", s[1], days)}),
+	)
+}
+
+func CommandDue(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 1 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+	var ahead int
+	if len(s) > 1 {
+		var err error
+		ahead, err = strconv.Atoi(s[1])
+		if err != nil {
+			return responses.New(
+				responses.AddError(err),
+			)
+		}
+	}
+
+	items, err := srv.Due(ctx, s[0], time.Duration(ahead)*24*time.Hour)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		list = append(list, fmt.Sprintf("%s %s changed %s due %s (every %d days)", item.UUID, item.MetaData,
+			item.ChangedAt.Format(time.DateOnly), item.DueAt.Format(time.DateOnly), item.Days))
+	}
+	return responses.New(
+		responses.AddList(list),
+	)
+}
+
+// permissions - access of recipient to shared item
+var permissions = map[string]int{
+	"ro": store.PermRead,
+	"rw": store.PermReadWrite,
+}
+
+func CommandShare(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 3 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+	perm := store.PermRead
+	if len(s) > 3 {
+		var ok bool
+		perm, ok = permissions[s[3]]
+		if !ok {
+			return responses.New(
+				responses.AddError(ErrBadPermission),
+			)
+		}
+	}
+
+	err := srv.Share(ctx, s[0], s[1], s[2], perm)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddList([]string{fmt.Sprintf("Shared %s with %s", s[1], s[2])}),
+	)
+}
+
+func CommandUnshare(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 3 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	err := srv.Unshare(ctx, s[0], s[1], s[2])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddList([]string{fmt.Sprintf("Unshared %s with %s", s[1], s[2])}),
+	)
+}
+
+func CommandShared(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 1 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	items, err := srv.Shared(ctx, s[0])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		perm := "ro"
+		if item.Permission == store.PermReadWrite {
+			perm = "rw"
+		}
+		list = append(list, fmt.Sprintf("%s %s %s from %s (%s)", item.UUID, store.GetStringType(item.TypeData), item.MetaData,
+			item.Owner, perm))
+	}
+	return responses.New(
+		responses.AddList(list),
+	)
+}
+
+func CommandGetShared(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 2 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+	data, err := srv.GetShared(ctx, s[0], s[1])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddData(data.TypeData, data.Data, data.MetaData),
+	)
+}
+
+// roles - roles of members of organization
+var roles = []string{"owner", "admin", "member", "readonly"}
+
+func CommandOrg(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 2 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	id, err := srv.CreateOrg(ctx, s[0], s[1])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddUUID(id),
+	)
+}
+
+func CommandMember(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 4 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+	role := slices.Index(roles, s[3])
+	if role < 0 {
+		return responses.New(
+			responses.AddError(ErrBadRole),
+		)
+	}
+
+	err := srv.SetMember(ctx, s[0], s[1], s[2], role, false)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddList([]string{fmt.Sprintf("%s is %s", s[2], s[3])}),
+	)
+}
+
+func CommandRemoveMember(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 3 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	err := srv.SetMember(ctx, s[0], s[1], s[2], 0, true)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddList([]string{fmt.Sprintf("%s removed", s[2])}),
+	)
+}
+
+func CommandMembers(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 2 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	members, err := srv.Members(ctx, s[0], s[1])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	list := make([]string, 0, len(members))
+	for _, member := range members {
+		list = append(list, fmt.Sprintf("%s %s", member.Name, roles[member.Role]))
+	}
+	return responses.New(
+		responses.AddList(list),
+	)
+}
+
+func CommandCollection(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 3 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	uuid, err := srv.CreateCollection(ctx, s[0], s[1], s[2])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddUUID(uuid),
+	)
+}
+
+func CommandCollections(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 1 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	collections, err := srv.Collections(ctx, s[0])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	list := make([]string, 0, len(collections))
+	for _, c := range collections {
+		list = append(list, fmt.Sprintf("%s %s org %s (%s, key v%d)", c.UUID, c.Name, c.Org, roles[c.Role], c.Version))
+	}
+	return responses.New(
+		responses.AddList(list),
+	)
+}
+
+func CommandTeam(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 2 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	items, err := srv.CollectionItems(ctx, s[0], s[1])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		list = append(list, fmt.Sprintf("%s %s %s by %s", item.UUID, store.GetStringType(item.TypeData), item.MetaData, item.Owner))
+	}
+	return responses.New(
+		responses.AddList(list),
+	)
+}
+
+func CommandSecret(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	s, ttl, err := ttlOption(s)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	if len(s) < 2 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+	var views int
+	if len(s) > 2 {
+		views, err = strconv.Atoi(s[2])
+		if err != nil {
+			return responses.New(
+				responses.AddError(err),
+			)
+		}
+	}
+
+	link, expires, err := srv.CreateSecret(ctx, s[0], s[1], views, ttl)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddList([]string{link, "expires " + expires.Format(time.DateTime)}),
+	)
+}
+
+func CommandRedeem(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 2 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	secret, left, err := srv.RedeemSecret(ctx, s[1])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddList([]string{secret, fmt.Sprintf("views left %d", left)}),
+	)
+}
+
+// emergencyStates - states of emergency access
+var emergencyStates = []string{"invited", "requested", "approved", "denied", "expired"}
+
+func CommandInvite(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	s, value, err := option(s, "wait")
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	if len(s) < 2 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+	var wait time.Duration
+	if value != "" {
+		wait, err = time.ParseDuration(value)
+		if err != nil || wait <= 0 {
+			return responses.New(
+				responses.AddError(ErrBadWait),
+			)
+		}
+	}
+
+	e, err := srv.InviteEmergency(ctx, s[0], s[1], wait)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	return responses.New(
+		responses.AddUUID(e.ID),
+	)
+}
+
+// commandEmergencyStep - step of emergency access by id
+func commandEmergencyStep(ctx context.Context, srv *service.HandleService, step int, s ...string) *responses.Respond {
+	if len(s) < 2 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	e, err := srv.EmergencyStep(ctx, s[0], s[1], step)
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	if step == transaction.EmergencyRevoke {
+		return responses.New(
+			responses.AddList([]string{e.ID + " revoked"}),
+		)
+	}
+	return responses.New(
+		responses.AddList([]string{emergencyLine(e)}),
+	)
+}
+
+func CommandRequestAccess(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	return commandEmergencyStep(ctx, srv, transaction.EmergencyRequest, s...)
+}
+
+func CommandApprove(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	return commandEmergencyStep(ctx, srv, transaction.EmergencyApprove, s...)
+}
+
+func CommandDeny(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	return commandEmergencyStep(ctx, srv, transaction.EmergencyDeny, s...)
+}
+
+func CommandRevokeAccess(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	return commandEmergencyStep(ctx, srv, transaction.EmergencyRevoke, s...)
+}
+
+func emergencyLine(e *transaction.Emergency) string {
+	line := fmt.Sprintf("%s %s -> %s %s (wait %s)", e.ID, e.Owner, e.Contact, emergencyStates[e.State], e.Wait)
+	switch {
+	case e.State == store.EmergencyRequested:
+		line += " granted at " + e.RequestedAt.Add(e.Wait).Format(time.DateTime)
+	case !e.GrantedAt.IsZero():
+		line += " granted " + e.GrantedAt.Format(time.DateTime)
+	}
+	return line
+}
+
+func CommandEmergency(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 1 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	items, err := srv.Emergencies(ctx, s[0])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	list := make([]string, 0, len(items))
+	for i := range items {
+		list = append(list, emergencyLine(&items[i]))
+	}
+	return responses.New(
+		responses.AddList(list),
+	)
+}
+
+func CommandViewAccess(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 2 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+
+	items, err := srv.EmergencyItems(ctx, s[0], s[1])
+	if err != nil {
+		return responses.New(
+			responses.AddError(err),
+		)
+	}
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		list = append(list, fmt.Sprintf("%s %s %s", item.UUID, store.GetStringType(item.TypeData), item.MetaData))
+	}
+	return responses.New(
+		responses.AddList(list),
+	)
+}
+
+func CommandViewItem(ctx context.Context, srv *service.HandleService, s ...string) *responses.Respond {
+	if len(s) < 3 {
+		return responses.New(
+			responses.AddError(ErrParamsNotEnough),
+		)
+	}
+	data, err := srv.GetEmergencyItem(ctx, s[0], s[1], s[2])
 	if err != nil {
 		return responses.New(
 			responses.AddError(err),
@@ -102,6 +102,9 @@ func (p *Prompt) getResponse(resp *responses.Respond) {
 	if t, ok := resp.GetToken(); ok {
 		p.token = t
 		p.name = resp.GetMetaData()
+		for _, line := range resp.GetNotes() {
+			pterm.Println(line)
+		}
 		return
 	}
 
@@ -109,6 +112,9 @@ func (p *Prompt) getResponse(resp *responses.Respond) {
 		pterm.Printfln("Load Data :%s", data)
 		pterm.Printfln("Metadata :%s", resp.GetMetaData())
 		pterm.Printfln("Typedata :%s", store.GetStringType(resp.GetType()))
+		for _, a := range resp.GetAttachments() {
+			pterm.Printfln("Attachment :%s", a)
+		}
 		return
 	}
 
@@ -116,4 +122,14 @@ func (p *Prompt) getResponse(resp *responses.Respond) {
 		pterm.Printfln("Data UUID :%s", data)
 		return
 	}
+
+	if list, ok := resp.GetList(); ok {
+		if len(list) == 0 {
+			pterm.Println("Empty")
+		}
+		for _, line := range list {
+			pterm.Println(line)
+		}
+		return
+	}
 }
@@ -6,6 +6,7 @@ const (
 	UserData
 	StreamUserData
 	UserDataUUID
+	ListData
 )
 
 type (
@@ -15,6 +16,7 @@ type (
 		userTypeData int
 		data         string
 		metadata     string
+		list         []string
 		err          error
 	}
 )
@@ -48,6 +50,27 @@ func AddData(t int, data string, metadata string) func(*Respond) {
 	}
 }
 
+// AddNotes - lines shown with token, e.g. secret shown once
+func AddNotes(list []string) func(*Respond) {
+	return func(r *Respond) {
+		r.list = list
+	}
+}
+
+func AddList(list []string) func(*Respond) {
+	return func(r *Respond) {
+		r.list = list
+		r.typeData = ListData
+	}
+}
+
+// AddAttachments - lines listed after data
+func AddAttachments(list []string) func(*Respond) {
+	return func(r *Respond) {
+		r.list = list
+	}
+}
+
 func AddError(err error) func(*Respond) {
 	return func(r *Respond) {
 		r.err = err
@@ -83,6 +106,27 @@ func (r *Respond) GetUUID() (string, bool) {
 	return "", false
 }
 
+func (r *Respond) GetList() ([]string, bool) {
+	if r.typeData == ListData {
+		return r.list, true
+	}
+	return nil, false
+}
+
+func (r *Respond) GetNotes() []string {
+	if r.typeData == TokenData {
+		return r.list
+	}
+	return nil
+}
+
+func (r *Respond) GetAttachments() []string {
+	if r.typeData == UserData {
+		return r.list
+	}
+	return nil
+}
+
 func (r *Respond) GetMetaData() string {
 	return r.metadata
 }
@@ -3,59 +3,888 @@ package service
 
 import (
 	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/4aleksei/gokeeper/internal/client/grpcclient"
 	"github.com/4aleksei/gokeeper/internal/client/transaction"
-	"github.com/google/uuid"
+	"github.com/4aleksei/gokeeper/internal/client/vault"
+	"github.com/4aleksei/gokeeper/internal/common/aescoder"
+	"github.com/4aleksei/gokeeper/internal/common/search"
+	"github.com/4aleksei/gokeeper/internal/common/store"
+	"github.com/4aleksei/gokeeper/internal/common/streams/compressors/codecs"
+	"github.com/4aleksei/gokeeper/internal/common/utils/random"
+	"github.com/4aleksei/gokeeper/internal/common/utils/retry"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+const chunkSize int = 4096
+
 type (
 	HandleService struct {
 		client *grpcclient.KeeperServiceService
+		e2e    bool
+		vault  *vault.Vault
+		// private - rsa key of user, opens item keys shared to user
+		private *rsa.PrivateKey
+		// pending - login waiting for code of second factor
+		pending *challenge
+		// name - user of login, master key is derived from it
+		name string
+	}
+
+	challenge struct {
+		challenge string
+		name      string
+		master    []byte
+	}
+)
+
+func New(c *grpcclient.KeeperServiceService, e2e bool) *HandleService {
+	return &HandleService{
+		client: c,
+		e2e:    e2e,
+	}
+}
+
+func (s *HandleService) SendRegister(ctx context.Context, name string, pass string) (string, error) {
+	var vaultKey string
+	var v *vault.Vault
+	master, err := vault.MasterKey(name, pass)
+	if err != nil {
+		return "", err
+	}
+	if s.e2e {
+		v, err = vault.New()
+		if err != nil {
+			return "", err
+		}
+		vaultKey, err = v.Wrap(master)
+		if err != nil {
+			return "", err
+		}
+	}
+	req := &transaction.Request{
+		Command: transaction.UserRegister{User: transaction.User{Name: name, Password: pass, VaultKey: vaultKey}},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	str, ok := resp.Resp.(transaction.TokenUser)
+	if !ok {
+		return "", transaction.ErrBadTypeResponse
+	}
+	s.vault = v
+	s.name = name
+	err = s.setKeys(ctx, str.Token, master)
+	if err != nil {
+		return "", err
+	}
+	return str.Token, nil
+}
+
+// SendLogin - login by name and password, ErrOTPRequired - login waits for SendCode
+func (s *HandleService) SendLogin(ctx context.Context, name string, pass string) (string, error) {
+	master, err := vault.MasterKey(name, pass)
+	if err != nil {
+		return "", err
+	}
+	return s.login(ctx, name, transaction.User{Name: name, Password: pass}, master)
+}
+
+// SendCode - second step of login, totp code or recovery code
+func (s *HandleService) SendCode(ctx context.Context, code string) (string, error) {
+	if s.pending == nil {
+		return "", transaction.ErrNoChallenge
+	}
+	return s.login(ctx, s.pending.name, transaction.User{Challenge: s.pending.challenge, OTP: code}, s.pending.master)
+}
+
+func (s *HandleService) login(ctx context.Context, name string, user transaction.User, master []byte) (string, error) {
+	req := &transaction.Request{
+		Command: transaction.UserLogin{User: user},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	str, ok := resp.Resp.(transaction.TokenUser)
+	if !ok {
+		return "", transaction.ErrBadTypeResponse
+	}
+	if str.Challenge != "" {
+		s.pending = &challenge{challenge: str.Challenge, name: name, master: master}
+		return "", transaction.ErrOTPRequired
+	}
+	s.pending = nil
+	s.name = name
+	if s.e2e {
+		s.vault, err = vault.Unwrap(master, str.VaultKey)
+		if err != nil {
+			return "", err
+		}
+	}
+	if str.PublicKey == "" {
+		// user registered before sharing
+		err = s.setKeys(ctx, str.Token, master)
+	} else {
+		s.private, err = vault.OpenPrivateKey(master, str.PrivateKey)
+	}
+	if err != nil {
+		return "", err
+	}
+	return str.Token, nil
+}
+
+// wrapKeys - vault key and private key of user wrapped by key, empty - no such key
+func (s *HandleService) wrapKeys(key []byte) (string, string, error) {
+	var vaultKey, privateKey string
+	var err error
+	if s.vault != nil {
+		vaultKey, err = s.vault.Wrap(key)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	if s.private != nil {
+		privateKey, err = vault.WrapPrivateKey(key, s.private)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	return vaultKey, privateKey, nil
+}
+
+// Name - user of login
+func (s *HandleService) Name() string {
+	return s.name
+}
+
+// ChangePassword - keys of user are wrapped by new master key, all sessions are revoked, token of new session is returned
+func (s *HandleService) ChangePassword(ctx context.Context, token string, old string, pass string) (string, error) {
+	if s.name == "" {
+		return "", transaction.ErrNoLogin
+	}
+	master, err := vault.MasterKey(s.name, pass)
+	if err != nil {
+		return "", err
+	}
+	vaultKey, privateKey, err := s.wrapKeys(master)
+	if err != nil {
+		return "", err
+	}
+	req := &transaction.Request{
+		Command: transaction.ChangePasswordData{Token: transaction.TokenUser{Token: token}, Old: old, New: pass,
+			VaultKey: vaultKey, PrivateKey: privateKey},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	str, ok := resp.Resp.(transaction.TokenUser)
+	if !ok {
+		return "", transaction.ErrBadTypeResponse
+	}
+	return str.Token, nil
+}
+
+// SetRecovery - new recovery key of user, keys of user are wrapped by it, it is shown once
+func (s *HandleService) SetRecovery(ctx context.Context, token string) (string, error) {
+	recovery, err := vault.NewRecoveryKey()
+	if err != nil {
+		return "", err
+	}
+	auth, key, err := vault.RecoveryKeys(recovery)
+	if err != nil {
+		return "", err
+	}
+	vaultKey, privateKey, err := s.wrapKeys(key)
+	if err != nil {
+		return "", err
+	}
+	req := &transaction.Request{
+		Command: transaction.SetRecoveryData{Token: transaction.TokenUser{Token: token}, Recovery: auth,
+			VaultKey: vaultKey, PrivateKey: privateKey},
+	}
+	_, err = s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return recovery, nil
+}
+
+// Recover - new password of user by recovery key, keys of user are opened by recovery key and wrapped by new master key,
+// code - totp code or recovery code of second factor if it is enabled
+func (s *HandleService) Recover(ctx context.Context, name string, recovery string, pass string, code string) (string, error) {
+	auth, key, err := vault.RecoveryKeys(recovery)
+	if err != nil {
+		return "", err
+	}
+	req := &transaction.Request{
+		Command: transaction.OpenRecoveryData{Name: name, Recovery: auth},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	keys, ok := resp.Resp.(transaction.RecoveryKeys)
+	if !ok {
+		return "", transaction.ErrBadTypeResponse
+	}
+	s.vault, s.private = nil, nil
+	if keys.VaultKey != "" {
+		s.vault, err = vault.Unwrap(key, keys.VaultKey)
+		if err != nil {
+			return "", err
+		}
+	}
+	if keys.PrivateKey != "" {
+		s.private, err = vault.OpenPrivateKey(key, keys.PrivateKey)
+		if err != nil {
+			return "", err
+		}
+	}
+	master, err := vault.MasterKey(name, pass)
+	if err != nil {
+		return "", err
+	}
+	vaultKey, privateKey, err := s.wrapKeys(master)
+	if err != nil {
+		return "", err
+	}
+	req = &transaction.Request{
+		Command: transaction.RecoverData{Name: name, Recovery: auth, Password: pass, VaultKey: vaultKey, PrivateKey: privateKey,
+			OTP: code},
+	}
+	resp, err = s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	str, ok := resp.Resp.(transaction.TokenUser)
+	if !ok {
+		return "", transaction.ErrBadTypeResponse
+	}
+	s.name = name
+	return str.Token, nil
+}
+
+// Logout - token of session is revoked, all - all sessions of user are revoked, keys of user are dropped
+func (s *HandleService) Logout(ctx context.Context, token string, all bool) error {
+	req := &transaction.Request{
+		Command: transaction.LogoutData{Token: transaction.TokenUser{Token: token}, All: all},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return err
+	}
+	_, ok := resp.Resp.(transaction.TokenUser)
+	if !ok {
+		return transaction.ErrBadTypeResponse
+	}
+	s.vault = nil
+	s.private = nil
+	s.name = ""
+	return nil
+}
+
+// DeleteAccount - user is deleted with all items, password confirms deletion
+func (s *HandleService) DeleteAccount(ctx context.Context, token string, password string) error {
+	req := &transaction.Request{
+		Command: transaction.DeleteAccountData{Token: transaction.TokenUser{Token: token}, Password: password},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return err
+	}
+	_, ok := resp.Resp.(transaction.TokenUser)
+	if !ok {
+		return transaction.ErrBadTypeResponse
+	}
+	s.vault = nil
+	s.private = nil
+	s.name = ""
+	return nil
+}
+
+// CreateAPIToken - named token for automation, token is shown once,
+// in end-to-end mode tags are sent as blind tokens, server matches them with tokens of items
+func (s *HandleService) CreateAPIToken(ctx context.Context, token string, t transaction.APITokenData) (*transaction.APIToken, error) {
+	t.Token = transaction.TokenUser{Token: token}
+	if s.e2e && len(t.Tags) > 0 {
+		if s.vault == nil {
+			return nil, vault.ErrNoVaultKey
+		}
+		tokens, err := s.vault.Tokens(strings.Join(t.Tags, " "))
+		if err != nil {
+			return nil, err
+		}
+		t.Tags = tokens
+	}
+	req := &transaction.Request{
+		Command: t,
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := resp.Resp.(transaction.APIToken)
+	if !ok {
+		return nil, transaction.ErrBadTypeResponse
+	}
+	return &str, nil
+}
+
+func (s *HandleService) APITokens(ctx context.Context, token string) ([]transaction.APIToken, error) {
+	req := &transaction.Request{
+		Command: transaction.APITokensData{Token: transaction.TokenUser{Token: token}},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := resp.Resp.(transaction.APITokens)
+	if !ok {
+		return nil, transaction.ErrBadTypeResponse
+	}
+	return str.Items, nil
+}
+
+func (s *HandleService) RevokeAPIToken(ctx context.Context, token string, name string) error {
+	req := &transaction.Request{
+		Command: transaction.RevokeAPITokenData{Token: transaction.TokenUser{Token: token}, Name: name},
+	}
+	_, err := s.client.SendSingleCommand(ctx, req)
+	return err
+}
+
+// EnrollTOTP - secret of second factor and its otpauth URI for authenticator app,
+// enabled second factor is enrolled again with its current code or password
+func (s *HandleService) EnrollTOTP(ctx context.Context, token string, code string, password string) (*transaction.Enrollment, error) {
+	req := &transaction.Request{
+		Command: transaction.EnrollData{Token: transaction.TokenUser{Token: token}, Code: code, Password: password},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := resp.Resp.(transaction.Enrollment)
+	if !ok {
+		return nil, transaction.ErrBadTypeResponse
+	}
+	return &str, nil
+}
+
+// VerifyTOTP - code of authenticator app enables second factor, recovery codes are shown once
+func (s *HandleService) VerifyTOTP(ctx context.Context, token string, code string) ([]string, error) {
+	req := &transaction.Request{
+		Command: transaction.VerifyTOTPData{Token: transaction.TokenUser{Token: token}, Code: code},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := resp.Resp.(transaction.RecoveryCodes)
+	if !ok {
+		return nil, transaction.ErrBadTypeResponse
+	}
+	return str.Codes, nil
+}
+
+// Sessions - devices logged in as user
+func (s *HandleService) Sessions(ctx context.Context, token string) ([]transaction.Session, error) {
+	req := &transaction.Request{
+		Command: transaction.SessionsData{Token: transaction.TokenUser{Token: token}},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := resp.Resp.(transaction.Sessions)
+	if !ok {
+		return nil, transaction.ErrBadTypeResponse
+	}
+	return str.Items, nil
+}
+
+// RevokeSession - tokens of session are rejected, e.g. of lost device
+func (s *HandleService) RevokeSession(ctx context.Context, token string, id string) error {
+	req := &transaction.Request{
+		Command: transaction.RevokeSessionData{Token: transaction.TokenUser{Token: token}, ID: id},
+	}
+	_, err := s.client.SendSingleCommand(ctx, req)
+	return err
+}
+
+// setKeys - new rsa keys of user, public key lets other users share items
+func (s *HandleService) setKeys(ctx context.Context, token string, master []byte) error {
+	prv, public, private, err := vault.NewKeyPair(master)
+	if err != nil {
+		return err
+	}
+	req := &transaction.Request{
+		Command: transaction.KeysData{Token: transaction.TokenUser{Token: token}, PublicKey: public, PrivateKey: private},
+	}
+	_, err = s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return err
+	}
+	s.private = prv
+	return nil
+}
+
+// Share - item is shared with recipient, item key is wrapped by public key of recipient
+func (s *HandleService) Share(ctx context.Context, token string, uuid string, recipient string, perm int) error {
+	if s.e2e {
+		return transaction.ErrShareE2E
+	}
+	req := &transaction.Request{
+		Command: transaction.ShareData{Token: transaction.TokenUser{Token: token}, UUID: transaction.UUIDData{UUID: uuid},
+			Recipient: recipient, Permission: perm},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return err
+	}
+	_, ok := resp.Resp.(transaction.SharedKey)
+	if !ok {
+		return transaction.ErrBadTypeResponse
+	}
+	return nil
+}
+
+// Unshare - access of recipient to item is revoked
+func (s *HandleService) Unshare(ctx context.Context, token string, uuid string, recipient string) error {
+	req := &transaction.Request{
+		Command: transaction.UnshareData{Token: transaction.TokenUser{Token: token}, UUID: transaction.UUIDData{UUID: uuid}, Recipient: recipient},
+	}
+	_, err := s.client.SendSingleCommand(ctx, req)
+	return err
+}
+
+// Shared - items of other users shared with user
+func (s *HandleService) Shared(ctx context.Context, token string) ([]transaction.SharedItem, error) {
+	req := &transaction.Request{
+		Command: transaction.SharedData{Token: transaction.TokenUser{Token: token}},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := resp.Resp.(transaction.SharedItems)
+	if !ok {
+		return nil, transaction.ErrBadTypeResponse
+	}
+	return str.Items, nil
+}
+
+// GetShared - item shared with user, it is opened by item key wrapped by public key of user
+func (s *HandleService) GetShared(ctx context.Context, token string, uuid string) (*transaction.UserData, error) {
+	items, err := s.Shared(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.UUID == uuid {
+			return s.openItem(ctx, token, uuid, item.WrappedKey)
+		}
+	}
+	return nil, transaction.ErrNotShared
+}
+
+// openItem - item encrypted by item key is fetched and opened by client, wrapped - item key wrapped for user
+func (s *HandleService) openItem(ctx context.Context, token string, uuid string, wrapped string) (*transaction.UserData, error) {
+	if s.private == nil {
+		return nil, transaction.ErrNoPrivateKey
+	}
+	key, err := aescoder.UnwrapFor(s.private, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	req := &transaction.Request{
+		Command: transaction.GetSealedData{Token: transaction.TokenUser{Token: token}, UUID: transaction.UUIDData{UUID: uuid}},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := resp.Resp.(transaction.SealedItem)
+	if !ok {
+		return nil, transaction.ErrBadTypeResponse
+	}
+	data, err := key.OpenOne(str.Data)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := key.OpenOne(str.MetaData)
+	if err != nil {
+		return nil, err
+	}
+	return &transaction.UserData{TypeData: str.TypeData, Data: string(data), MetaData: string(meta)}, nil
+}
+
+// sealMetaData - in end-to-end mode metadata is encrypted and blind indexed by client
+func (s *HandleService) sealMetaData(metadata string) (string, []string, error) {
+	if !s.e2e {
+		return metadata, nil, nil
+	}
+	if s.vault == nil {
+		return "", nil, vault.ErrNoVaultKey
+	}
+	tokens, err := s.vault.Tokens(metadata)
+	if err != nil {
+		return "", nil, err
+	}
+	enc, err := s.vault.Encrypt(metadata)
+	if err != nil {
+		return "", nil, err
+	}
+	return enc, tokens, nil
+}
+
+func (s *HandleService) seal(data string) (string, error) {
+	if !s.e2e {
+		return data, nil
+	}
+	if s.vault == nil {
+		return "", vault.ErrNoVaultKey
+	}
+	return s.vault.Encrypt(data)
+}
+
+func (s *HandleService) open(data string) (string, error) {
+	if !s.e2e {
+		return data, nil
+	}
+	if s.vault == nil {
+		return "", vault.ErrNoVaultKey
+	}
+	return s.vault.Decrypt(data)
+}
+
+// SendData - add item, ttl - lifetime of item, 0 - never expires, collection - team vault of item, empty - personal item
+func (s *HandleService) SendData(ctx context.Context, token string, typdata int, data string, metadata string, ttl time.Duration,
+	collection string) (string, error) {
+	if s.e2e && collection != "" {
+		return "", transaction.ErrCollectionE2E
+	}
+	metadata, tokens, err := s.sealMetaData(metadata)
+	if err != nil {
+		return "", err
+	}
+	data, err = s.seal(data)
+	if err != nil {
+		return "", err
+	}
+	req := &transaction.Request{
+		Command: transaction.UserData{Token: transaction.TokenUser{Token: token}, TypeData: typdata, Data: data, MetaData: metadata, Tokens: tokens,
+			ExpiresAt: expiresAt(ttl), Collection: collection},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	str, ok := resp.Resp.(transaction.UUIDData)
+	if !ok {
+		return "", transaction.ErrBadTypeResponse
+	}
+	return str.UUID, nil
+}
+
+func (s *HandleService) GetData(ctx context.Context, token string, uuid string) (*transaction.UserData, error) {
+	req := &transaction.Request{
+		Command: transaction.GetUserData{Token: transaction.TokenUser{Token: token}, UUID: transaction.UUIDData{UUID: uuid}},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := resp.Resp.(transaction.UserData)
+	if !ok {
+		return nil, transaction.ErrBadTypeResponse
+	}
+	str.Data, err = s.open(str.Data)
+	if err != nil {
+		return nil, err
+	}
+	str.MetaData, err = s.open(str.MetaData)
+	if err != nil {
+		return nil, err
+	}
+	for i := range str.Attachments {
+		str.Attachments[i].Name, err = s.open(str.Attachments[i].Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &str, nil
+}
+
+func (s *HandleService) DeleteData(ctx context.Context, token string, uuid string) ([]string, error) {
+	req := &transaction.Request{
+		Command: transaction.DeleteUserData{Token: transaction.TokenUser{Token: token}, UUID: transaction.UUIDData{UUID: uuid}},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := resp.Resp.(transaction.DeletedData)
+	if !ok {
+		return nil, transaction.ErrBadTypeResponse
+	}
+	return str.UUIDs, nil
+}
+
+// UpdateData - replace data of item, metadata is kept
+func (s *HandleService) UpdateData(ctx context.Context, token string, uuid string, data string) error {
+	data, err := s.seal(data)
+	if err != nil {
+		return err
+	}
+	req := &transaction.Request{
+		Command: transaction.UpdateUserData{Token: transaction.TokenUser{Token: token}, UUID: transaction.UUIDData{UUID: uuid}, Data: data},
+	}
+	_, err = s.client.SendSingleCommand(ctx, req)
+	return err
+}
+
+// SetRotation - rotation policy in days of login item or of login items of collection uuid, empty uuid - default policy of user
+func (s *HandleService) SetRotation(ctx context.Context, token string, uuid string, days int) error {
+	req := &transaction.Request{
+		Command: transaction.RotationData{Token: transaction.TokenUser{Token: token}, UUID: transaction.UUIDData{UUID: uuid}, Days: days},
+	}
+	_, err := s.client.SendSingleCommand(ctx, req)
+	return err
+}
+
+// Due - login items due for password rotation within ahead from now
+func (s *HandleService) Due(ctx context.Context, token string, ahead time.Duration) ([]transaction.DueItem, error) {
+	req := &transaction.Request{
+		Command: transaction.DueData{Token: transaction.TokenUser{Token: token}, At: time.Now().Add(ahead)},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := resp.Resp.(transaction.DueItems)
+	if !ok {
+		return nil, transaction.ErrBadTypeResponse
+	}
+	for i := range str.Items {
+		str.Items[i].MetaData, err = s.open(str.Items[i].MetaData)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return str.Items, nil
+}
+
+// Usage - stored items and bytes of user and quotas of server
+func (s *HandleService) Usage(ctx context.Context, token string) (*transaction.Usage, error) {
+	req := &transaction.Request{
+		Command: transaction.UsageData{Token: transaction.TokenUser{Token: token}},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := resp.Resp.(transaction.Usage)
+	if !ok {
+		return nil, transaction.ErrBadTypeResponse
+	}
+	return &str, nil
+}
+
+// secretLink - scheme and host of link of secret, key of secret is fragment, it is not sent to server
+const secretLink = "gokeeper://secret/"
+
+// CreateSecret - one-time link of secret, secret is sealed by random key of link fragment,
+// views - view limit, 0 - one view, ttl - lifetime, 0 - default of server
+func (s *HandleService) CreateSecret(ctx context.Context, token string, secret string, views int, ttl time.Duration) (string, time.Time, error) {
+	key, err := random.GenerateRandom(32)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	sealed, err := aescoder.Seal(key, []byte(secret))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req := &transaction.Request{
+		Command: transaction.SecretData{Token: transaction.TokenUser{Token: token}, Ciphertext: hex.EncodeToString(sealed),
+			MaxViews: views, ExpiresAt: expiresAt(ttl)},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	str, ok := resp.Resp.(transaction.SecretID)
+	if !ok {
+		return "", time.Time{}, transaction.ErrBadTypeResponse
+	}
+	return secretLink + str.ID + "#" + hex.EncodeToString(key), str.ExpiresAt, nil
+}
+
+// RedeemSecret - secret of link, no login is needed, views left after this view
+func (s *HandleService) RedeemSecret(ctx context.Context, link string) (string, int, error) {
+	id, fragment, ok := strings.Cut(strings.TrimPrefix(link, secretLink), "#")
+	if !ok || id == "" || !strings.HasPrefix(link, secretLink) {
+		return "", 0, transaction.ErrBadLink
 	}
-)
+	key, err := hex.DecodeString(fragment)
+	if err != nil {
+		return "", 0, transaction.ErrBadLink
+	}
+	resp, err := s.client.SendSingleCommand(ctx, &transaction.Request{Command: transaction.RedeemData{ID: id}})
+	if err != nil {
+		return "", 0, err
+	}
+	str, ok := resp.Resp.(transaction.RedeemedSecret)
+	if !ok {
+		return "", 0, transaction.ErrBadTypeResponse
+	}
+	sealed, err := hex.DecodeString(str.Ciphertext)
+	if err != nil {
+		return "", 0, err
+	}
+	secret, err := aescoder.Open(key, sealed)
+	if err != nil {
+		return "", 0, err
+	}
+	return string(secret), str.ViewsLeft, nil
+}
 
-func New(c *grpcclient.KeeperServiceService) *HandleService {
-	return &HandleService{
-		client: c,
+// InviteEmergency - trusted contact may request access to vault, wait - period to deny request, 0 - default of server
+func (s *HandleService) InviteEmergency(ctx context.Context, token string, contact string, wait time.Duration) (*transaction.Emergency, error) {
+	if s.e2e {
+		return nil, transaction.ErrEmergencyE2E
+	}
+	req := &transaction.Request{
+		Command: transaction.EmergencyInviteData{Token: transaction.TokenUser{Token: token}, Contact: contact, Wait: wait},
 	}
+	return s.emergency(ctx, req)
 }
 
-func (s *HandleService) SendRegister(ctx context.Context, name string, pass string) (string, error) {
+// EmergencyStep - request of contact, approval, denial or revocation of emergency access
+func (s *HandleService) EmergencyStep(ctx context.Context, token string, id string, step int) (*transaction.Emergency, error) {
 	req := &transaction.Request{
-		Command: transaction.UserRegister{User: transaction.User{Name: name, Password: pass}},
+		Command: transaction.EmergencyStepData{Token: transaction.TokenUser{Token: token}, ID: id, Step: step},
 	}
+	return s.emergency(ctx, req)
+}
+
+func (s *HandleService) emergency(ctx context.Context, req *transaction.Request) (*transaction.Emergency, error) {
 	resp, err := s.client.SendSingleCommand(ctx, req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	str, ok := resp.Resp.(transaction.TokenUser)
+	str, ok := resp.Resp.(transaction.Emergency)
 	if !ok {
-		return "", transaction.ErrBadTypeResponse
+		return nil, transaction.ErrBadTypeResponse
 	}
-	return str.Token, nil
+	return &str, nil
 }
 
-func (s *HandleService) SendLogin(ctx context.Context, name string, pass string) (string, error) {
+// Emergencies - emergency access of user as owner and as contact
+func (s *HandleService) Emergencies(ctx context.Context, token string) ([]transaction.Emergency, error) {
+	req := &transaction.Request{
+		Command: transaction.EmergencyListData{Token: transaction.TokenUser{Token: token}},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := resp.Resp.(transaction.Emergencies)
+	if !ok {
+		return nil, transaction.ErrBadTypeResponse
+	}
+	return str.Items, nil
+}
+
+// EmergencyItems - items of owner of approved emergency access, they are read by GetData
+func (s *HandleService) EmergencyItems(ctx context.Context, token string, id string) ([]transaction.EmergencyItem, error) {
+	req := &transaction.Request{
+		Command: transaction.EmergencyAccessData{Token: transaction.TokenUser{Token: token}, ID: id},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := resp.Resp.(transaction.EmergencyItems)
+	if !ok {
+		return nil, transaction.ErrBadTypeResponse
+	}
+	return str.Items, nil
+}
+
+// GetEmergencyItem - item of vault of approved emergency access id, opened by item key wrapped by public key of contact
+func (s *HandleService) GetEmergencyItem(ctx context.Context, token string, id string, uuid string) (*transaction.UserData, error) {
+	items, err := s.EmergencyItems(ctx, token, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.UUID == uuid {
+			return s.openItem(ctx, token, uuid, item.WrappedKey)
+		}
+	}
+	return nil, transaction.ErrNotShared
+}
+
+// CreateOrg - organization with user as owner
+func (s *HandleService) CreateOrg(ctx context.Context, token string, name string) (string, error) {
 	req := &transaction.Request{
-		Command: transaction.UserLogin{User: transaction.User{Name: name, Password: pass}},
+		Command: transaction.OrgData{Token: transaction.TokenUser{Token: token}, Name: name},
 	}
 	resp, err := s.client.SendSingleCommand(ctx, req)
 	if err != nil {
 		return "", err
 	}
-	str, ok := resp.Resp.(transaction.TokenUser)
+	str, ok := resp.Resp.(transaction.OrgID)
 	if !ok {
 		return "", transaction.ErrBadTypeResponse
 	}
-	return str.Token, nil
+	return str.ID, nil
+}
+
+// SetMember - add member of organization or change its role, remove - member is removed
+func (s *HandleService) SetMember(ctx context.Context, token string, org string, user string, role int, remove bool) error {
+	req := &transaction.Request{
+		Command: transaction.MemberData{Token: transaction.TokenUser{Token: token}, Org: org, User: user, Role: role, Remove: remove},
+	}
+	_, err := s.client.SendSingleCommand(ctx, req)
+	return err
+}
+
+func (s *HandleService) Members(ctx context.Context, token string, org string) ([]transaction.OrgMember, error) {
+	req := &transaction.Request{
+		Command: transaction.MembersData{Token: transaction.TokenUser{Token: token}, Org: org},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := resp.Resp.(transaction.OrgMembers)
+	if !ok {
+		return nil, transaction.ErrBadTypeResponse
+	}
+	return str.Members, nil
 }
 
-func (s *HandleService) SendData(ctx context.Context, token string, typdata int, data string, metadata string) (string, error) {
+// CreateCollection - team vault of organization
+func (s *HandleService) CreateCollection(ctx context.Context, token string, org string, name string) (string, error) {
 	req := &transaction.Request{
-		Command: transaction.UserData{Token: transaction.TokenUser{Token: token}, TypeData: typdata, Data: data, MetaData: metadata},
+		Command: transaction.CollectionData{Token: transaction.TokenUser{Token: token}, Org: org, Name: name},
 	}
 	resp, err := s.client.SendSingleCommand(ctx, req)
 	if err != nil {
@@ -68,120 +897,526 @@ func (s *HandleService) SendData(ctx context.Context, token string, typdata int,
 	return str.UUID, nil
 }
 
-func (s *HandleService) GetData(ctx context.Context, token string, uuid string) (*transaction.UserData, error) {
+// Collections - collections of organizations of user
+func (s *HandleService) Collections(ctx context.Context, token string) ([]transaction.Collection, error) {
 	req := &transaction.Request{
-		Command: transaction.GetUserData{Token: transaction.TokenUser{Token: token}, UUID: transaction.UUIDData{UUID: uuid}},
+		Command: transaction.CollectionsData{Token: transaction.TokenUser{Token: token}},
 	}
 	resp, err := s.client.SendSingleCommand(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	str, ok := resp.Resp.(transaction.UserData)
+	str, ok := resp.Resp.(transaction.Collections)
 	if !ok {
 		return nil, transaction.ErrBadTypeResponse
 	}
-	return &str, nil
+	return str.Items, nil
+}
+
+func (s *HandleService) CollectionItems(ctx context.Context, token string, uuid string) ([]transaction.CollectionItem, error) {
+	req := &transaction.Request{
+		Command: transaction.CollectionItemsData{Token: transaction.TokenUser{Token: token}, UUID: transaction.UUIDData{UUID: uuid}},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := resp.Resp.(transaction.CollectionItems)
+	if !ok {
+		return nil, transaction.ErrBadTypeResponse
+	}
+	return str.Items, nil
 }
 
-func openReadFile(filename string) (chan []byte, error) {
+func openReadFile(ctx context.Context, filename string, offset int64) (chan []byte, error) {
 	file, err := os.Open(filename)
 	if err != nil {
 		return nil, err
 	}
+	_, err = file.Seek(offset, io.SeekStart)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
 
 	ch := make(chan []byte)
-	var errR error
 	go func() {
-		buffer := make([]byte, 4096)
 		defer close(ch)
 		defer file.Close()
 		for {
-			var n int
-			n, errR = file.Read(buffer)
-			if errR == io.EOF {
-				break
+			buffer := make([]byte, chunkSize)
+			n, errR := file.Read(buffer)
+			if n > 0 {
+				select {
+				case ch <- buffer[:n]:
+				case <-ctx.Done():
+					return
+				}
 			}
 			if errR != nil {
 				break
 			}
-			ch <- buffer[:n]
 		}
 	}()
 	return ch, nil
 }
 
-func (s *HandleService) UploadData(ctx context.Context, token string, typdata int, metadata string, filename string) (string, error) {
-	ch, err := openReadFile(filename)
+// UploadData - upload file as item, compression - codec of server storage, empty - chosen by server,
+// ttl - lifetime of item, 0 - never expires, collection - team vault of item, empty - personal item
+func (s *HandleService) UploadData(ctx context.Context, token string, typdata int, metadata string, filename string,
+	compression string, ttl time.Duration, collection string) (string, error) {
+	if s.e2e && collection != "" {
+		return "", transaction.ErrCollectionE2E
+	}
+	return s.uploadData(ctx, token, typdata, metadata, filename, "", compression, ttl, collection)
+}
+
+// AttachData - upload file as attachment of item parent, file base name is attachment name
+func (s *HandleService) AttachData(ctx context.Context, token string, parent string, filename string) (string, error) {
+	return s.uploadData(ctx, token, store.BinaryType, filepath.Base(filename), filename, parent, "", 0, "")
+}
+
+func (s *HandleService) uploadData(ctx context.Context, token string, typdata int, metadata string, filename string,
+	parent string, compression string, ttl time.Duration, collection string) (string, error) {
+	metadata, tokens, err := s.sealMetaData(metadata)
 	if err != nil {
 		return "", err
 	}
-	req := &transaction.Request{
-		Command: transaction.StreamData{Token: transaction.TokenUser{Token: token}, TypeData: typdata, MetaData: metadata, Output: ch},
+	// session is saved on disk, upload is resumed after restart of client
+	state, err := loadUpload(s.name, filename, parent)
+	if err != nil {
+		return "", err
 	}
-
-	resp, err := s.client.SendStreamCommand(ctx, req)
+	if s.e2e {
+		// sealed file is not compressible, server stores it as is
+		if state.Sealed == "" {
+			state.Sealed = strings.TrimSuffix(state.path, ".json") + ".sealed"
+			err = s.sealBlob(filename, state.Sealed)
+			if err != nil {
+				return "", err
+			}
+		}
+		filename = state.Sealed
+		compression = codecs.None
+	}
+	info, err := os.Stat(filename)
 	if err != nil {
 		return "", err
 	}
-	str, ok := resp.Resp.(transaction.UUIDData)
-	if !ok {
-		return "", transaction.ErrBadTypeResponse
+	session := state.Session
+	expiry := expiresAt(ttl)
+	var res string
+	err = retry.RetryAction(ctx, retry.RetryTimes(), func(ctx context.Context) error {
+		offset, err := s.queryUpload(ctx, token, session)
+		if err != nil {
+			return err
+		}
+		state.Offset = offset
+		err = state.save()
+		if err != nil {
+			return err
+		}
+		// checksum of whole file, committed part is hashed from disk
+		h, err := hashFile(filename, offset)
+		if err != nil {
+			return err
+		}
+		ctxRead, cancel := context.WithCancel(ctx)
+		defer cancel()
+		ch, err := openReadFile(ctxRead, filename, offset)
+		if err != nil {
+			return err
+		}
+		req := &transaction.Request{
+			Command: transaction.StreamData{Token: transaction.TokenUser{Token: token}, TypeData: typdata, MetaData: metadata, Tokens: tokens,
+				Parent: parent, Session: session, Offset: offset, Size: info.Size(), Compression: compression, ExpiresAt: expiry,
+				Collection: collection, Hash: h, Output: ch},
+		}
+		resp, err := s.client.SendStreamCommand(ctx, req)
+		if err != nil {
+			return err
+		}
+		str, ok := resp.Resp.(transaction.UUIDData)
+		if !ok {
+			return transaction.ErrBadTypeResponse
+		}
+		res = str.UUID
+		return nil
+	}, isRetriable)
+	if err != nil {
+		return "", err
 	}
-	return str.UUID, nil
+	return res, state.done()
+}
+
+// sealBlob - blob sealed by vault key to file sealed, end-to-end mode, server never gets plain blob
+func (s *HandleService) sealBlob(filename string, sealed string) error {
+	if s.vault == nil {
+		return vault.ErrNoVaultKey
+	}
+	return s.vault.SealFile(filename, sealed)
 }
-func (s *HandleService) genFileName() string {
-	name := uuid.New().String() + ".data"
-	return name
+
+// openBlob - blob file sealed by vault key is opened to plain file, sealed file is removed,
+// length not zero limits size of plain file
+func (s *HandleService) openBlob(sealed string, filename string, length int64) error {
+	if s.vault == nil {
+		return vault.ErrNoVaultKey
+	}
+	err := s.vault.OpenFile(sealed, filename)
+	if err != nil {
+		return err
+	}
+	if length > 0 && fileSize(filename) > length {
+		err = os.Truncate(filename, length)
+		if err != nil {
+			return err
+		}
+	}
+	return os.Remove(sealed)
 }
 
-func openWriteFile(ctx context.Context, filename string) (chan []byte, error) {
+// queryUpload - committed offset of upload session, zero for new session
+func (s *HandleService) queryUpload(ctx context.Context, token string, session string) (int64, error) {
+	req := &transaction.Request{
+		Command: transaction.QueryUploadData{Token: transaction.TokenUser{Token: token}, Session: session},
+	}
+	resp, err := s.client.SendSingleCommand(ctx, req)
+	if status.Code(err) == codes.NotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	str, ok := resp.Resp.(transaction.UploadOffset)
+	if !ok {
+		return 0, transaction.ErrBadTypeResponse
+	}
+	return str.Offset, nil
+}
 
-	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+// hashFile - sha256 of first n bytes of file
+func hashFile(filename string, n int64) (hash.Hash, error) {
+	h := sha256.New()
+	if n == 0 {
+		return h, nil
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	_, err = io.CopyN(h, file, n)
 	if err != nil {
 		return nil, err
 	}
+	return h, nil
+}
 
-	ch := make(chan []byte)
-	var errW error
-	go func() {
+// verifyFile - downloaded file is checked with checksum of server
+func verifyFile(filename string, size int64, checksum string) error {
+	h, err := hashFile(filename, size)
+	if err != nil {
+		return err
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+	if sum != checksum {
+		return fmt.Errorf("%w: file %s sha256 %s, server sha256 %s", transaction.ErrChecksum, filename, sum, checksum)
+	}
+	return nil
+}
 
-		defer file.Close()
-		for res := range ch {
+func isRetriable(err error) bool {
+	if errors.Is(err, transaction.ErrSizeMismatch) || errors.Is(err, transaction.ErrBadTypeResponse) {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted, codes.Internal, codes.Unknown, codes.DeadlineExceeded, codes.FailedPrecondition:
+		return true
+	default:
+		return false
+	}
+}
+
+// expiresAt - expiry time of item of lifetime ttl, zero - never
+func expiresAt(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
 
-			select {
-			case <-ctx.Done():
-				return
+func highlight(text string, query string) string {
+	return search.Highlight(text, search.Normalize(query))
+}
+
+// openWriteFile - chunks appended to file, result of write in done after close of chunks
+func openWriteFile(filename string) (chan []byte, chan error, error) {
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
 
-			default:
+	ch := make(chan []byte)
+	done := make(chan error, 1)
+	go func() {
+		var errW error
+		for res := range ch {
+			if errW == nil {
 				_, errW = file.Write(res)
-				if errW != nil {
-					return
-				}
 			}
 		}
+		if errC := file.Close(); errW == nil {
+			errW = errC
+		}
+		done <- errW
 	}()
-	return ch, nil
+	return ch, done, nil
+}
+
+func fileSize(filename string) int64 {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// DownloadData - blob to file uuid.data, partial file is resumed, length not zero limits size of file,
+// in end-to-end mode whole sealed blob is downloaded to uuid.data.part and opened by vault key
+func (s *HandleService) DownloadData(ctx context.Context, token string, uuid string, length int64) (*transaction.UserData, error) {
+	if s.e2e {
+		return s.downloadSealed(ctx, token, uuid, length)
+	}
+	return s.downloadData(ctx, token, uuid, uuid+".data", length)
+}
+
+func (s *HandleService) downloadSealed(ctx context.Context, token string, uuid string, length int64) (*transaction.UserData, error) {
+	if s.vault == nil {
+		return nil, vault.ErrNoVaultKey
+	}
+	filename := uuid + ".data"
+	part := filename + ".part"
+	data, err := s.downloadData(ctx, token, uuid, part, 0)
+	if err != nil {
+		return nil, err
+	}
+	err = s.openBlob(part, filename, length)
+	if err != nil {
+		return nil, err
+	}
+	data.Data = filename
+	data.Size = fileSize(filename)
+	return data, nil
 }
 
-func (s *HandleService) DownloadData(ctx context.Context, token string, uuid string) (*transaction.UserData, error) {
-	filename := s.genFileName()
-	ch, err := openWriteFile(ctx, filename)
+// downloadData - blob to file, partial file is resumed, length not zero limits size of file
+func (s *HandleService) downloadData(ctx context.Context, token string, uuid string, filename string,
+	length int64) (*transaction.UserData, error) {
+	if length > 0 && fileSize(filename) >= length {
+		data, err := s.GetData(ctx, token, uuid)
+		if err != nil {
+			return nil, err
+		}
+		data.Data = filename
+		return data, nil
+	}
+
+	var str transaction.UserData
+	err := retry.RetryAction(ctx, retry.RetryTimes(), func(ctx context.Context) error {
+		offset := fileSize(filename)
+		var rangeLen int64
+		if length > 0 {
+			rangeLen = length - offset
+		}
+		ch, done, err := openWriteFile(filename)
+		if err != nil {
+			return err
+		}
+		req := &transaction.Request{
+			Command: transaction.GetStreamData{Token: transaction.TokenUser{Token: token}, UUID: transaction.UUIDData{UUID: uuid},
+				Offset: offset, Length: rangeLen, Input: ch},
+		}
+		resp, err := s.client.SendStreamCommand(ctx, req)
+		errW := <-done
+		if err != nil {
+			return err
+		}
+		if errW != nil {
+			return errW
+		}
+		var ok bool
+		str, ok = resp.Resp.(transaction.UserData)
+		if !ok {
+			return transaction.ErrBadTypeResponse
+		}
+		want := str.Size
+		if length > 0 && length < want {
+			want = length
+		}
+		if fileSize(filename) != want {
+			return transaction.ErrSizeMismatch
+		}
+		return nil
+	}, isRetriable)
+	if err != nil {
+		return nil, err
+	}
+	if str.Checksum != "" && fileSize(filename) == str.Size {
+		err = verifyFile(filename, str.Size, str.Checksum)
+		if err != nil {
+			return nil, err
+		}
+	}
+	str.MetaData, err = s.open(str.MetaData)
 	if err != nil {
 		return nil, err
 	}
+	str.Data = filename
+	return &str, nil
+
+}
 
+// ExportAccount - all items of user are decrypted into dir, blobs to files uuid.data, list of items to items.json
+func (s *HandleService) ExportAccount(ctx context.Context, token string, dir string) ([]transaction.ExportItem, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan transaction.ExportChunk)
+	done := make(chan error, 1)
+	var items []transaction.ExportItem
+	go func() {
+		var errW error
+		items, errW = writeExport(dir, ch)
+		done <- errW
+	}()
 	req := &transaction.Request{
-		Command: transaction.GetStreamData{Token: transaction.TokenUser{Token: token}, UUID: transaction.UUIDData{UUID: uuid}, Input: ch},
+		Command: transaction.ExportData{Token: transaction.TokenUser{Token: token}, Input: ch},
 	}
 	resp, err := s.client.SendStreamCommand(ctx, req)
+	errW := <-done
 	if err != nil {
 		return nil, err
 	}
-	str, ok := resp.Resp.(transaction.UserData)
-	if !ok {
+	if errW != nil {
+		return nil, errW
+	}
+	if _, ok := resp.Resp.(transaction.TokenUser); !ok {
 		return nil, transaction.ErrBadTypeResponse
 	}
-	str.Data = filename
-	return &str, nil
+	for i := range items {
+		if items[i].File != "" && items[i].Checksum != "" {
+			err = verifyFile(items[i].File, items[i].Size, items[i].Checksum)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if items[i].File != "" && s.e2e {
+			sealed := items[i].File + ".part"
+			err = os.Rename(items[i].File, sealed)
+			if err != nil {
+				return nil, err
+			}
+			err = s.openBlob(sealed, items[i].File, 0)
+			if err != nil {
+				return nil, err
+			}
+			items[i].Size = fileSize(items[i].File)
+			items[i].Checksum = ""
+		}
+		items[i].Data, err = s.open(items[i].Data)
+		if err != nil {
+			return nil, err
+		}
+		items[i].MetaData, err = s.open(items[i].MetaData)
+		if err != nil {
+			return nil, err
+		}
+	}
+	list, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(filepath.Join(dir, "items.json"), list, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// writeExport - items of chunks, blobs are written to files, chunks are read to end after error
+func writeExport(dir string, ch chan transaction.ExportChunk) ([]transaction.ExportItem, error) {
+	var items []transaction.ExportItem
+	var file *os.File
+	var errW error
+	for c := range ch {
+		if errW != nil {
+			continue
+		}
+		if len(items) == 0 || items[len(items)-1].UUID != c.UUID {
+			if file != nil {
+				errW = file.Close()
+				file = nil
+			}
+			item := transaction.ExportItem{
+				UUID:       c.UUID,
+				Type:       store.GetStringType(c.TypeData),
+				Data:       c.Data,
+				MetaData:   c.MetaData,
+				Parent:     c.Parent,
+				Collection: c.Collection,
+				ExpiresAt:  c.ExpiresAt,
+				Size:       c.Size,
+				Checksum:   c.Checksum,
+			}
+			if c.IsBlob && errW == nil {
+				item.File = filepath.Join(dir, c.UUID+".data")
+				file, errW = os.OpenFile(item.File, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+			}
+			items = append(items, item)
+		}
+		if file != nil && errW == nil {
+			_, errW = file.Write(c.Blob)
+		}
+	}
+	if file != nil {
+		if errC := file.Close(); errW == nil {
+			errW = errC
+		}
+	}
+	return items, errW
+}
 
+func (s *HandleService) Search(ctx context.Context, token string, query string) ([]transaction.SearchItem, error) {
+	cmd := transaction.SearchData{Token: transaction.TokenUser{Token: token}, Query: query}
+	if s.e2e {
+		if s.vault == nil {
+			return nil, vault.ErrNoVaultKey
+		}
+		tokens, err := s.vault.Tokens(query)
+		if err != nil {
+			return nil, err
+		}
+		cmd.Query = ""
+		cmd.Tokens = tokens
+	}
+	resp, err := s.client.SendStreamCommand(ctx, &transaction.Request{Command: cmd})
+	if err != nil {
+		return nil, err
+	}
+	str, ok := resp.Resp.(transaction.SearchResults)
+	if !ok {
+		return nil, transaction.ErrBadTypeResponse
+	}
+	if s.e2e {
+		for i := range str.Items {
+			str.Items[i].MetaData, err = s.open(str.Items[i].MetaData)
+			if err != nil {
+				return nil, err
+			}
+			str.Items[i].MetaData = highlight(str.Items[i].MetaData, query)
+		}
+	}
+	return str.Items, nil
 }
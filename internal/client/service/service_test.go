@@ -0,0 +1,185 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/4aleksei/gokeeper/internal/client/config"
+	"github.com/4aleksei/gokeeper/internal/client/grpcclient"
+	"github.com/4aleksei/gokeeper/internal/client/transaction"
+	"github.com/4aleksei/gokeeper/internal/common/cryptocerts"
+	"github.com/4aleksei/gokeeper/internal/common/datacrypto"
+	"github.com/4aleksei/gokeeper/internal/common/logger"
+	"github.com/4aleksei/gokeeper/internal/common/store"
+	"github.com/4aleksei/gokeeper/internal/common/store/cache"
+	serverconfig "github.com/4aleksei/gokeeper/internal/server/config"
+	"github.com/4aleksei/gokeeper/internal/server/grpcserver"
+	server "github.com/4aleksei/gokeeper/internal/server/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testServer struct {
+	serv    *server.HandlerService
+	grpc    *grpcserver.KeeperServiceService
+	address string
+}
+
+func freeAddress(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().String()
+}
+
+var (
+	onceCfg sync.Once
+	cfg     *serverconfig.Config
+)
+
+func newTestServer(t *testing.T) *testServer {
+	l, err := logger.New(logger.Config{Level: "error"})
+	require.NoError(t, err)
+	onceCfg.Do(func() {
+		cfg, err = serverconfig.New()
+	})
+	require.NoError(t, err)
+	c := *cfg
+	c.FilePath = t.TempDir() + string(os.PathSeparator)
+	c.GrcpAddress = freeAddress(t)
+	pr, pub, err := cryptocerts.GenerateKey()
+	require.NoError(t, err)
+	serv, err := server.New(cache.New(l.Logger), datacrypto.New(pr, pub), l.Logger, &c)
+	require.NoError(t, err)
+	g, err := grpcserver.New(serv, l, &c)
+	require.NoError(t, err)
+	t.Cleanup(g.StopServ)
+	return &testServer{serv: serv, grpc: g, address: c.GrcpAddress}
+}
+
+func newTestClient(t *testing.T, address string, e2e bool) *HandleService {
+	l, err := logger.New(logger.Config{Level: "error"})
+	require.NoError(t, err)
+	c, err := grpcclient.New(&config.Config{Address: address, Device: "test"}, l)
+	require.NoError(t, err)
+	return New(c, e2e)
+}
+
+// serverView - blob as server reads it after its own decryption
+func serverView(t *testing.T, ts *testServer, name string, pass string, uuid string) []byte {
+	ctx := context.Background()
+	user, err := ts.serv.LoginUser(ctx, name, pass)
+	require.NoError(t, err)
+	_, f, err := ts.serv.GetDataStream(ctx, user.Id, uuid, 0, 0)
+	require.NoError(t, err)
+	defer f.CloseRead()
+	var res bytes.Buffer
+	buf := make([]byte, 4096)
+	for {
+		n, err := f.ReadData(buf)
+		res.Write(buf[:n])
+		if err == io.EOF {
+			return res.Bytes()
+		}
+		require.NoError(t, err)
+	}
+}
+
+func TestEndToEndBlob(t *testing.T) {
+	ts := newTestServer(t)
+	srv := newTestClient(t, ts.address, true)
+	ctx := context.Background()
+
+	token, err := srv.SendRegister(ctx, "user1", "abcd")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	plain := bytes.Repeat([]byte("plaintext secret of blob "), 10000)
+	filename := filepath.Join(dir, "file.bin")
+	require.NoError(t, os.WriteFile(filename, plain, 0600))
+
+	uuid, err := srv.UploadData(ctx, token, store.BinaryType, "file", filename, "", 0, "")
+	require.NoError(t, err)
+
+	stored := serverView(t, ts, "user1", "abcd", uuid)
+	assert.NotEmpty(t, stored)
+	assert.False(t, bytes.Contains(stored, []byte("plaintext secret")))
+
+	data, err := srv.DownloadData(ctx, token, uuid, 0)
+	require.NoError(t, err)
+	got, err := os.ReadFile(data.Data)
+	require.NoError(t, err)
+	assert.Equal(t, plain, got)
+	assert.Equal(t, "file", data.MetaData)
+}
+
+func TestUploadResumeAfterRestart(t *testing.T) {
+	ts := newTestServer(t)
+	ctx := context.Background()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	srv := newTestClient(t, ts.address, false)
+	_, err := srv.SendRegister(ctx, "user1", "abcd")
+	require.NoError(t, err)
+
+	plain := bytes.Repeat([]byte("resumable upload "), 10000)
+	filename := filepath.Join(t.TempDir(), "file.bin")
+	require.NoError(t, os.WriteFile(filename, plain, 0600))
+
+	// client was stopped after part of file was sent
+	state, err := loadUpload("user1", filename, "")
+	require.NoError(t, err)
+	state.Session = "s-restart"
+	require.NoError(t, state.save())
+
+	user, err := ts.serv.LoginUser(ctx, "user1", "abcd")
+	require.NoError(t, err)
+	data := &store.UserData{Id: user.Id, TypeData: store.BinaryType, MetaData: "file"}
+	f, upload, err := ts.serv.OpenUploadStream(ctx, data, "s-restart", 0, int64(len(plain)), plain[:50000])
+	require.NoError(t, err)
+	_, err = f.WriteData(plain[:50000])
+	require.NoError(t, err)
+	require.NoError(t, ts.serv.SuspendUpload(ctx, upload, f))
+
+	srv2 := newTestClient(t, ts.address, false)
+	token, err := srv2.SendLogin(ctx, "user1", "abcd")
+	require.NoError(t, err)
+	uuid, err := srv2.UploadData(ctx, token, store.BinaryType, "file", filename, "", 0, "")
+	require.NoError(t, err)
+
+	_, err = ts.serv.QueryUpload(ctx, user.Id, "s-restart")
+	assert.ErrorIs(t, err, cache.ErrValueNotFound)
+	assert.Equal(t, plain, serverView(t, ts, "user1", "abcd", uuid))
+	_, err = os.Stat(state.path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestGetShared(t *testing.T) {
+	ts := newTestServer(t)
+	ctx := context.Background()
+
+	owner := newTestClient(t, ts.address, false)
+	tokenOwner, err := owner.SendRegister(ctx, "owner", "abcd")
+	require.NoError(t, err)
+	colleague := newTestClient(t, ts.address, false)
+	tokenColleague, err := colleague.SendRegister(ctx, "colleague", "abcd")
+	require.NoError(t, err)
+
+	uuid, err := owner.SendData(ctx, tokenOwner, store.LoginType, "db:secret", "database", 0, "")
+	require.NoError(t, err)
+	_, err = colleague.GetShared(ctx, tokenColleague, uuid)
+	assert.ErrorIs(t, err, transaction.ErrNotShared)
+
+	require.NoError(t, owner.Share(ctx, tokenOwner, uuid, "colleague", store.PermRead))
+	data, err := colleague.GetShared(ctx, tokenColleague, uuid)
+	require.NoError(t, err)
+	assert.Equal(t, "db:secret", data.Data)
+	assert.Equal(t, "database", data.MetaData)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// uploadState - upload session of file saved on disk, upload is resumed after restart of client,
+	// Sealed - file sealed by vault key in end-to-end mode, it is kept until commit, sealing is not repeatable
+	uploadState struct {
+		Session string    `json:"session"`
+		Size    int64     `json:"size"`
+		ModTime time.Time `json:"mod_time"`
+		Sealed  string    `json:"sealed,omitempty"`
+		Offset  int64     `json:"offset"`
+		path    string
+	}
+)
+
+// uploadDir - directory of upload sessions of client
+func uploadDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	dir = filepath.Join(dir, "gokeeper", "uploads")
+	return dir, os.MkdirAll(dir, 0700)
+}
+
+// loadUpload - saved session of file of user uploaded as item or attachment of parent,
+// new session if file is changed after it was saved
+func loadUpload(name string, filename string, parent string) (*uploadState, error) {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := uploadDir()
+	if err != nil {
+		return nil, err
+	}
+	key := sha256.Sum256([]byte(name + "\x00" + parent + "\x00" + abs))
+	path := filepath.Join(dir, hex.EncodeToString(key[:])+".json")
+	state := &uploadState{}
+	b, err := os.ReadFile(path)
+	if err == nil && json.Unmarshal(b, state) == nil && state.Size == info.Size() && state.ModTime.Equal(info.ModTime()) &&
+		(state.Sealed == "" || fileExists(state.Sealed)) {
+		state.path = path
+		return state, nil
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if state.Sealed != "" {
+		_ = os.Remove(state.Sealed)
+	}
+	return &uploadState{
+		Session: uuid.New().String(),
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+		path:    path,
+	}, nil
+}
+
+func (u *uploadState) save() error {
+	b, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(u.path, b, 0600)
+}
+
+// done - session is committed, its state and sealed file are removed
+func (u *uploadState) done() error {
+	if u.Sealed != "" {
+		_ = os.Remove(u.Sealed)
+	}
+	err := os.Remove(u.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func fileExists(filename string) bool {
+	_, err := os.Stat(filename)
+	return err == nil
+}
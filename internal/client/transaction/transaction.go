@@ -2,21 +2,212 @@ package transaction
 
 import (
 	"errors"
+	"hash"
+	"time"
 )
 
 var (
 	ErrBadTypeCommand  = errors.New("unk  type command")
 	ErrBadTypeResponse = errors.New("unk  type response")
+	ErrSizeMismatch    = errors.New("error, sent size differs from file size")
+	ErrChecksum        = errors.New("error, checksum of data differs from checksum of server")
+	ErrShareE2E        = errors.New("error, items of end-to-end mode are sealed by vault key, sharing is not supported")
+	ErrBadLink         = errors.New("error, link of secret is not gokeeper://secret/id#key")
+	ErrEmergencyE2E    = errors.New("error, items of end-to-end mode are sealed by vault key, emergency access is not supported")
+	ErrNoLogin         = errors.New("error, login is required")
+	ErrOTPRequired     = errors.New("error, code of second factor is required")
+	ErrNoChallenge     = errors.New("error, login is not waiting for code of second factor")
+	ErrCollectionE2E   = errors.New("error, items of end-to-end mode are sealed by vault key, collections are not supported")
+	ErrNotShared       = errors.New("error, item is not shared with user")
+	ErrNoPrivateKey    = errors.New("error, private key of user is not opened, login is required")
 )
 
 type (
+	// User - OTP - code of second factor, Challenge - challenge of first step of login, it replaces name and password
 	User struct {
+		Name      string
+		Password  string
+		VaultKey  string
+		OTP       string
+		Challenge string
+	}
+
+	// TokenUser - Challenge - second factor is required, Token is empty
+	TokenUser struct {
+		Token      string
+		VaultKey   string
+		PublicKey  string
+		PrivateKey string
+		Challenge  string
+	}
+
+	// LogoutData - token of session is revoked, All - all sessions of user are revoked
+	LogoutData struct {
+		Token TokenUser
+		All   bool
+	}
+
+	SessionsData struct {
+		Token TokenUser
+	}
+
+	// Session - login of user from device, Current - session of request
+	Session struct {
+		ID            string
+		Device        string
+		ClientVersion string
+		Address       string
+		CreatedAt     time.Time
+		LastSeen      time.Time
+		Current       bool
+	}
+
+	Sessions struct {
+		Items []Session
+	}
+
+	RevokeSessionData struct {
+		Token TokenUser
+		ID    string
+	}
+
+	// APITokenData - new api token, Items, Collections and Tags restrict it, TTL 0 - token does not expire
+	APITokenData struct {
+		Token       TokenUser
+		Name        string
+		Permission  int
+		Items       []string
+		Collections []string
+		Tags        []string
+		TTL         time.Duration
+	}
+
+	// APIToken - Secret - new token, it is shown once
+	APIToken struct {
+		Secret      string
+		Name        string
+		Permission  int
+		Items       []string
+		Collections []string
+		Tags        []string
+		ExpiresAt   time.Time
+		CreatedAt   time.Time
+		LastUsed    time.Time
+	}
+
+	APITokensData struct {
+		Token TokenUser
+	}
+
+	APITokens struct {
+		Items []APIToken
+	}
+
+	RevokeAPITokenData struct {
+		Token TokenUser
+		Name  string
+	}
+
+	// ChangePasswordData - keys of user wrapped by new master key, empty - user has no such key
+	ChangePasswordData struct {
+		Token      TokenUser
+		Old        string
+		New        string
+		VaultKey   string
+		PrivateKey string
+	}
+
+	// SetRecoveryData - Recovery - secret of recovery key, keys of user wrapped by recovery key
+	SetRecoveryData struct {
+		Token      TokenUser
+		Recovery   string
+		VaultKey   string
+		PrivateKey string
+	}
+
+	OpenRecoveryData struct {
 		Name     string
+		Recovery string
+	}
+
+	// RecoveryKeys - keys of user wrapped by recovery key
+	RecoveryKeys struct {
+		VaultKey   string
+		PrivateKey string
+	}
+
+	// RecoverData - new password by recovery key, keys of user wrapped by new master key
+	// ExportData - chunks of export are sent to Input, it is closed at end of stream
+	ExportData struct {
+		Token TokenUser
+		Input chan ExportChunk
+	}
+
+	// ExportChunk - first chunk of item has its fields, next chunks of blob have UUID, Blob and Offset
+	ExportChunk struct {
+		UUID       string
+		TypeData   int
+		Data       string
+		MetaData   string
+		Parent     string
+		Collection string
+		ExpiresAt  time.Time
+		IsBlob     bool
+		Blob       []byte
+		Offset     int64
+		Size       int64
+		Checksum   string
+	}
+
+	// ExportItem - exported item, File - file of blob
+	ExportItem struct {
+		UUID       string    `json:"uuid"`
+		Type       string    `json:"type"`
+		Data       string    `json:"data,omitempty"`
+		MetaData   string    `json:"metadata"`
+		Parent     string    `json:"parent,omitempty"`
+		Collection string    `json:"collection,omitempty"`
+		ExpiresAt  time.Time `json:"expires_at,omitzero"`
+		File       string    `json:"file,omitempty"`
+		Size       int64     `json:"size,omitempty"`
+		Checksum   string    `json:"checksum,omitempty"`
+	}
+
+	DeleteAccountData struct {
+		Token    TokenUser
 		Password string
 	}
 
-	TokenUser struct {
-		Token string
+	// RecoverData - OTP - code of second factor if it is enabled
+	RecoverData struct {
+		Name       string
+		Recovery   string
+		Password   string
+		VaultKey   string
+		PrivateKey string
+		OTP        string
+	}
+
+	// EnrollData - Code or Password confirms enrollment again of enabled second factor
+	EnrollData struct {
+		Token    TokenUser
+		Code     string
+		Password string
+	}
+
+	// Enrollment - totp secret and its otpauth URI
+	Enrollment struct {
+		Secret string
+		URI    string
+	}
+
+	VerifyTOTPData struct {
+		Token TokenUser
+		Code  string
+	}
+
+	RecoveryCodes struct {
+		Codes []string
 	}
 
 	UUIDData struct {
@@ -37,23 +228,325 @@ type (
 	}
 
 	UserData struct {
-		Token    TokenUser
+		Token       TokenUser
+		TypeData    int
+		Data        string
+		MetaData    string
+		Tokens      []string
+		Size        int64
+		Checksum    string
+		ExpiresAt   time.Time
+		Collection  string
+		Attachments []Attachment
+	}
+
+	Attachment struct {
+		UUID string
+		Name string
+		Size int64
+	}
+
+	DeleteUserData struct {
+		Token TokenUser
+		UUID  UUIDData
+	}
+
+	DeletedData struct {
+		UUIDs []string
+	}
+
+	StreamData struct {
+		Token       TokenUser
+		TypeData    int
+		MetaData    string
+		Tokens      []string
+		Parent      string
+		Session     string
+		Offset      int64
+		Size        int64
+		Compression string
+		ExpiresAt   time.Time
+		Collection  string
+		Hash        hash.Hash
+		Output      chan []byte
+	}
+
+	QueryUploadData struct {
+		Token   TokenUser
+		Session string
+	}
+
+	UploadOffset struct {
+		Offset int64
+		Size   int64
+	}
+
+	SearchData struct {
+		Token  TokenUser
+		Query  string
+		Tokens []string
+	}
+
+	SearchItem struct {
+		UUID     string
 		TypeData int
-		Data     string
 		MetaData string
+		Score    int
+		Fields   []string
 	}
 
-	StreamData struct {
+	SearchResults struct {
+		Items []SearchItem
+	}
+
+	UsageData struct {
+		Token TokenUser
+	}
+
+	// Usage - stored items and bytes of user, limits of zero are not set
+	Usage struct {
+		Items      int64
+		Bytes      int64
+		QuotaItems int64
+		QuotaBytes int64
+		MaxUpload  int64
+	}
+
+	UpdateUserData struct {
 		Token    TokenUser
-		TypeData int
+		UUID     UUIDData
+		Data     string
 		MetaData string
-		Output   chan []byte
+		Tokens   []string
 	}
 
-	GetStreamData struct {
+	RotationData struct {
+		Token TokenUser
+		UUID  UUIDData
+		Days  int
+	}
+
+	DueData struct {
+		Token TokenUser
+		At    time.Time
+	}
+
+	// DueItem - login item with password older than rotation policy
+	DueItem struct {
+		UUID      string
+		MetaData  string
+		ChangedAt time.Time
+		DueAt     time.Time
+		Days      int
+	}
+
+	DueItems struct {
+		Items []DueItem
+	}
+
+	KeysData struct {
+		Token      TokenUser
+		PublicKey  string
+		PrivateKey string
+	}
+
+	ShareData struct {
+		Token      TokenUser
+		UUID       UUIDData
+		Recipient  string
+		Permission int
+	}
+
+	// SharedKey - item key wrapped by public key of recipient
+	SharedKey struct {
+		WrappedKey string
+	}
+
+	UnshareData struct {
+		Token     TokenUser
+		UUID      UUIDData
+		Recipient string
+	}
+
+	SharedData struct {
+		Token TokenUser
+	}
+
+	// SharedItem - item of other user shared with user
+	SharedItem struct {
+		UUID       string
+		Owner      string
+		TypeData   int
+		MetaData   string
+		Permission int
+		WrappedKey string
+	}
+
+	SharedItems struct {
+		Items []SharedItem
+	}
+
+	// GetSealedData - item encrypted by item key, it is opened by client
+	GetSealedData struct {
 		Token TokenUser
 		UUID  UUIDData
-		Input chan []byte
+	}
+
+	SealedItem struct {
+		UUID     string
+		TypeData int
+		Data     []byte
+		MetaData []byte
+	}
+
+	OrgData struct {
+		Token TokenUser
+		Name  string
+	}
+
+	OrgID struct {
+		ID string
+	}
+
+	// MemberData - add member or change role, Remove - member is removed
+	MemberData struct {
+		Token  TokenUser
+		Org    string
+		User   string
+		Role   int
+		Remove bool
+	}
+
+	MembersData struct {
+		Token TokenUser
+		Org   string
+	}
+
+	OrgMember struct {
+		Name string
+		Role int
+	}
+
+	OrgMembers struct {
+		Members []OrgMember
+	}
+
+	CollectionData struct {
+		Token TokenUser
+		Org   string
+		Name  string
+	}
+
+	CollectionsData struct {
+		Token TokenUser
+	}
+
+	// Collection - team vault of organization, WrappedKey - collection key wrapped by public key of user
+	Collection struct {
+		UUID       string
+		Org        string
+		Name       string
+		Role       int
+		Version    int
+		WrappedKey string
+	}
+
+	Collections struct {
+		Items []Collection
+	}
+
+	CollectionItemsData struct {
+		Token TokenUser
+		UUID  UUIDData
+	}
+
+	CollectionItem struct {
+		UUID     string
+		Owner    string
+		TypeData int
+		MetaData string
+	}
+
+	CollectionItems struct {
+		Items []CollectionItem
+	}
+
+	SecretData struct {
+		Token      TokenUser
+		Ciphertext string
+		MaxViews   int
+		ExpiresAt  time.Time
+	}
+
+	SecretID struct {
+		ID        string
+		ExpiresAt time.Time
+	}
+
+	RedeemData struct {
+		ID string
+	}
+
+	RedeemedSecret struct {
+		Ciphertext string
+		ViewsLeft  int
+	}
+
+	// EmergencyInviteData - trusted contact for emergency access, Wait 0 - default of server
+	EmergencyInviteData struct {
+		Token   TokenUser
+		Contact string
+		Wait    time.Duration
+	}
+
+	// EmergencyStepData - step of emergency access: request, approve, deny or revoke
+	EmergencyStepData struct {
+		Token TokenUser
+		ID    string
+		Step  int
+	}
+
+	Emergency struct {
+		ID          string
+		Owner       string
+		Contact     string
+		State       int
+		Wait        time.Duration
+		RequestedAt time.Time
+		GrantedAt   time.Time
+	}
+
+	EmergencyListData struct {
+		Token TokenUser
+	}
+
+	Emergencies struct {
+		Items []Emergency
+	}
+
+	EmergencyAccessData struct {
+		Token TokenUser
+		ID    string
+	}
+
+	// EmergencyItem - item of owner, WrappedKey - item key wrapped by public key of contact
+	EmergencyItem struct {
+		UUID       string
+		TypeData   int
+		MetaData   string
+		WrappedKey string
+	}
+
+	EmergencyItems struct {
+		Items []EmergencyItem
+	}
+
+	GetStreamData struct {
+		Token  TokenUser
+		UUID   UUIDData
+		Offset int64
+		Length int64
+		Input  chan []byte
 	}
 
 	Request struct {
@@ -65,3 +558,11 @@ type (
 		Err  error
 	}
 )
+
+// steps of emergency access
+const (
+	EmergencyRequest int = iota
+	EmergencyApprove
+	EmergencyDeny
+	EmergencyRevoke
+)
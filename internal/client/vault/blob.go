@@ -0,0 +1,156 @@
+package vault
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/4aleksei/gokeeper/internal/common/utils/random"
+)
+
+const (
+	blobInfo  string = "gokeeper blob"
+	blobBlock int    = 64 * 1024
+)
+
+var (
+	ErrBadBlob = errors.New("error, sealed blob is truncated or corrupted")
+)
+
+// blobCipher - cipher of blobs, key is derived from vault key
+func (v *Vault) blobCipher() (cipher.AEAD, error) {
+	key, err := hkdf.Key(sha256.New, v.key, nil, blobInfo, keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// blockData - block is bound to its index and to end of blob, blocks can not be reordered or cut off
+func blockData(index uint64, last bool) []byte {
+	ad := binary.BigEndian.AppendUint64(nil, index)
+	if last {
+		return append(ad, 1)
+	}
+	return append(ad, 0)
+}
+
+// atEnd - reader has no more data
+func atEnd(r *bufio.Reader) (bool, error) {
+	_, err := r.Peek(1)
+	if err == io.EOF {
+		return true, nil
+	}
+	return false, err
+}
+
+// Seal - blob of r sealed by vault key to w, blocks of blobBlock with random nonce each
+func (v *Vault) Seal(r io.Reader, w io.Writer) error {
+	aead, err := v.blobCipher()
+	if err != nil {
+		return err
+	}
+	br := bufio.NewReaderSize(r, blobBlock)
+	buf := make([]byte, blobBlock)
+	for index := uint64(0); ; index++ {
+		n, err := io.ReadFull(br, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		last, err := atEnd(br)
+		if err != nil {
+			return err
+		}
+		nonce, err := random.GenerateRandom(aead.NonceSize())
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(aead.Seal(nonce, nonce, buf[:n], blockData(index, last)))
+		if err != nil {
+			return err
+		}
+		if last {
+			return nil
+		}
+	}
+}
+
+// Open - blob sealed by Seal opened to w
+func (v *Vault) Open(r io.Reader, w io.Writer) error {
+	aead, err := v.blobCipher()
+	if err != nil {
+		return err
+	}
+	sealed := aead.NonceSize() + blobBlock + aead.Overhead()
+	br := bufio.NewReaderSize(r, sealed)
+	buf := make([]byte, sealed)
+	for index := uint64(0); ; index++ {
+		n, err := io.ReadFull(br, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		if n < aead.NonceSize()+aead.Overhead() {
+			return ErrBadBlob
+		}
+		last, err := atEnd(br)
+		if err != nil {
+			return err
+		}
+		nonce := buf[:aead.NonceSize()]
+		p, err := aead.Open(nil, nonce, buf[aead.NonceSize():n], blockData(index, last))
+		if err != nil {
+			return ErrBadBlob
+		}
+		_, err = w.Write(p)
+		if err != nil {
+			return err
+		}
+		if last {
+			return nil
+		}
+	}
+}
+
+// SealFile - file src sealed to dst
+func (v *Vault) SealFile(src string, dst string) error {
+	return convertFile(src, dst, v.Seal)
+}
+
+// OpenFile - file src sealed by SealFile opened to dst
+func (v *Vault) OpenFile(src string, dst string) error {
+	return convertFile(src, dst, v.Open)
+}
+
+func convertFile(src string, dst string, convert func(io.Reader, io.Writer) error) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(out)
+	err = convert(in, w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if errC := out.Close(); err == nil {
+		err = errC
+	}
+	if err != nil {
+		_ = os.Remove(dst)
+	}
+	return err
+}
@@ -0,0 +1,44 @@
+package vault
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealOpen(t *testing.T) {
+	v, err := New()
+	require.NoError(t, err)
+
+	for _, size := range []int{0, 1, blobBlock, blobBlock + 1, 3*blobBlock - 7} {
+		plain := bytes.Repeat([]byte{'a'}, size)
+		var sealed bytes.Buffer
+		require.NoError(t, v.Seal(bytes.NewReader(plain), &sealed))
+		assert.False(t, size > 0 && bytes.Contains(sealed.Bytes(), plain[:min(size, 64)]))
+
+		var opened bytes.Buffer
+		require.NoError(t, v.Open(bytes.NewReader(sealed.Bytes()), &opened))
+		assert.True(t, bytes.Equal(plain, opened.Bytes()))
+	}
+}
+
+func TestOpenTruncated(t *testing.T) {
+	v, err := New()
+	require.NoError(t, err)
+	other, err := New()
+	require.NoError(t, err)
+
+	var sealed bytes.Buffer
+	require.NoError(t, v.Seal(bytes.NewReader(bytes.Repeat([]byte{'a'}, 2*blobBlock+5)), &sealed))
+	block := sealed.Len() - (5 + 12 + 16)
+
+	// blob is cut at end of block, last block is missing
+	err = v.Open(bytes.NewReader(sealed.Bytes()[:block]), &bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrBadBlob)
+	err = v.Open(bytes.NewReader(nil), &bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrBadBlob)
+	err = other.Open(bytes.NewReader(sealed.Bytes()), &bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrBadBlob)
+}
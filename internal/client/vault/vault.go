@@ -0,0 +1,178 @@
+// Package vault - end-to-end mode, user vault key never leaves client unwrapped
+package vault
+
+import (
+	"crypto/hkdf"
+	"crypto/pbkdf2"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/4aleksei/gokeeper/internal/common/aescoder"
+	"github.com/4aleksei/gokeeper/internal/common/cryptocerts"
+	"github.com/4aleksei/gokeeper/internal/common/search"
+	"github.com/4aleksei/gokeeper/internal/common/utils/random"
+)
+
+type (
+	Vault struct {
+		key []byte
+	}
+)
+
+const (
+	keySize       int    = 32
+	kdfIterations int    = 600000
+	kdfSalt       string = "gokeeper:"
+	searchInfo    string = "gokeeper search index"
+	recoveryAuth  string = "gokeeper recovery auth"
+	recoveryWrap  string = "gokeeper recovery wrap"
+)
+
+var (
+	ErrNoVaultKey     = errors.New("error, no vault key, end-to-end mode")
+	ErrBadRecoveryKey = errors.New("error, recovery key is not valid")
+)
+
+// MasterKey - key derived from user name and password, wraps vault key
+func MasterKey(name string, password string) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, password, []byte(kdfSalt+name), kdfIterations, keySize)
+}
+
+func New() (*Vault, error) {
+	key, err := random.GenerateRandom(keySize)
+	if err != nil {
+		return nil, err
+	}
+	return &Vault{key: key}, nil
+}
+
+// Unwrap - open vault key wrapped by master key
+func Unwrap(master []byte, wrapped string) (*Vault, error) {
+	if wrapped == "" {
+		return nil, ErrNoVaultKey
+	}
+	c, err := hex.DecodeString(wrapped)
+	if err != nil {
+		return nil, err
+	}
+	key, err := aescoder.Open(master, c)
+	if err != nil {
+		return nil, err
+	}
+	return &Vault{key: key}, nil
+}
+
+// Wrap - vault key encrypted by master key for storage on server
+func (v *Vault) Wrap(master []byte) (string, error) {
+	c, err := aescoder.Seal(master, v.key)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(c), nil
+}
+
+func (v *Vault) Encrypt(s string) (string, error) {
+	c, err := aescoder.Seal(v.key, []byte(s))
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(c), nil
+}
+
+func (v *Vault) Decrypt(s string) (string, error) {
+	c, err := hex.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	p, err := aescoder.Open(v.key, c)
+	if err != nil {
+		return "", err
+	}
+	return string(p), nil
+}
+
+func (v *Vault) searchKey() ([]byte, error) {
+	return hkdf.Key(sha256.New, v.key, nil, searchInfo, keySize)
+}
+
+// Tokens - blind index of text
+func (v *Vault) Tokens(text string) ([]string, error) {
+	key, err := v.searchKey()
+	if err != nil {
+		return nil, err
+	}
+	return search.BlindTokens(key, text), nil
+}
+
+// NewKeyPair - rsa keys of user for sharing, private key is wrapped by master key
+func NewKeyPair(master []byte) (*rsa.PrivateKey, string, string, error) {
+	prv, pub, err := cryptocerts.GenerateKey()
+	if err != nil {
+		return nil, "", "", err
+	}
+	public, err := cryptocerts.EncodePublicKey(pub)
+	if err != nil {
+		return nil, "", "", err
+	}
+	private, err := WrapPrivateKey(master, prv)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return prv, public, private, nil
+}
+
+// WrapPrivateKey - private key of user encrypted by master key for storage on server
+func WrapPrivateKey(master []byte, prv *rsa.PrivateKey) (string, error) {
+	c, err := aescoder.Seal(master, cryptocerts.EncodePrivateKey(prv))
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(c), nil
+}
+
+// NewRecoveryKey - random recovery key of user, it is shown once
+func NewRecoveryKey() (string, error) {
+	key, err := random.GenerateRandom(keySize)
+	if err != nil {
+		return "", err
+	}
+	s := hex.EncodeToString(key)
+	groups := make([]string, 0, len(s)/8)
+	for i := 0; i < len(s); i += 8 {
+		groups = append(groups, s[i:i+8])
+	}
+	return strings.Join(groups, "-"), nil
+}
+
+// RecoveryKeys - secret of recovery key for server and key wrapping keys of user, server never has wrapping key
+func RecoveryKeys(recovery string) (string, []byte, error) {
+	key, err := hex.DecodeString(strings.ReplaceAll(strings.TrimSpace(recovery), "-", ""))
+	if err != nil || len(key) != keySize {
+		return "", nil, ErrBadRecoveryKey
+	}
+	auth, err := hkdf.Key(sha256.New, key, nil, recoveryAuth, keySize)
+	if err != nil {
+		return "", nil, err
+	}
+	wrap, err := hkdf.Key(sha256.New, key, nil, recoveryWrap, keySize)
+	if err != nil {
+		return "", nil, err
+	}
+	return hex.EncodeToString(auth), wrap, nil
+}
+
+// OpenPrivateKey - private key of user wrapped by NewKeyPair
+func OpenPrivateKey(master []byte, wrapped string) (*rsa.PrivateKey, error) {
+	c, err := hex.DecodeString(wrapped)
+	if err != nil {
+		return nil, err
+	}
+	der, err := aescoder.Open(master, c)
+	if err != nil {
+		return nil, err
+	}
+	return cryptocerts.ParsePrivateKey(der)
+}
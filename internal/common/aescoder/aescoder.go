@@ -2,11 +2,15 @@
 package aescoder
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 )
@@ -30,6 +34,10 @@ type (
 	}
 )
 
+var (
+	ErrShortCipher = errors.New("error, cipher text too short")
+)
+
 func DecodeAESKey(prv *rsa.PrivateKey, hexKey string) (*KeyAES, error) {
 	key, err := hex.DecodeString(hexKey)
 	if err != nil {
@@ -65,6 +73,66 @@ func (k *KeyAES) GetKey() string {
 	return k.cipherKey
 }
 
+// WrapFor - key encrypted by public key of recipient of shared item
+func (k *KeyAES) WrapFor(pub *rsa.PublicKey) (string, error) {
+	c, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, k.key, nil)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(c), nil
+}
+
+// UnwrapFor - key of shared item wrapped by WrapFor
+func UnwrapFor(prv *rsa.PrivateKey, wrapped string) (*KeyAES, error) {
+	c, err := hex.DecodeString(wrapped)
+	if err != nil {
+		return nil, err
+	}
+	key, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, prv, c, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyAES{
+		key:       key,
+		cipherKey: wrapped,
+	}, nil
+}
+
+// Wrap - other key encrypted by key, item key wrapped by collection key
+func (k *KeyAES) Wrap(other *KeyAES) (string, error) {
+	c, err := Seal(k.key, other.key)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(c), nil
+}
+
+// Unwrap - key wrapped by Wrap
+func (k *KeyAES) Unwrap(wrapped string) (*KeyAES, error) {
+	c, err := hex.DecodeString(wrapped)
+	if err != nil {
+		return nil, err
+	}
+	key, err := Open(k.key, c)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyAES{
+		key:       key,
+		cipherKey: wrapped,
+	}, nil
+}
+
+// OpenOne - data written by WriteOne with key, item data is opened by reader of item key
+func (k *KeyAES) OpenOne(c []byte) ([]byte, error) {
+	buf := bytes.Clone(c)
+	r, err := NewReader(io.NopCloser(bytes.NewReader(c)), k)
+	if err != nil {
+		return nil, err
+	}
+	return r.ReadOne(buf)
+}
+
 func NewReader(r io.ReadCloser, key *KeyAES) (*AesReader, error) {
 	aesblock, err := aes.NewCipher(key.key)
 
@@ -169,3 +237,189 @@ func (a *AesWriter) WriteOne(p []byte) (int, error) {
 	k := a.aesgcm.Seal( /*p[0:]*/ nil, a.nonce, p, nil)
 	return a.w.Write(k)
 }
+
+// Seal - encrypt p with raw key, random nonce is prepended to ciphertext
+func Seal(key []byte, p []byte) ([]byte, error) {
+	aesblock, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aesgcm, err := cipher.NewGCM(aesblock)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := generateRandom(aesgcm.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+	return aesgcm.Seal(nonce, nonce, p, nil), nil
+}
+
+// Open - decrypt ciphertext made by Seal
+func Open(key []byte, c []byte) ([]byte, error) {
+	aesblock, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aesgcm, err := cipher.NewGCM(aesblock)
+	if err != nil {
+		return nil, err
+	}
+	if len(c) < aesgcm.NonceSize() {
+		return nil, ErrShortCipher
+	}
+	return aesgcm.Open(nil, c[:aesgcm.NonceSize()], c[aesgcm.NonceSize():], nil)
+}
+
+const (
+	// BlockSize - plain size of independently sealed block of stream
+	BlockSize int = 4096
+	// SealedBlockSize - size of sealed block in storage: nonce, cipher text, tag
+	SealedBlockSize int = BlockSize + blockOverhead
+	blockNonceSize  int = 12
+	blockOverhead   int = blockNonceSize + 16
+)
+
+type (
+	// BlockWriter - stream split into fixed-size blocks, each sealed with random nonce and block index
+	BlockWriter struct {
+		w      io.Writer
+		aesgcm cipher.AEAD
+		buf    []byte
+		index  uint64
+	}
+
+	// BlockReader - reads stream written by BlockWriter
+	BlockReader struct {
+		r      io.Reader
+		aesgcm cipher.AEAD
+		sealed []byte
+		plain  []byte
+		index  uint64
+	}
+)
+
+func newGCM(key *KeyAES) (cipher.AEAD, error) {
+	aesblock, err := aes.NewCipher(key.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCMWithNonceSize(aesblock, blockNonceSize)
+}
+
+// blockData - block index is authenticated, blocks can not be reordered
+func blockData(index uint64) []byte {
+	ad := make([]byte, 8)
+	binary.BigEndian.PutUint64(ad, index)
+	return ad
+}
+
+// NewBlockWriter - index is number of first block, not zero when stream is appended
+func NewBlockWriter(w io.Writer, key *KeyAES, index uint64) (*BlockWriter, error) {
+	aesgcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockWriter{
+		w:      w,
+		aesgcm: aesgcm,
+		buf:    make([]byte, 0, BlockSize),
+		index:  index,
+	}, nil
+}
+
+func (b *BlockWriter) sealBlock() error {
+	nonce, err := generateRandom(blockNonceSize)
+	if err != nil {
+		return err
+	}
+	k := b.aesgcm.Seal(nonce, nonce, b.buf, blockData(b.index))
+	b.index++
+	b.buf = b.buf[:0]
+	_, err = b.w.Write(k)
+	return err
+}
+
+func (b *BlockWriter) Write(p []byte) (int, error) {
+	var n int
+	for len(p) > 0 {
+		c := copy(b.buf[len(b.buf):BlockSize], p)
+		b.buf = b.buf[:len(b.buf)+c]
+		p = p[c:]
+		n += c
+		if len(b.buf) == BlockSize {
+			if err := b.sealBlock(); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Flush - seal short tail block, stream must not be written after
+func (b *BlockWriter) Flush() error {
+	if len(b.buf) == 0 {
+		return nil
+	}
+	return b.sealBlock()
+}
+
+// NewBlockReader - index is number of first block in r, not zero when stream is read from offset
+func NewBlockReader(r io.Reader, key *KeyAES, index uint64) (*BlockReader, error) {
+	aesgcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockReader{
+		r:      r,
+		aesgcm: aesgcm,
+		sealed: make([]byte, SealedBlockSize),
+		index:  index,
+	}, nil
+}
+
+func (b *BlockReader) Read(p []byte) (int, error) {
+	if len(b.plain) == 0 {
+		n, err := io.ReadFull(b.r, b.sealed)
+		if err == io.EOF {
+			return 0, io.EOF
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return 0, err
+		}
+		b.plain, err = openBlock(b.aesgcm, b.index, b.sealed[:n])
+		if err != nil {
+			return 0, err
+		}
+		b.index++
+	}
+	n := copy(p, b.plain)
+	b.plain = b.plain[n:]
+	return n, nil
+}
+
+func openBlock(aesgcm cipher.AEAD, index uint64, sealed []byte) ([]byte, error) {
+	if len(sealed) < blockOverhead {
+		return nil, ErrShortCipher
+	}
+	return aesgcm.Open(sealed[blockNonceSize:blockNonceSize], sealed[:blockNonceSize], sealed[blockNonceSize:], blockData(index))
+}
+
+// SealedSize - size in storage of plain size bytes
+func SealedSize(size int64) int64 {
+	blocks := size / int64(BlockSize)
+	res := blocks * int64(SealedBlockSize)
+	if tail := size % int64(BlockSize); tail > 0 {
+		res += tail + int64(blockOverhead)
+	}
+	return res
+}
+
+// OpenBlock - decrypt single sealed block with number index
+func OpenBlock(key *KeyAES, index uint64, sealed []byte) ([]byte, error) {
+	aesgcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return openBlock(aesgcm, index, sealed)
+}
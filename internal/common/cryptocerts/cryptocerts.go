@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/pem"
 	"errors"
 	"os"
@@ -38,3 +39,37 @@ func GenerateKey() (*rsa.PrivateKey, *rsa.PublicKey, error) {
 	}
 	return privateKey, &privateKey.PublicKey, nil
 }
+
+// EncodePublicKey - hex PKIX form of public key of user
+func EncodePublicKey(pub *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(der), nil
+}
+
+func ParsePublicKey(s string) (*rsa.PublicKey, error) {
+	der, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, ErrNoRSA
+	}
+	return pub, nil
+}
+
+// EncodePrivateKey - PKCS1 form of private key, it is wrapped by client before storage
+func EncodePrivateKey(prv *rsa.PrivateKey) []byte {
+	return x509.MarshalPKCS1PrivateKey(prv)
+}
+
+func ParsePrivateKey(der []byte) (*rsa.PrivateKey, error) {
+	return x509.ParsePKCS1PrivateKey(der)
+}
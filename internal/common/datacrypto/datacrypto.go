@@ -27,32 +27,52 @@ func (d *DataCryptDecrypt) Encrypt(data *store.UserData) (*store.UserDataCrypt,
 	if err != nil {
 		return nil, nil, err
 	}
+	dataEnc, err := d.EncryptWith(data, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dataEnc, key, nil
+}
 
+// EncryptWith - data encrypted by existing key of item, keys wrapped for shares stay valid
+func (d *DataCryptDecrypt) EncryptWith(data *store.UserData, key *aescoder.KeyAES) (*store.UserDataCrypt, error) {
 	dataEnc := &store.UserDataCrypt{
-		Id:       data.Id,
-		Uuid:     data.Uuid,
-		TypeData: data.TypeData,
-		EnKey:    key.GetKey(),
+		Id:         data.Id,
+		Uuid:       data.Uuid,
+		TypeData:   data.TypeData,
+		EnKey:      key.GetKey(),
+		Tokens:     data.Tokens,
+		Parent:     data.Parent,
+		Size:       data.Size,
+		Checksum:   data.Checksum,
+		Codec:      data.Codec,
+		Chunked:    data.Chunked,
+		Chunks:     data.Chunks,
+		Object:     data.Object,
+		ExpiresAt:  data.ExpiresAt,
+		ChangedAt:  data.ChangedAt,
+		Rotation:   data.Rotation,
+		Collection: data.Collection,
 	}
 
 	var wData bytes.Buffer
 	wrD, err := aescoder.NewWriter(&wData, key)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	wrD.WriteOne([]byte(data.UserData))
 
 	var wMe bytes.Buffer
 	wrMe, err := aescoder.NewWriter(&wMe, key)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	wrMe.WriteOne([]byte(data.MetaData))
 	dataEnc.UserDataEn = make([]byte, wData.Len())
 	dataEnc.MetaDataEn = make([]byte, wMe.Len())
 	copy(dataEnc.UserDataEn, wData.Bytes())
 	copy(dataEnc.MetaDataEn, wMe.Bytes())
-	return dataEnc, key, nil
+	return dataEnc, nil
 }
 
 func (d *DataCryptDecrypt) Decrypt(dataEnc *store.UserDataCrypt) (*store.UserData, *aescoder.KeyAES, error) {
@@ -62,9 +82,21 @@ func (d *DataCryptDecrypt) Decrypt(dataEnc *store.UserDataCrypt) (*store.UserDat
 		return nil, nil, err
 	}
 	data := &store.UserData{
-		Id:       dataEnc.Id,
-		Uuid:     dataEnc.Uuid,
-		TypeData: dataEnc.TypeData,
+		Id:         dataEnc.Id,
+		Uuid:       dataEnc.Uuid,
+		TypeData:   dataEnc.TypeData,
+		Tokens:     dataEnc.Tokens,
+		Parent:     dataEnc.Parent,
+		Size:       dataEnc.Size,
+		Checksum:   dataEnc.Checksum,
+		Codec:      dataEnc.Codec,
+		Chunked:    dataEnc.Chunked,
+		Chunks:     dataEnc.Chunks,
+		Object:     dataEnc.Object,
+		ExpiresAt:  dataEnc.ExpiresAt,
+		ChangedAt:  dataEnc.ChangedAt,
+		Rotation:   dataEnc.Rotation,
+		Collection: dataEnc.Collection,
 	}
 
 	r := bytes.NewReader(dataEnc.UserDataEn)
@@ -95,3 +127,13 @@ func (d *DataCryptDecrypt) Decrypt(dataEnc *store.UserDataCrypt) (*store.UserDat
 	data.MetaData = string(npMeta)
 	return data, key, nil
 }
+
+// NewKey - random key wrapped by server key, key of collection
+func (d *DataCryptDecrypt) NewKey() (*aescoder.KeyAES, error) {
+	return aescoder.NewAES(d.pubKey)
+}
+
+// OpenKey - key wrapped by NewKey
+func (d *DataCryptDecrypt) OpenKey(enKey string) (*aescoder.KeyAES, error) {
+	return aescoder.DecodeAESKey(d.privKey, enKey)
+}
@@ -2,56 +2,346 @@
 package datafile
 
 import (
+	"context"
 	"errors"
+	"io"
+	"os"
+	"path/filepath"
 
 	"github.com/4aleksei/gokeeper/internal/common/aescoder"
+	"github.com/4aleksei/gokeeper/internal/common/store"
+	"github.com/4aleksei/gokeeper/internal/common/streams/compressors/codecs"
 	"github.com/4aleksei/gokeeper/internal/common/streams/encoders/aesstream"
+	"github.com/4aleksei/gokeeper/internal/common/streams/hashers/sha256stream"
 	"github.com/4aleksei/gokeeper/internal/common/streams/sources"
+	"github.com/4aleksei/gokeeper/internal/common/streams/sources/chunkstore"
 	"github.com/4aleksei/gokeeper/internal/common/streams/sources/readwrite"
+	"github.com/4aleksei/gokeeper/internal/common/streams/sources/replica"
+	"github.com/4aleksei/gokeeper/internal/common/streams/sources/s3object"
 	"github.com/4aleksei/gokeeper/internal/common/streams/sources/singlefile"
+	"github.com/4aleksei/gokeeper/internal/common/streams/sources/tee"
 )
 
 type (
+	checksumI interface {
+		Sum() string
+		State() []byte
+	}
+
+	// Position - progress of written file, to continue write
+	Position struct {
+		Size      int64
+		Stored    int64
+		HashState []byte
+	}
+
+	// Replication - second store of written file, quorum of primary and replica
+	Replication struct {
+		Store  replica.Store
+		Quorum int
+	}
+
+	manifestI interface {
+		Chunks() []store.Chunk
+	}
+
 	LongtermfileWrite struct {
 		success  bool
+		keep     bool
+		limit    int64
 		closed   bool
+		size     int64
 		filename string
+		hash     checksumI
+		stored   *counter
+		chunks   *chunkstore.Store
+		manifest manifestI
+		objects  *s3object.Client
+		replica  replica.Store
 		writer   *sources.SourceWriter
 	}
 
 	LongtermfileRead struct {
 		closed   bool
+		skip     int64
+		remain   int64
+		limited  bool
 		filename string
 		reader   *sources.SourceReader
 	}
+
+	// counter - bytes stored after compression, before encryption
+	counter struct {
+		w io.Writer
+		n int64
+	}
+
+	// storeSource - blob file of replica store as source of reader
+	storeSource struct {
+		ctx  context.Context
+		from replica.Store
+		name string
+		body io.ReadCloser
+	}
 )
 
 var (
 	ErrFileWriteNotSucc = errors.New("error,file write not success")
+	ErrFileShort        = errors.New("error,file shorter than offset")
+	ErrSizeLimit        = errors.New("error,data exceeds size limit")
 )
 
-func NewWrite(filename string, key *aescoder.KeyAES) *LongtermfileWrite {
+// NewWrite - writer of file, data compressed by codec, empty codec - not compressed,
+// rep - replica written at once with file, nil - no replica
+func NewWrite(filename string, key *aescoder.KeyAES, codec string, rep *Replication) (*LongtermfileWrite, error) {
+	lw, err := newWrite(filename, codec, Position{},
+		sources.WithDestinationWriter(rep.tee(filepath.Base(filename), singlefile.NewWriter(filename))),
+		sources.WithMiddleWriter(aesstream.NewWriter(key)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	lw.replica = rep.store()
+	return lw, nil
+}
+
+// NewObjectWrite - writer of object name in object store, data compressed by codec
+func NewObjectWrite(objects *s3object.Client, name string, key *aescoder.KeyAES, codec string, rep *Replication) (*LongtermfileWrite, error) {
+	lw, err := newWrite(name, codec, Position{},
+		sources.WithDestinationWriter(rep.tee(name, objects.NewWriter(name))),
+		sources.WithMiddleWriter(aesstream.NewWriter(key)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	lw.objects = objects
+	lw.replica = rep.store()
+	return lw, nil
+}
+
+// tee - primary destination and replica of name
+func (r *Replication) tee(name string, primary tee.DestinationWriter) tee.DestinationWriter {
+	if r == nil || r.Store == nil {
+		return primary
+	}
+	return tee.NewWriter(r.Quorum, primary, r.Store.NewWriter(name))
+}
+
+func (r *Replication) store() replica.Store {
+	if r == nil {
+		return nil
+	}
+	return r.Store
+}
+
+// NewWriteAt - continue file written up to position, short tail block is rewritten
+func NewWriteAt(filename string, key *aescoder.KeyAES, codec string, pos Position) (*LongtermfileWrite, error) {
+	index := pos.Stored / int64(aescoder.BlockSize)
+	tail, err := cutTail(filename, key, index, pos.Stored%int64(aescoder.BlockSize))
+	if err != nil {
+		return nil, err
+	}
+	return newWrite(filename, codec, pos,
+		sources.WithDestinationWriter(singlefile.NewAppendWriter(filename)),
+		sources.WithMiddleWriter(aesstream.NewWriterAt(key, uint64(index), tail)),
+	)
+}
+
+// newWrite - conveer: hash of plain data, compression, count of stored bytes, encryption, file
+func newWrite(filename string, codec string, pos Position, dest ...sources.OptionSourceWriter) (*LongtermfileWrite, error) {
+	hash, err := sha256stream.NewWriterState(pos.HashState)
+	if err != nil {
+		return nil, err
+	}
+	comp, err := codecs.NewWriter(codec)
+	if err != nil {
+		return nil, err
+	}
+	stored := &counter{n: pos.Stored}
+	opts := append(dest, sources.WithSourceWriter(&readwrite.ByteWriter{}),
+		sources.WithMiddleWriter(stored),
+	)
+	if comp != nil {
+		opts = append(opts, sources.WithMiddleWriter(comp))
+	}
+	opts = append(opts, sources.WithMiddleWriter(hash))
+	lw := &LongtermfileWrite{
+		writer:   sources.CreateWriter(opts...),
+		filename: filename,
+		size:     pos.Size,
+		hash:     hash,
+		stored:   stored,
+	}
+	return lw, nil
+}
+
+// NewChunkWrite - writer of content-defined chunks, chunks - manifest of data written before
+func NewChunkWrite(cs *chunkstore.Store, chunks []store.Chunk, pos Position) (*LongtermfileWrite, error) {
+	hash, err := sha256stream.NewWriterState(pos.HashState)
+	if err != nil {
+		return nil, err
+	}
+	dest := cs.NewWriter(chunks)
 	lw := &LongtermfileWrite{
-		writer: sources.CreateWriter(sources.WithDestinationWriter(singlefile.NewWriter(filename)),
+		writer: sources.CreateWriter(sources.WithDestinationWriter(dest),
 			sources.WithSourceWriter(&readwrite.ByteWriter{}),
-			sources.WithMiddleWriter(aesstream.NewWriter(key)),
+			sources.WithMiddleWriter(hash),
 		),
+		size:     pos.Size,
+		hash:     hash,
+		stored:   &counter{n: pos.Stored},
+		chunks:   cs,
+		manifest: dest,
+	}
+	return lw, nil
+}
+
+func (c *counter) OpenWriter(w io.Writer) (io.Writer, error) {
+	c.w = w
+	return c, nil
+}
+
+func (c *counter) CloseWrite() error {
+	return nil
+}
+
+func (c *counter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func cutTail(filename string, key *aescoder.KeyAES, index int64, tailLen int64) ([]byte, error) {
+	file, err := os.OpenFile(filename, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	start := index * int64(aescoder.SealedBlockSize)
+	var tail []byte
+	if tailLen > 0 {
+		sealed := make([]byte, aescoder.SealedSize(tailLen))
+		_, err = file.ReadAt(sealed, start)
+		if err != nil {
+			return nil, ErrFileShort
+		}
+		tail, err = aescoder.OpenBlock(key, uint64(index), sealed)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		info, err := file.Stat()
+		if err != nil {
+			return nil, err
+		}
+		if info.Size() < start {
+			return nil, ErrFileShort
+		}
+	}
+	return tail, file.Truncate(start)
+}
+
+// NewRead - reader of file, data decompressed by codec
+func NewRead(filename string, key *aescoder.KeyAES, codec string) (*LongtermfileRead, error) {
+	return NewReadAt(filename, key, codec, 0, 0)
+}
+
+// NewReadAt - reader of plain range from offset, length zero - to end of file,
+// compressed data is read from start of file
+func NewReadAt(filename string, key *aescoder.KeyAES, codec string, offset int64, length int64) (*LongtermfileRead, error) {
+	return newReadAt(func(pos int64) sources.OptionSourceReader {
+		return sources.WithSourceReader(singlefile.NewReaderAt(filename, pos))
+	}, filename, key, codec, offset, length)
+}
+
+// NewObjectReadAt - reader of plain range of object name in object store
+func NewObjectReadAt(objects *s3object.Client, name string, key *aescoder.KeyAES, codec string, offset int64, length int64) (*LongtermfileRead, error) {
+	return newReadAt(func(pos int64) sources.OptionSourceReader {
+		return sources.WithSourceReader(objects.NewReaderAt(name, pos))
+	}, name, key, codec, offset, length)
+}
+
+// newReadAt - source is opened at position of first sealed block of range
+func newReadAt(source func(pos int64) sources.OptionSourceReader, filename string, key *aescoder.KeyAES,
+	codec string, offset int64, length int64) (*LongtermfileRead, error) {
+	dec, err := codecs.NewReader(codec)
+	if err != nil {
+		return nil, err
+	}
+	index := offset / int64(aescoder.BlockSize)
+	skip := offset % int64(aescoder.BlockSize)
+	if dec != nil {
+		index, skip = 0, offset
+	}
+	opts := []sources.OptionSourceReader{sources.WithDestinationReader(&readwrite.ByteReader{}),
+		source(index * int64(aescoder.SealedBlockSize)),
+		sources.WithMiddleReader(aesstream.NewReaderAt(key, uint64(index))),
+	}
+	if dec != nil {
+		opts = append(opts, sources.WithMiddleReader(dec))
+	}
+	lr := &LongtermfileRead{
+		reader:   sources.CreateReader(opts...),
 		filename: filename,
+		skip:     skip,
+		remain:   length,
+		limited:  length > 0,
 	}
-	return lw
+	return lr, nil
 }
 
-func NewRead(filename string, key *aescoder.KeyAES) *LongtermfileRead {
+// NewChunkReadAt - reader of plain range of chunked data, length zero - to end
+func NewChunkReadAt(cs *chunkstore.Store, chunks []store.Chunk, offset int64, length int64) *LongtermfileRead {
 	lr := &LongtermfileRead{
 		reader: sources.CreateReader(sources.WithDestinationReader(&readwrite.ByteReader{}),
-			sources.WithSourceReader(singlefile.NewReader(filename)),
-			sources.WithMiddleReader(aesstream.NewReader(key)),
+			sources.WithSourceReader(cs.NewReaderAt(chunks, offset)),
 		),
-		filename: filename,
+		remain:  length,
+		limited: length > 0,
 	}
 	return lr
 }
 
+func (s *storeSource) OpenReader() (io.Reader, error) {
+	var err error
+	s.body, err = s.from.Open(s.ctx, s.name)
+	return s.body, err
+}
+
+func (s *storeSource) CloseRead() error {
+	if s.body == nil {
+		return nil
+	}
+	defer func() { s.body = nil }()
+	return s.body.Close()
+}
+
+// Verify - plain size and hex sha256 of blob file name of store, file is decrypted and decompressed
+func Verify(ctx context.Context, from replica.Store, name string, key *aescoder.KeyAES, codec string) (int64, string, error) {
+	lr, err := newReadAt(func(int64) sources.OptionSourceReader {
+		return sources.WithSourceReader(&storeSource{ctx: ctx, from: from, name: name})
+	}, name, key, codec, 0, 0)
+	if err != nil {
+		return 0, "", err
+	}
+	err = lr.OpenReader()
+	if err != nil {
+		_ = lr.CloseRead()
+		return 0, "", err
+	}
+	defer lr.CloseRead()
+	hash := sha256stream.NewWriter()
+	w, err := hash.OpenWriter(io.Discard)
+	if err != nil {
+		return 0, "", err
+	}
+	n, err := io.Copy(w, readerFunc(lr.ReadData))
+	if err != nil {
+		return 0, "", err
+	}
+	return n, hash.Sum(), nil
+}
+
 func (l *LongtermfileWrite) Success() {
 	l.success = true
 }
@@ -60,34 +350,141 @@ func (l *LongtermfileWrite) OpenWriter() error {
 	return l.writer.OpenWriter()
 }
 
+// Keep - written part of file is kept on failure, it is continued by resumable upload
+func (l *LongtermfileWrite) Keep() {
+	l.keep = true
+}
+
+// CloseWrite - failed or not success file is unlinked, unless it is kept
 func (l *LongtermfileWrite) CloseWrite() error {
 	if l.closed {
 		return nil
 	}
 	l.closed = true
 	err := l.writer.CloseWrite()
-	if err != nil {
-		// Unlink file
-		return err
+	if err == nil && !l.success {
+		err = ErrFileWriteNotSucc
 	}
-	if !l.success {
-		//	Unlink file
-		return ErrFileWriteNotSucc
+	if err != nil && !l.keep {
+		return errors.Join(err, l.Remove())
 	}
-	return nil
+	return err
+}
+
+// Limit - plain data above n bytes is not written, 0 - no limit
+func (l *LongtermfileWrite) Limit(n int64) {
+	l.limit = n
 }
 
 func (l *LongtermfileWrite) WriteData(b []byte) (int, error) {
+	if l.limit > 0 && l.size+int64(len(b)) > l.limit {
+		return 0, ErrSizeLimit
+	}
+	n, err := l.writer.WriteData(b)
+	if err == nil {
+		l.size += int64(len(b))
+	}
+	return n, err
+}
+
+// Size - bytes of plain data in file
+func (l *LongtermfileWrite) Size() int64 {
+	return l.size
+}
+
+// Checksum - hex sha256 of plain data in file
+func (l *LongtermfileWrite) Checksum() string {
+	return l.hash.Sum()
+}
 
-	return l.writer.WriteData(b)
+// Position - progress of closed file, to continue write
+func (l *LongtermfileWrite) Position() Position {
+	return Position{
+		Size:      l.size,
+		Stored:    l.stored.n,
+		HashState: l.hash.State(),
+	}
+}
+
+// Chunks - manifest of chunked data, nil for file
+func (l *LongtermfileWrite) Chunks() []store.Chunk {
+	if l.manifest == nil {
+		return nil
+	}
+	return l.manifest.Chunks()
+}
+
+// Remove - unlink written file, object or release written chunks
+func (l *LongtermfileWrite) Remove() error {
+	if l.chunks != nil {
+		return l.chunks.Release(l.Chunks())
+	}
+	var errR error
+	if l.replica != nil {
+		errR = l.replica.Remove(context.Background(), filepath.Base(l.filename))
+	}
+	if l.objects != nil {
+		return errors.Join(l.objects.Remove(context.Background(), l.filename), errR)
+	}
+	return errors.Join(Remove(l.filename), errR)
+}
+
+// Push - copy closed local file to store, name in store is base name of file
+func (l *LongtermfileWrite) Push(ctx context.Context, to replica.Store) error {
+	file, err := os.Open(l.filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return replica.Write(ctx, to, filepath.Base(l.filename), file)
+}
+
+// Move - push closed local file to store and unlink it
+func (l *LongtermfileWrite) Move(ctx context.Context, to replica.Store) error {
+	err := l.Push(ctx, to)
+	if err != nil {
+		return err
+	}
+	return Remove(l.filename)
+}
+
+func Remove(filename string) error {
+	err := os.Remove(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
 }
 
 func (l *LongtermfileRead) ReadData(b []byte) (int, error) {
-	return l.reader.ReadData(b)
+	if !l.limited {
+		return l.reader.ReadData(b)
+	}
+	if l.remain <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(b)) > l.remain {
+		b = b[:l.remain]
+	}
+	n, err := l.reader.ReadData(b)
+	l.remain -= int64(n)
+	return n, err
 }
 
 func (l *LongtermfileRead) OpenReader() error {
-	return l.reader.OpenReader()
+	err := l.reader.OpenReader()
+	if err != nil || l.skip == 0 {
+		return err
+	}
+	// start of first block or compressed data before offset
+	_, err = io.CopyN(io.Discard, readerFunc(l.reader.ReadData), l.skip)
+	return err
+}
+
+type readerFunc func([]byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) {
+	return f(p)
 }
 
 func (l *LongtermfileRead) CloseRead() error {
@@ -9,5 +9,8 @@ type (
 	ServerEncoder interface {
 		Encrypt(*store.UserData) (*store.UserDataCrypt, *aescoder.KeyAES, error)
 		Decrypt(*store.UserDataCrypt) (*store.UserData, *aescoder.KeyAES, error)
+		EncryptWith(*store.UserData, *aescoder.KeyAES) (*store.UserDataCrypt, error)
+		NewKey() (*aescoder.KeyAES, error)
+		OpenKey(string) (*aescoder.KeyAES, error)
 	}
 )
@@ -2,15 +2,77 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"github.com/4aleksei/gokeeper/internal/common/store"
 )
 
 type (
 	ServerStorage interface {
-		AddUser(context.Context, string, string) (*store.User, error)
+		AddUser(context.Context, string, string, string) (*store.User, error)
 		GetUser(context.Context, string) (*store.User, error)
+		GetUserByID(context.Context, uint64) (*store.User, error)
+		UpdateUser(context.Context, *store.User) error
+		SwapUser(context.Context, *store.User, *store.User) (bool, error)
+		DeleteUser(context.Context, uint64) error
 		AddData(context.Context, *store.UserDataCrypt) error
 		GetData(context.Context, string) (*store.UserDataCrypt, error)
+		UpdateData(context.Context, *store.UserDataCrypt) error
+		DeleteData(context.Context, string) error
+		GetListData(context.Context, uint64) ([]*store.UserDataCrypt, error)
+		GetAllData(context.Context) ([]*store.UserDataCrypt, error)
+		GetUsage(context.Context, uint64) (*store.Usage, error)
+		AddUpload(context.Context, *store.UploadSession) error
+		GetUpload(context.Context, uint64, string) (*store.UploadSession, error)
+		UpdateUpload(context.Context, *store.UploadSession) error
+		DeleteUpload(context.Context, uint64, string) error
+		GetAllUploads(context.Context) ([]*store.UploadSession, error)
+		AddShare(context.Context, *store.Share) error
+		GetShare(context.Context, string, uint64) (*store.Share, error)
+		GetSharesOf(context.Context, string) ([]*store.Share, error)
+		GetSharesTo(context.Context, uint64) ([]*store.Share, error)
+		DeleteShare(context.Context, string, uint64) error
+		AcquireChunk(context.Context, string) (int64, error)
+		ReleaseChunk(context.Context, string) (int64, error)
+		AddOrg(context.Context, *store.Org) error
+		GetOrg(context.Context, string) (*store.Org, error)
+		SetMember(context.Context, *store.Member) error
+		GetMember(context.Context, string, uint64) (*store.Member, error)
+		GetMembers(context.Context, string) ([]*store.Member, error)
+		GetMemberships(context.Context, uint64) ([]*store.Member, error)
+		DeleteMember(context.Context, string, uint64) error
+		AddCollection(context.Context, *store.Collection) error
+		GetCollection(context.Context, string) (*store.Collection, error)
+		UpdateCollection(context.Context, *store.Collection) error
+		GetCollections(context.Context, string) ([]*store.Collection, error)
+		GetCollectionData(context.Context, string) ([]*store.UserDataCrypt, error)
+		AddSecret(context.Context, *store.Secret) error
+		TakeSecret(context.Context, string, time.Time) (*store.Secret, error)
+		DeleteExpiredSecrets(context.Context, time.Time) (int, error)
+		DeleteSecrets(context.Context, uint64) (int, error)
+		AddEmergency(context.Context, *store.Emergency) error
+		GetEmergency(context.Context, string) (*store.Emergency, error)
+		UpdateEmergency(context.Context, *store.Emergency) error
+		DeleteEmergency(context.Context, string) error
+		GetEmergencies(context.Context, uint64) ([]*store.Emergency, error)
+		AddRefreshToken(context.Context, *store.RefreshToken) error
+		TakeRefreshToken(context.Context, string) (*store.RefreshToken, error)
+		DeleteExpiredRefreshTokens(context.Context, time.Time) (int, error)
+		DeleteRefreshTokens(context.Context, uint64) (int, error)
+		RevokeToken(context.Context, string, time.Time) error
+		IsTokenRevoked(context.Context, string) (bool, error)
+		DeleteExpiredRevocations(context.Context, time.Time) (int, error)
+		AddSession(context.Context, *store.Session) error
+		GetSession(context.Context, string) (*store.Session, error)
+		TouchSession(context.Context, string, time.Time) error
+		DeleteSession(context.Context, string) error
+		GetSessions(context.Context, uint64) ([]*store.Session, error)
+		DeleteStaleSessions(context.Context, time.Time) (int, error)
+		AddAPIToken(context.Context, *store.APIToken) error
+		GetAPIToken(context.Context, string) (*store.APIToken, error)
+		TouchAPIToken(context.Context, string, time.Time) error
+		DeleteAPIToken(context.Context, uint64, string) error
+		GetAPITokens(context.Context, uint64) ([]*store.APIToken, error)
+		DeleteExpiredAPITokens(context.Context, time.Time) (int, error)
 	}
 )
@@ -0,0 +1,145 @@
+// Package search - normalization, blind index and ranking of item metadata
+package search
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	scoreExact  int = 2
+	scorePrefix int = 1
+	minWordLen  int = 2
+	markOpen        = "*"
+	markClose       = "*"
+)
+
+type (
+	Match struct {
+		Score int
+		Words []string
+	}
+)
+
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Normalize - lower case unique words of text, short words are skipped
+func Normalize(text string) []string {
+	var res []string
+	seen := make(map[string]struct{})
+	for _, w := range splitWords(text) {
+		w = strings.ToLower(w)
+		if len([]rune(w)) < minWordLen {
+			continue
+		}
+		if _, ok := seen[w]; ok {
+			continue
+		}
+		seen[w] = struct{}{}
+		res = append(res, w)
+	}
+	return res
+}
+
+// BlindTokens - keyed HMAC tokens of normalized words, server never sees the words
+func BlindTokens(key []byte, text string) []string {
+	words := Normalize(text)
+	res := make([]string, 0, len(words))
+	for _, w := range words {
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte(w))
+		res = append(res, hex.EncodeToString(h.Sum(nil)))
+	}
+	return res
+}
+
+// MatchTokens - count of query tokens present in item tokens
+func MatchTokens(itemTokens, query []string) int {
+	set := make(map[string]struct{}, len(itemTokens))
+	for _, t := range itemTokens {
+		set[t] = struct{}{}
+	}
+	var score int
+	for _, t := range query {
+		if _, ok := set[t]; ok {
+			score++
+		}
+	}
+	return score
+}
+
+// MatchText - rank text against query words, exact word match is ranked above prefix
+func MatchText(text string, query []string) Match {
+	var m Match
+	words := Normalize(text)
+	for _, q := range query {
+		best := 0
+		for _, w := range words {
+			switch {
+			case w == q:
+				best = scoreExact
+			case strings.HasPrefix(w, q) && best < scorePrefix:
+				best = scorePrefix
+			}
+		}
+		if best > 0 {
+			m.Score += best
+			m.Words = append(m.Words, q)
+		}
+	}
+	return m
+}
+
+// Highlight - mark words of text starting with any of query words
+func Highlight(text string, query []string) string {
+	if len(query) == 0 {
+		return text
+	}
+	var b strings.Builder
+	var word []rune
+	flush := func() {
+		if len(word) == 0 {
+			return
+		}
+		w := string(word)
+		lw := strings.ToLower(w)
+		marked := false
+		for _, q := range query {
+			if strings.HasPrefix(lw, q) {
+				marked = true
+				break
+			}
+		}
+		if marked {
+			b.WriteString(markOpen + w + markClose)
+		} else {
+			b.WriteString(w)
+		}
+		word = word[:0]
+	}
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			word = append(word, r)
+			continue
+		}
+		flush()
+		b.WriteRune(r)
+	}
+	flush()
+	return b.String()
+}
+
+// Rank - sort results by score desc, stable for equal scores
+func Rank[T any](items []T, score func(T) int) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return score(items[i]) > score(items[j])
+	})
+}
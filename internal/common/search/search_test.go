@@ -0,0 +1,30 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Normalize(t *testing.T) {
+	assert.Equal(t, []string{"github", "work", "account"}, Normalize("GitHub: work-account, github a"))
+}
+
+func Test_BlindTokens(t *testing.T) {
+	t.Run("Test same word same token", func(t *testing.T) {
+		assert.Equal(t, BlindTokens([]byte("k"), "Card"), BlindTokens([]byte("k"), "card"))
+	})
+	t.Run("Test other key other token", func(t *testing.T) {
+		assert.NotEqual(t, BlindTokens([]byte("k1"), "card"), BlindTokens([]byte("k2"), "card"))
+	})
+}
+
+func Test_MatchText(t *testing.T) {
+	m := MatchText("github work account", []string{"github", "acc", "mail"})
+	assert.Equal(t, scoreExact+scorePrefix, m.Score)
+	assert.Equal(t, []string{"github", "acc"}, m.Words)
+}
+
+func Test_Highlight(t *testing.T) {
+	assert.Equal(t, "*GitHub*: work *account*", Highlight("GitHub: work account", []string{"github", "acc"}))
+}
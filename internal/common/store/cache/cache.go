@@ -17,13 +17,74 @@ type (
 	StoreCache struct {
 		users     sync.Map
 		usersData cacheStore
+		uploads   sync.Map
+		chunks    chunkRefs
+		shares    shareStore
+		orgs      orgStore
+		secrets   secretStore
+		emergency emergencyStore
+		refresh   refreshStore
+		revoked   revokedStore
+		sessions  sessionStore
+		apiTokens apiTokenStore
 		l         *zap.Logger
 	}
 
+	apiTokenStore struct {
+		lock   sync.RWMutex
+		tokens map[string]*store.APIToken
+	}
+
+	sessionStore struct {
+		lock     sync.RWMutex
+		sessions map[string]*store.Session
+	}
+
+	// revokedStore - jti of revoked tokens until their expiry
+	revokedStore struct {
+		lock sync.RWMutex
+		ids  map[string]time.Time
+	}
+
+	refreshStore struct {
+		lock   sync.Mutex
+		tokens map[string]*store.RefreshToken
+	}
+
+	emergencyStore struct {
+		lock   sync.RWMutex
+		grants map[string]*store.Emergency
+	}
+
+	secretStore struct {
+		lock    sync.Mutex
+		secrets map[string]*store.Secret
+	}
+
+	// orgStore - organizations, members by organization and user, collections
+	orgStore struct {
+		lock        sync.RWMutex
+		orgs        map[string]*store.Org
+		members     map[string]map[uint64]*store.Member
+		collections map[string]*store.Collection
+	}
+
+	// shareStore - shares of item by recipient
+	shareStore struct {
+		lock   sync.RWMutex
+		shares map[string]map[uint64]*store.Share
+	}
+
+	chunkRefs struct {
+		lock sync.Mutex
+		refs map[string]int64
+	}
+
 	cacheStore struct {
 		lock      sync.RWMutex
 		uuidUsers map[uint64][]*store.UserDataCrypt
 		dataUsers map[string]*store.UserDataCrypt
+		usage     map[uint64]*store.Usage
 	}
 )
 
@@ -40,6 +101,18 @@ func New(l *zap.Logger) *StoreCache {
 
 	stor.usersData.uuidUsers = make(map[uint64][]*store.UserDataCrypt)
 	stor.usersData.dataUsers = make(map[string]*store.UserDataCrypt)
+	stor.usersData.usage = make(map[uint64]*store.Usage)
+	stor.chunks.refs = make(map[string]int64)
+	stor.shares.shares = make(map[string]map[uint64]*store.Share)
+	stor.orgs.orgs = make(map[string]*store.Org)
+	stor.orgs.members = make(map[string]map[uint64]*store.Member)
+	stor.orgs.collections = make(map[string]*store.Collection)
+	stor.secrets.secrets = make(map[string]*store.Secret)
+	stor.emergency.grants = make(map[string]*store.Emergency)
+	stor.refresh.tokens = make(map[string]*store.RefreshToken)
+	stor.revoked.ids = make(map[string]time.Time)
+	stor.sessions.sessions = make(map[string]*store.Session)
+	stor.apiTokens.tokens = make(map[string]*store.APIToken)
 	return stor
 }
 
@@ -52,6 +125,75 @@ func (c *cacheStore) AddData(userdata *store.UserDataCrypt) error {
 	}
 	c.dataUsers[userdata.Uuid] = userdata
 	c.uuidUsers[userdata.Id] = append(c.uuidUsers[userdata.Id], userdata)
+	c.addUsage(userdata, 1)
+	return nil
+}
+
+// addUsage - count item of user, sign -1 - item is removed
+func (c *cacheStore) addUsage(userdata *store.UserDataCrypt, sign int64) {
+	u, ok := c.usage[userdata.Id]
+	if !ok {
+		u = &store.Usage{}
+		c.usage[userdata.Id] = u
+	}
+	u.Items += sign
+	u.Bytes += sign * usedBytes(userdata)
+}
+
+// usedBytes - plain size of blob, stored size of other item
+func usedBytes(userdata *store.UserDataCrypt) int64 {
+	if userdata.Blob {
+		return userdata.Size
+	}
+	return int64(len(userdata.UserDataEn) + len(userdata.MetaDataEn))
+}
+
+func (c *cacheStore) GetUsage(userID uint64) store.Usage {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	u, ok := c.usage[userID]
+	if !ok {
+		return store.Usage{}
+	}
+	return *u
+}
+
+func (c *cacheStore) UpdateData(userdata *store.UserDataCrypt) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	old, ok := c.dataUsers[userdata.Uuid]
+	if !ok {
+		return ErrValueNotFound
+	}
+	c.dataUsers[userdata.Uuid] = userdata
+	list := c.uuidUsers[old.Id]
+	for i, v := range list {
+		if v.Uuid == userdata.Uuid {
+			list[i] = userdata
+			break
+		}
+	}
+	c.addUsage(old, -1)
+	c.addUsage(userdata, 1)
+	return nil
+}
+
+func (c *cacheStore) DeleteData(uuid string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	data, ok := c.dataUsers[uuid]
+	if !ok {
+		return ErrValueNotFound
+	}
+	delete(c.dataUsers, uuid)
+	c.addUsage(data, -1)
+	list := c.uuidUsers[data.Id]
+	for i, v := range list {
+		if v.Uuid == uuid {
+			c.uuidUsers[data.Id] = append(list[:i:i], list[i+1:]...)
+			break
+		}
+	}
 	return nil
 }
 
@@ -82,10 +224,12 @@ func getId() uint64 {
 	return idUsers.Load()
 }
 
-func (s *StoreCache) AddUser(ctx context.Context, user string, pass string) (*store.User, error) {
+// AddUser - new user with hash of password and vault key wrapped by client, empty - no end-to-end mode
+func (s *StoreCache) AddUser(ctx context.Context, user string, pass string, vaultKey string) (*store.User, error) {
 	userSt := &store.User{
 		Name:     user,
 		HashPass: pass,
+		VaultKey: vaultKey,
 		Id:       getId(),
 	}
 	s.l.Debug("Add user", zap.String("Name", user))
@@ -105,10 +249,64 @@ func (s *StoreCache) GetUser(ctx context.Context, user string) (*store.User, err
 	return nil, ErrUserNotFound
 }
 
+// GetUserByID - user of token
+func (s *StoreCache) GetUserByID(ctx context.Context, id uint64) (*store.User, error) {
+	var user *store.User
+	s.users.Range(func(_, v any) bool {
+		u := v.(*store.User)
+		if u.Id == id {
+			user = u
+			return false
+		}
+		return true
+	})
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (s *StoreCache) UpdateUser(ctx context.Context, user *store.User) error {
+	_, ok := s.users.Load(user.Name)
+	if !ok {
+		return ErrUserNotFound
+	}
+	s.users.Store(user.Name, user)
+	return nil
+}
+
+// SwapUser - updated replaces old if user is not changed since old was read, false - user is changed, old is read again
+func (s *StoreCache) SwapUser(ctx context.Context, old *store.User, updated *store.User) (bool, error) {
+	if s.users.CompareAndSwap(old.Name, old, updated) {
+		return true, nil
+	}
+	if _, ok := s.users.Load(old.Name); !ok {
+		return false, ErrUserNotFound
+	}
+	return false, nil
+}
+
+// DeleteUser - user of account, items of user are deleted before
+func (s *StoreCache) DeleteUser(ctx context.Context, id uint64) error {
+	user, err := s.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.users.Delete(user.Name)
+	s.usersData.lock.Lock()
+	defer s.usersData.lock.Unlock()
+	delete(s.usersData.uuidUsers, id)
+	delete(s.usersData.usage, id)
+	return nil
+}
+
 func (s *StoreCache) AddData(ctx context.Context, userdata *store.UserDataCrypt) error {
 	uuid := uuid.New()
 	userdata.Uuid = uuid.String()
 	userdata.TimeStamp = time.Now()
+	if userdata.ChangedAt.IsZero() {
+		userdata.ChangedAt = userdata.TimeStamp
+	}
 	err := s.usersData.AddData(userdata)
 	if err != nil {
 		return ErrValueExists
@@ -116,6 +314,11 @@ func (s *StoreCache) AddData(ctx context.Context, userdata *store.UserDataCrypt)
 	return nil
 }
 
+// UpdateData - replace item of same uuid
+func (s *StoreCache) UpdateData(ctx context.Context, userdata *store.UserDataCrypt) error {
+	return s.usersData.UpdateData(userdata)
+}
+
 func (s *StoreCache) GetData(ctx context.Context, uuid string) (*store.UserDataCrypt, error) {
 	data, err := s.usersData.GetData(uuid)
 	if err != nil {
@@ -123,3 +326,668 @@ func (s *StoreCache) GetData(ctx context.Context, uuid string) (*store.UserDataC
 	}
 	return data, nil
 }
+
+func (s *StoreCache) GetListData(ctx context.Context, userID uint64) ([]*store.UserDataCrypt, error) {
+	data, err := s.usersData.GetList(userID)
+	if err != nil {
+		// no items yet - empty list
+		return nil, nil
+	}
+	res := make([]*store.UserDataCrypt, len(data))
+	copy(res, data)
+	return res, nil
+}
+
+// GetUsage - count and bytes of items of user
+func (s *StoreCache) GetUsage(ctx context.Context, userID uint64) (*store.Usage, error) {
+	u := s.usersData.GetUsage(userID)
+	return &u, nil
+}
+
+// GetAllData - items of all users
+func (s *StoreCache) GetAllData(ctx context.Context) ([]*store.UserDataCrypt, error) {
+	s.usersData.lock.RLock()
+	defer s.usersData.lock.RUnlock()
+	res := make([]*store.UserDataCrypt, 0, len(s.usersData.dataUsers))
+	for _, v := range s.usersData.dataUsers {
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+func (s *StoreCache) DeleteData(ctx context.Context, uuid string) error {
+	return s.usersData.DeleteData(uuid)
+}
+
+// uploadKey - upload session of user, session id is chosen by client, it is unique per user only
+type uploadKey struct {
+	user uint64
+	id   string
+}
+
+func (s *StoreCache) AddUpload(ctx context.Context, upload *store.UploadSession) error {
+	upload.TimeStamp = time.Now()
+	_, ok := s.uploads.LoadOrStore(uploadKey{user: upload.UserId, id: upload.Id}, upload)
+	if ok {
+		return ErrValueExists
+	}
+	return nil
+}
+
+func (s *StoreCache) GetUpload(ctx context.Context, user uint64, id string) (*store.UploadSession, error) {
+	val, ok := s.uploads.Load(uploadKey{user: user, id: id})
+	if !ok {
+		return nil, ErrValueNotFound
+	}
+	upload := *val.(*store.UploadSession)
+	return &upload, nil
+}
+
+func (s *StoreCache) UpdateUpload(ctx context.Context, upload *store.UploadSession) error {
+	key := uploadKey{user: upload.UserId, id: upload.Id}
+	_, ok := s.uploads.Load(key)
+	if !ok {
+		return ErrValueNotFound
+	}
+	upload.TimeStamp = time.Now()
+	s.uploads.Store(key, upload)
+	return nil
+}
+
+// GetAllUploads - open upload sessions of all users
+func (s *StoreCache) GetAllUploads(ctx context.Context) ([]*store.UploadSession, error) {
+	var res []*store.UploadSession
+	s.uploads.Range(func(_, v any) bool {
+		upload := *v.(*store.UploadSession)
+		res = append(res, &upload)
+		return true
+	})
+	return res, nil
+}
+
+func (s *StoreCache) DeleteUpload(ctx context.Context, user uint64, id string) error {
+	s.uploads.Delete(uploadKey{user: user, id: id})
+	return nil
+}
+
+// AcquireChunk - add reference of chunk, count of references is returned
+func (s *StoreCache) AcquireChunk(ctx context.Context, id string) (int64, error) {
+	s.chunks.lock.Lock()
+	defer s.chunks.lock.Unlock()
+	s.chunks.refs[id]++
+	return s.chunks.refs[id], nil
+}
+
+// ReleaseChunk - drop reference of chunk, count of references is returned
+func (s *StoreCache) ReleaseChunk(ctx context.Context, id string) (int64, error) {
+	s.chunks.lock.Lock()
+	defer s.chunks.lock.Unlock()
+	refs, ok := s.chunks.refs[id]
+	if !ok {
+		return 0, ErrValueNotFound
+	}
+	if refs <= 1 {
+		delete(s.chunks.refs, id)
+		return 0, nil
+	}
+	s.chunks.refs[id] = refs - 1
+	return refs - 1, nil
+}
+
+// AddShare - share of item to recipient, existing share is replaced
+func (s *StoreCache) AddShare(ctx context.Context, share *store.Share) error {
+	s.shares.lock.Lock()
+	defer s.shares.lock.Unlock()
+	share.TimeStamp = time.Now()
+	byRecipient, ok := s.shares.shares[share.Uuid]
+	if !ok {
+		byRecipient = make(map[uint64]*store.Share)
+		s.shares.shares[share.Uuid] = byRecipient
+	}
+	byRecipient[share.Recipient] = share
+	return nil
+}
+
+func (s *StoreCache) GetShare(ctx context.Context, uuid string, recipient uint64) (*store.Share, error) {
+	s.shares.lock.RLock()
+	defer s.shares.lock.RUnlock()
+	share, ok := s.shares.shares[uuid][recipient]
+	if !ok {
+		return nil, ErrValueNotFound
+	}
+	res := *share
+	return &res, nil
+}
+
+// GetSharesOf - shares of item
+func (s *StoreCache) GetSharesOf(ctx context.Context, uuid string) ([]*store.Share, error) {
+	s.shares.lock.RLock()
+	defer s.shares.lock.RUnlock()
+	var res []*store.Share
+	for _, share := range s.shares.shares[uuid] {
+		v := *share
+		res = append(res, &v)
+	}
+	return res, nil
+}
+
+// GetSharesTo - shares of items to recipient
+func (s *StoreCache) GetSharesTo(ctx context.Context, recipient uint64) ([]*store.Share, error) {
+	s.shares.lock.RLock()
+	defer s.shares.lock.RUnlock()
+	var res []*store.Share
+	for _, byRecipient := range s.shares.shares {
+		if share, ok := byRecipient[recipient]; ok {
+			v := *share
+			res = append(res, &v)
+		}
+	}
+	return res, nil
+}
+
+func (s *StoreCache) DeleteShare(ctx context.Context, uuid string, recipient uint64) error {
+	s.shares.lock.Lock()
+	defer s.shares.lock.Unlock()
+	byRecipient, ok := s.shares.shares[uuid]
+	if !ok {
+		return ErrValueNotFound
+	}
+	if _, ok = byRecipient[recipient]; !ok {
+		return ErrValueNotFound
+	}
+	delete(byRecipient, recipient)
+	if len(byRecipient) == 0 {
+		delete(s.shares.shares, uuid)
+	}
+	return nil
+}
+
+func (s *StoreCache) AddOrg(ctx context.Context, org *store.Org) error {
+	s.orgs.lock.Lock()
+	defer s.orgs.lock.Unlock()
+	org.Id = uuid.New().String()
+	org.TimeStamp = time.Now()
+	s.orgs.orgs[org.Id] = org
+	s.orgs.members[org.Id] = make(map[uint64]*store.Member)
+	return nil
+}
+
+func (s *StoreCache) GetOrg(ctx context.Context, id string) (*store.Org, error) {
+	s.orgs.lock.RLock()
+	defer s.orgs.lock.RUnlock()
+	org, ok := s.orgs.orgs[id]
+	if !ok {
+		return nil, ErrValueNotFound
+	}
+	res := *org
+	return &res, nil
+}
+
+// SetMember - member of organization, role of existing member is replaced
+func (s *StoreCache) SetMember(ctx context.Context, member *store.Member) error {
+	s.orgs.lock.Lock()
+	defer s.orgs.lock.Unlock()
+	members, ok := s.orgs.members[member.Org]
+	if !ok {
+		return ErrValueNotFound
+	}
+	v := *member
+	members[member.User] = &v
+	return nil
+}
+
+func (s *StoreCache) GetMember(ctx context.Context, org string, user uint64) (*store.Member, error) {
+	s.orgs.lock.RLock()
+	defer s.orgs.lock.RUnlock()
+	member, ok := s.orgs.members[org][user]
+	if !ok {
+		return nil, ErrValueNotFound
+	}
+	res := *member
+	return &res, nil
+}
+
+func (s *StoreCache) GetMembers(ctx context.Context, org string) ([]*store.Member, error) {
+	s.orgs.lock.RLock()
+	defer s.orgs.lock.RUnlock()
+	var res []*store.Member
+	for _, member := range s.orgs.members[org] {
+		v := *member
+		res = append(res, &v)
+	}
+	return res, nil
+}
+
+// GetMemberships - organizations of user
+func (s *StoreCache) GetMemberships(ctx context.Context, user uint64) ([]*store.Member, error) {
+	s.orgs.lock.RLock()
+	defer s.orgs.lock.RUnlock()
+	var res []*store.Member
+	for _, members := range s.orgs.members {
+		if member, ok := members[user]; ok {
+			v := *member
+			res = append(res, &v)
+		}
+	}
+	return res, nil
+}
+
+func (s *StoreCache) DeleteMember(ctx context.Context, org string, user uint64) error {
+	s.orgs.lock.Lock()
+	defer s.orgs.lock.Unlock()
+	if _, ok := s.orgs.members[org][user]; !ok {
+		return ErrValueNotFound
+	}
+	delete(s.orgs.members[org], user)
+	return nil
+}
+
+func (s *StoreCache) AddCollection(ctx context.Context, collection *store.Collection) error {
+	s.orgs.lock.Lock()
+	defer s.orgs.lock.Unlock()
+	if _, ok := s.orgs.orgs[collection.Org]; !ok {
+		return ErrValueNotFound
+	}
+	collection.Uuid = uuid.New().String()
+	collection.TimeStamp = time.Now()
+	s.orgs.collections[collection.Uuid] = copyCollection(collection)
+	return nil
+}
+
+func (s *StoreCache) GetCollection(ctx context.Context, id string) (*store.Collection, error) {
+	s.orgs.lock.RLock()
+	defer s.orgs.lock.RUnlock()
+	collection, ok := s.orgs.collections[id]
+	if !ok {
+		return nil, ErrValueNotFound
+	}
+	return copyCollection(collection), nil
+}
+
+func (s *StoreCache) UpdateCollection(ctx context.Context, collection *store.Collection) error {
+	s.orgs.lock.Lock()
+	defer s.orgs.lock.Unlock()
+	if _, ok := s.orgs.collections[collection.Uuid]; !ok {
+		return ErrValueNotFound
+	}
+	s.orgs.collections[collection.Uuid] = copyCollection(collection)
+	return nil
+}
+
+// GetCollections - collections of organization
+func (s *StoreCache) GetCollections(ctx context.Context, org string) ([]*store.Collection, error) {
+	s.orgs.lock.RLock()
+	defer s.orgs.lock.RUnlock()
+	var res []*store.Collection
+	for _, collection := range s.orgs.collections {
+		if collection.Org == org {
+			res = append(res, copyCollection(collection))
+		}
+	}
+	return res, nil
+}
+
+func copyCollection(collection *store.Collection) *store.Collection {
+	res := *collection
+	res.Keys = make(map[uint64]string, len(collection.Keys))
+	for user, key := range collection.Keys {
+		res.Keys[user] = key
+	}
+	return &res
+}
+
+// GetCollectionData - items of collection
+func (s *StoreCache) GetCollectionData(ctx context.Context, collection string) ([]*store.UserDataCrypt, error) {
+	s.usersData.lock.RLock()
+	defer s.usersData.lock.RUnlock()
+	var res []*store.UserDataCrypt
+	for _, data := range s.usersData.dataUsers {
+		if data.Collection == collection {
+			res = append(res, data)
+		}
+	}
+	return res, nil
+}
+
+func (s *StoreCache) AddSecret(ctx context.Context, secret *store.Secret) error {
+	s.secrets.lock.Lock()
+	defer s.secrets.lock.Unlock()
+	secret.Id = uuid.New().String()
+	secret.TimeStamp = time.Now()
+	v := *secret
+	s.secrets.secrets[secret.Id] = &v
+	return nil
+}
+
+// TakeSecret - view of secret, secret is deleted after last view, expired secret is not found
+func (s *StoreCache) TakeSecret(ctx context.Context, id string, now time.Time) (*store.Secret, error) {
+	s.secrets.lock.Lock()
+	defer s.secrets.lock.Unlock()
+	secret, ok := s.secrets.secrets[id]
+	if !ok {
+		return nil, ErrValueNotFound
+	}
+	if !now.Before(secret.ExpiresAt) {
+		delete(s.secrets.secrets, id)
+		return nil, ErrValueNotFound
+	}
+	secret.Views++
+	if secret.Views >= secret.MaxViews {
+		delete(s.secrets.secrets, id)
+	}
+	res := *secret
+	return &res, nil
+}
+
+func (s *StoreCache) DeleteExpiredSecrets(ctx context.Context, now time.Time) (int, error) {
+	s.secrets.lock.Lock()
+	defer s.secrets.lock.Unlock()
+	var n int
+	for id, secret := range s.secrets.secrets {
+		if !now.Before(secret.ExpiresAt) {
+			delete(s.secrets.secrets, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// DeleteSecrets - secrets created by owner
+func (s *StoreCache) DeleteSecrets(ctx context.Context, owner uint64) (int, error) {
+	s.secrets.lock.Lock()
+	defer s.secrets.lock.Unlock()
+	var n int
+	for id, secret := range s.secrets.secrets {
+		if secret.Owner == owner {
+			delete(s.secrets.secrets, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *StoreCache) AddEmergency(ctx context.Context, e *store.Emergency) error {
+	s.emergency.lock.Lock()
+	defer s.emergency.lock.Unlock()
+	e.Id = uuid.New().String()
+	e.TimeStamp = time.Now()
+	v := *e
+	s.emergency.grants[e.Id] = &v
+	return nil
+}
+
+func (s *StoreCache) GetEmergency(ctx context.Context, id string) (*store.Emergency, error) {
+	s.emergency.lock.RLock()
+	defer s.emergency.lock.RUnlock()
+	e, ok := s.emergency.grants[id]
+	if !ok {
+		return nil, ErrValueNotFound
+	}
+	res := *e
+	return &res, nil
+}
+
+func (s *StoreCache) UpdateEmergency(ctx context.Context, e *store.Emergency) error {
+	s.emergency.lock.Lock()
+	defer s.emergency.lock.Unlock()
+	if _, ok := s.emergency.grants[e.Id]; !ok {
+		return ErrValueNotFound
+	}
+	v := *e
+	s.emergency.grants[e.Id] = &v
+	return nil
+}
+
+func (s *StoreCache) DeleteEmergency(ctx context.Context, id string) error {
+	s.emergency.lock.Lock()
+	defer s.emergency.lock.Unlock()
+	if _, ok := s.emergency.grants[id]; !ok {
+		return ErrValueNotFound
+	}
+	delete(s.emergency.grants, id)
+	return nil
+}
+
+// GetEmergencies - emergency access of user as owner or as contact
+func (s *StoreCache) GetEmergencies(ctx context.Context, user uint64) ([]*store.Emergency, error) {
+	s.emergency.lock.RLock()
+	defer s.emergency.lock.RUnlock()
+	var res []*store.Emergency
+	for _, e := range s.emergency.grants {
+		if e.Owner == user || e.Contact == user {
+			v := *e
+			res = append(res, &v)
+		}
+	}
+	return res, nil
+}
+
+func (s *StoreCache) AddRefreshToken(ctx context.Context, t *store.RefreshToken) error {
+	s.refresh.lock.Lock()
+	defer s.refresh.lock.Unlock()
+	if _, ok := s.refresh.tokens[t.Id]; ok {
+		return ErrValueExists
+	}
+	t.TimeStamp = time.Now()
+	v := *t
+	s.refresh.tokens[t.Id] = &v
+	return nil
+}
+
+// TakeRefreshToken - refresh token is deleted, it is used once
+func (s *StoreCache) TakeRefreshToken(ctx context.Context, id string) (*store.RefreshToken, error) {
+	s.refresh.lock.Lock()
+	defer s.refresh.lock.Unlock()
+	t, ok := s.refresh.tokens[id]
+	if !ok {
+		return nil, ErrValueNotFound
+	}
+	delete(s.refresh.tokens, id)
+	return t, nil
+}
+
+func (s *StoreCache) DeleteExpiredRefreshTokens(ctx context.Context, now time.Time) (int, error) {
+	s.refresh.lock.Lock()
+	defer s.refresh.lock.Unlock()
+	var n int
+	for id, t := range s.refresh.tokens {
+		if !now.Before(t.ExpiresAt) {
+			delete(s.refresh.tokens, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// DeleteRefreshTokens - refresh tokens of user
+func (s *StoreCache) DeleteRefreshTokens(ctx context.Context, user uint64) (int, error) {
+	s.refresh.lock.Lock()
+	defer s.refresh.lock.Unlock()
+	var n int
+	for id, t := range s.refresh.tokens {
+		if t.User == user {
+			delete(s.refresh.tokens, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *StoreCache) RevokeToken(ctx context.Context, id string, expiresAt time.Time) error {
+	s.revoked.lock.Lock()
+	defer s.revoked.lock.Unlock()
+	s.revoked.ids[id] = expiresAt
+	return nil
+}
+
+func (s *StoreCache) IsTokenRevoked(ctx context.Context, id string) (bool, error) {
+	s.revoked.lock.RLock()
+	defer s.revoked.lock.RUnlock()
+	_, ok := s.revoked.ids[id]
+	return ok, nil
+}
+
+// DeleteExpiredRevocations - revoked tokens past expiry are rejected by expiry itself
+func (s *StoreCache) DeleteExpiredRevocations(ctx context.Context, now time.Time) (int, error) {
+	s.revoked.lock.Lock()
+	defer s.revoked.lock.Unlock()
+	var n int
+	for id, expiresAt := range s.revoked.ids {
+		if !now.Before(expiresAt) {
+			delete(s.revoked.ids, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *StoreCache) AddSession(ctx context.Context, session *store.Session) error {
+	s.sessions.lock.Lock()
+	defer s.sessions.lock.Unlock()
+	session.Id = uuid.New().String()
+	session.CreatedAt = time.Now()
+	session.LastSeen = session.CreatedAt
+	v := *session
+	s.sessions.sessions[session.Id] = &v
+	return nil
+}
+
+func (s *StoreCache) GetSession(ctx context.Context, id string) (*store.Session, error) {
+	s.sessions.lock.RLock()
+	defer s.sessions.lock.RUnlock()
+	session, ok := s.sessions.sessions[id]
+	if !ok {
+		return nil, ErrValueNotFound
+	}
+	res := *session
+	return &res, nil
+}
+
+// TouchSession - last seen time of session
+func (s *StoreCache) TouchSession(ctx context.Context, id string, now time.Time) error {
+	s.sessions.lock.Lock()
+	defer s.sessions.lock.Unlock()
+	session, ok := s.sessions.sessions[id]
+	if !ok {
+		return ErrValueNotFound
+	}
+	session.LastSeen = now
+	return nil
+}
+
+func (s *StoreCache) DeleteSession(ctx context.Context, id string) error {
+	s.sessions.lock.Lock()
+	defer s.sessions.lock.Unlock()
+	if _, ok := s.sessions.sessions[id]; !ok {
+		return ErrValueNotFound
+	}
+	delete(s.sessions.sessions, id)
+	return nil
+}
+
+func (s *StoreCache) GetSessions(ctx context.Context, user uint64) ([]*store.Session, error) {
+	s.sessions.lock.RLock()
+	defer s.sessions.lock.RUnlock()
+	var res []*store.Session
+	for _, session := range s.sessions.sessions {
+		if session.User == user {
+			v := *session
+			res = append(res, &v)
+		}
+	}
+	return res, nil
+}
+
+// DeleteStaleSessions - sessions not seen since before
+func (s *StoreCache) DeleteStaleSessions(ctx context.Context, before time.Time) (int, error) {
+	s.sessions.lock.Lock()
+	defer s.sessions.lock.Unlock()
+	var n int
+	for id, session := range s.sessions.sessions {
+		if session.LastSeen.Before(before) {
+			delete(s.sessions.sessions, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// AddAPIToken - names of tokens of user are unique
+func (s *StoreCache) AddAPIToken(ctx context.Context, t *store.APIToken) error {
+	s.apiTokens.lock.Lock()
+	defer s.apiTokens.lock.Unlock()
+	if _, ok := s.apiTokens.tokens[t.Id]; ok {
+		return ErrValueExists
+	}
+	for _, v := range s.apiTokens.tokens {
+		if v.User == t.User && v.Name == t.Name {
+			return ErrValueExists
+		}
+	}
+	t.CreatedAt = time.Now()
+	v := *t
+	s.apiTokens.tokens[t.Id] = &v
+	return nil
+}
+
+func (s *StoreCache) GetAPIToken(ctx context.Context, id string) (*store.APIToken, error) {
+	s.apiTokens.lock.RLock()
+	defer s.apiTokens.lock.RUnlock()
+	t, ok := s.apiTokens.tokens[id]
+	if !ok {
+		return nil, ErrValueNotFound
+	}
+	res := *t
+	return &res, nil
+}
+
+// TouchAPIToken - last use time of token
+func (s *StoreCache) TouchAPIToken(ctx context.Context, id string, now time.Time) error {
+	s.apiTokens.lock.Lock()
+	defer s.apiTokens.lock.Unlock()
+	t, ok := s.apiTokens.tokens[id]
+	if !ok {
+		return ErrValueNotFound
+	}
+	t.LastUsed = now
+	return nil
+}
+
+// DeleteAPIToken - token of user by name
+func (s *StoreCache) DeleteAPIToken(ctx context.Context, user uint64, name string) error {
+	s.apiTokens.lock.Lock()
+	defer s.apiTokens.lock.Unlock()
+	for id, t := range s.apiTokens.tokens {
+		if t.User == user && t.Name == name {
+			delete(s.apiTokens.tokens, id)
+			return nil
+		}
+	}
+	return ErrValueNotFound
+}
+
+func (s *StoreCache) GetAPITokens(ctx context.Context, user uint64) ([]*store.APIToken, error) {
+	s.apiTokens.lock.RLock()
+	defer s.apiTokens.lock.RUnlock()
+	var res []*store.APIToken
+	for _, t := range s.apiTokens.tokens {
+		if t.User == user {
+			v := *t
+			res = append(res, &v)
+		}
+	}
+	return res, nil
+}
+
+func (s *StoreCache) DeleteExpiredAPITokens(ctx context.Context, now time.Time) (int, error) {
+	s.apiTokens.lock.Lock()
+	defer s.apiTokens.lock.Unlock()
+	var n int
+	for id, t := range s.apiTokens.tokens {
+		if !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt) {
+			delete(s.apiTokens.tokens, id)
+			n++
+		}
+	}
+	return n, nil
+}
@@ -11,6 +11,27 @@ type (
 		Id       uint64
 		Name     string
 		HashPass string
+		VaultKey string
+		// Rotation - default rotation policy of login items in days, 0 - none
+		Rotation int
+		// PublicKey - hex PKIX rsa key, item keys are wrapped by it for sharing
+		PublicKey string
+		// PrivateKey - private key wrapped by client master key, opaque for server
+		PrivateKey string
+		// TokenVersion - tokens of older version are revoked, it is incremented to revoke all sessions
+		TokenVersion int
+		// TOTPSecret - secret of second factor of login, empty - login by password only,
+		// TOTPPending - secret of enrollment until its code is verified, TOTPStep - step of last used code
+		TOTPSecret  string
+		TOTPPending string
+		TOTPStep    int64
+		// RecoveryCodes - hashes of unused recovery codes of second factor
+		RecoveryCodes []string
+		// RecoveryHash - hash of secret derived from recovery key, empty - no recovery key,
+		// RecoveryVaultKey and RecoveryPrivateKey - keys of user wrapped by recovery key
+		RecoveryHash       string
+		RecoveryVaultKey   string
+		RecoveryPrivateKey string
 	}
 
 	UserData struct {
@@ -19,7 +40,20 @@ type (
 		TypeData  int
 		UserData  string
 		MetaData  string
-		TimeStamp time.Time
+		Tokens    []string
+		Parent    string
+		Size      int64
+		Checksum  string
+		Codec     string
+		Chunked   bool
+		Chunks    []Chunk
+		Object    bool
+		ExpiresAt time.Time
+		ChangedAt time.Time
+		Rotation  int
+		// Collection - team vault of item, empty - personal item
+		Collection string
+		TimeStamp  time.Time
 	}
 
 	UserDataCrypt struct {
@@ -29,8 +63,257 @@ type (
 		UserDataEn []byte
 		MetaDataEn []byte
 		EnKey      string
+		Tokens     []string
+		Parent     string
+		Size       int64
+		Checksum   string
+		Codec      string
+		Blob       bool
+		Chunked    bool
+		Chunks     []Chunk
+		Object     bool
+		ExpiresAt  time.Time
+		ChangedAt  time.Time
+		Rotation   int
+		Collection string
+		// CollectionKey - item key wrapped by key of collection
+		CollectionKey string
+		TimeStamp     time.Time
+	}
+
+	UploadSession struct {
+		Id        string
+		UserId    uint64
+		Data      *UserDataCrypt
+		Offset    int64
+		Stored    int64
+		Size      int64
+		HashState []byte
+		TimeStamp time.Time
+	}
+
+	// Chunk - part of chunked blob, Id - keyed hash of content
+	Chunk struct {
+		Id   string
+		Size int64
+	}
+
+	// Usage - stored items and bytes of user, limits of zero are not set
+	Usage struct {
+		Items      int64
+		Bytes      int64
+		QuotaItems int64
+		QuotaBytes int64
+		MaxUpload  int64
+	}
+
+	// RotationDue - login item with password older than rotation policy in days
+	RotationDue struct {
+		Uuid      string
+		MetaData  string
+		ChangedAt time.Time
+		DueAt     time.Time
+		Rotation  int
+	}
+
+	// Share - access of recipient to item of owner, WrappedKey - item key wrapped by public key of recipient
+	Share struct {
+		Uuid       string
+		Owner      uint64
+		Recipient  uint64
+		Permission int
+		WrappedKey string
+		TimeStamp  time.Time
+	}
+
+	SharedItem struct {
+		Uuid       string
+		Owner      string
+		TypeData   int
+		MetaData   string
+		Permission int
+		WrappedKey string
+	}
+
+	// Org - organization of users, its members share collections
+	Org struct {
+		Id        string
+		Name      string
+		TimeStamp time.Time
+	}
+
+	// Member - role of user in organization
+	Member struct {
+		Org  string
+		User uint64
+		Role int
+	}
+
+	OrgMember struct {
+		Name string
+		Role int
+	}
+
+	// Collection - team vault of organization, Key - collection key wrapped by server key,
+	// Keys - collection key wrapped by public key of each member, Version - number of rotations,
+	// Rotation - rotation policy of login items of collection in days
+	Collection struct {
+		Uuid      string
+		Org       string
+		Name      string
+		Key       string
+		Keys      map[uint64]string
+		Version   int
+		Rotation  int
+		TimeStamp time.Time
+	}
+
+	// CollectionKey - collection of member with its key wrapped for member
+	CollectionKey struct {
+		Uuid       string
+		Org        string
+		Name       string
+		Role       int
+		Version    int
+		WrappedKey string
+	}
+
+	CollectionItem struct {
+		Uuid     string
+		Owner    string
+		TypeData int
+		MetaData string
+	}
+
+	// Secret - one-time secret of link, Ciphertext - secret sealed by key of link fragment, server never has key
+	Secret struct {
+		Id         string
+		Owner      uint64
+		Ciphertext string
+		Views      int
+		MaxViews   int
+		ExpiresAt  time.Time
 		TimeStamp  time.Time
 	}
+
+	// RefreshToken - refresh token of user, Id - hash of token, token is used once
+	RefreshToken struct {
+		Id        string
+		User      uint64
+		Session   string
+		Version   int
+		ExpiresAt time.Time
+		TimeStamp time.Time
+	}
+
+	// Session - login of user from device, tokens of deleted session are revoked
+	Session struct {
+		Id            string
+		User          uint64
+		Device        string
+		ClientVersion string
+		Address       string
+		CreatedAt     time.Time
+		LastSeen      time.Time
+	}
+
+	// APIToken - named token of user for automation, Id - hash of token, Permission - read or read-write,
+	// Items and Collections restrict token to these items and collections, empty - all items of user,
+	// zero ExpiresAt - token does not expire
+	APIToken struct {
+		Id          string
+		User        uint64
+		Name        string
+		Permission  int
+		Items       []string
+		Collections []string
+		// Tags - words of metadata of items in scope, blind tokens of words for items of end-to-end mode
+		Tags      []string
+		ExpiresAt time.Time
+		CreatedAt time.Time
+		LastUsed  time.Time
+	}
+
+	// Tokens - access token with its expiry and refresh token
+	Tokens struct {
+		Access    string
+		Refresh   string
+		ExpiresAt time.Time
+	}
+
+	// Emergency - trusted contact of owner, contact reads vault of owner after request is approved
+	// or after Wait without denial
+	Emergency struct {
+		Id          string
+		Owner       uint64
+		Contact     uint64
+		Wait        time.Duration
+		State       int
+		RequestedAt time.Time
+		GrantedAt   time.Time
+		TimeStamp   time.Time
+	}
+
+	EmergencyContact struct {
+		Id          string
+		Owner       string
+		Contact     string
+		Wait        time.Duration
+		State       int
+		RequestedAt time.Time
+		GrantedAt   time.Time
+	}
+
+	// EmergencyItem - item of owner with item key wrapped by public key of contact
+	EmergencyItem struct {
+		Uuid       string
+		TypeData   int
+		MetaData   string
+		WrappedKey string
+	}
+
+	Attachment struct {
+		Uuid     string
+		MetaData string
+		Size     int64
+	}
+
+	SearchResult struct {
+		Uuid     string
+		TypeData int
+		MetaData string
+		Score    int
+		Fields   []string
+	}
+)
+
+const (
+	LoginType int = iota
+	CardType
+	TextType
+	BinaryType
+)
+
+const (
+	PermRead int = iota
+	PermReadWrite
+)
+
+// states of emergency access
+const (
+	EmergencyInvited int = iota
+	EmergencyRequested
+	EmergencyApproved
+	EmergencyDenied
+	EmergencyExpired
+)
+
+// roles of members of organization, lower role has more rights
+const (
+	RoleOwner int = iota
+	RoleAdmin
+	RoleMember
+	RoleReadOnly
 )
 
 var (
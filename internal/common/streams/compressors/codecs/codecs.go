@@ -0,0 +1,153 @@
+// Package codecs - compression codecs of stored data, choice by item type and content
+package codecs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/4aleksei/gokeeper/internal/common/streams/compressors/lzdata"
+	"github.com/4aleksei/gokeeper/internal/common/streams/compressors/zipdata"
+)
+
+type (
+	WriterI interface {
+		OpenWriter(io.Writer) (io.Writer, error)
+		CloseWrite() error
+	}
+
+	ReaderI interface {
+		OpenReader(io.Reader) (io.Reader, error)
+		CloseRead() error
+	}
+
+	codec struct {
+		writer func() WriterI
+		reader func() ReaderI
+	}
+)
+
+// codec names, stored codec of not compressed data is empty
+const (
+	Auto     = "auto"
+	None     = "none"
+	Gzip     = "gzip"
+	GzipFast = "gzip-fast"
+	LZ       = "lz"
+)
+
+var (
+	ErrUnknownCodec = errors.New("error, unknown compression codec")
+)
+
+var (
+	mu     sync.RWMutex
+	codecs = map[string]codec{
+		Gzip: {
+			writer: func() WriterI { return zipdata.NewWriter() },
+			reader: func() ReaderI { return zipdata.NewReader() },
+		},
+		GzipFast: {
+			writer: func() WriterI { return zipdata.NewWriterLevel(gzip.BestSpeed) },
+			reader: func() ReaderI { return zipdata.NewReader() },
+		},
+		// LZ - fast codec, lower ratio than gzip at about twice speed of gzip-fast
+		LZ: {
+			writer: func() WriterI { return lzdata.NewWriter() },
+			reader: func() ReaderI { return lzdata.NewReader() },
+		},
+	}
+)
+
+// magic bytes of compressed formats, compression is skipped for them
+var magics = [][]byte{
+	{0x1f, 0x8b},                       // gzip
+	{0x28, 0xb5, 0x2f, 0xfd},           // zstd
+	{'P', 'K', 0x03, 0x04},             // zip, docx, jar
+	{0xfd, '7', 'z', 'X', 'Z', 0x00},   // xz
+	{'B', 'Z', 'h'},                    // bzip2
+	{'7', 'z', 0xbc, 0xaf, 0x27, 0x1c}, // 7z
+	{'R', 'a', 'r', '!', 0x1a, 0x07},   // rar
+	{0x04, 0x22, 0x4d, 0x18},           // lz4
+	{0x89, 'P', 'N', 'G'},              // png
+	{0xff, 0xd8, 0xff},                 // jpeg
+	{'G', 'I', 'F', '8'},               // gif
+	{'O', 'g', 'g', 'S'},               // ogg
+	{'f', 'L', 'a', 'C'},               // flac
+	{'I', 'D', '3'},                    // mp3
+	{0x1a, 0x45, 0xdf, 0xa3},           // mkv, webm
+}
+
+// Register - add codec, zstd and other codecs are plugged in as middle writer and reader
+func Register(name string, writer func() WriterI, reader func() ReaderI) {
+	mu.Lock()
+	defer mu.Unlock()
+	codecs[name] = codec{writer: writer, reader: reader}
+}
+
+func get(name string) (codec, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	c, ok := codecs[name]
+	if !ok {
+		return codec{}, ErrUnknownCodec
+	}
+	return c, nil
+}
+
+// NewWriter - compressor of codec, nil for not compressed data
+func NewWriter(name string) (WriterI, error) {
+	if name == "" {
+		return nil, nil
+	}
+	c, err := get(name)
+	if err != nil {
+		return nil, err
+	}
+	return c.writer(), nil
+}
+
+// NewReader - decompressor of codec, nil for not compressed data
+func NewReader(name string) (ReaderI, error) {
+	if name == "" {
+		return nil, nil
+	}
+	c, err := get(name)
+	if err != nil {
+		return nil, err
+	}
+	return c.reader(), nil
+}
+
+// Compressed - head of data is a compressed format
+func Compressed(head []byte) bool {
+	for _, m := range magics {
+		if bytes.HasPrefix(head, m) {
+			return true
+		}
+	}
+	// mp4, mov: size then ftyp box; webp: RIFF container
+	if len(head) >= 8 && bytes.Equal(head[4:8], []byte("ftyp")) {
+		return true
+	}
+	return len(head) >= 12 && bytes.Equal(head[:4], []byte("RIFF")) && bytes.Equal(head[8:12], []byte("WEBP"))
+}
+
+// Choose - stored codec of item, auto - gzip for compressible data
+func Choose(requested string, compressible bool, head []byte) (string, error) {
+	switch requested {
+	case None:
+		return "", nil
+	case "", Auto:
+		if !compressible || Compressed(head) {
+			return "", nil
+		}
+		return Gzip, nil
+	}
+	if _, err := get(requested); err != nil {
+		return "", err
+	}
+	return requested, nil
+}
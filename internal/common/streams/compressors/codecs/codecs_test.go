@@ -0,0 +1,61 @@
+package codecs
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Choose(t *testing.T) {
+	tests := []struct {
+		name         string
+		requested    string
+		compressible bool
+		head         []byte
+		want         string
+		wantErr      error
+	}{
+		{name: "Test auto text", compressible: true, head: []byte("hello"), want: Gzip},
+		{name: "Test auto png", requested: Auto, compressible: true, head: []byte{0x89, 'P', 'N', 'G', 0x0d}, want: ""},
+		{name: "Test auto not compressible", want: ""},
+		{name: "Test none", requested: None, compressible: true, want: ""},
+		{name: "Test fast", requested: GzipFast, compressible: true, want: GzipFast},
+		{name: "Test lz", requested: LZ, compressible: true, want: LZ},
+		{name: "Test unknown", requested: "lzma", wantErr: ErrUnknownCodec},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Choose(tt.requested, tt.compressible, tt.head)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_Codecs(t *testing.T) {
+	data := bytes.Repeat([]byte("gokeeper "), 1000)
+	for _, name := range []string{Gzip, GzipFast, LZ} {
+		t.Run("Test "+name, func(t *testing.T) {
+			var buf bytes.Buffer
+			cw, err := NewWriter(name)
+			require.NoError(t, err)
+			w, err := cw.OpenWriter(&buf)
+			require.NoError(t, err)
+			_, err = w.Write(data)
+			require.NoError(t, err)
+			require.NoError(t, cw.CloseWrite())
+			assert.Less(t, buf.Len(), len(data))
+
+			cr, err := NewReader(name)
+			require.NoError(t, err)
+			r, err := cr.OpenReader(&buf)
+			require.NoError(t, err)
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, data, got)
+		})
+	}
+}
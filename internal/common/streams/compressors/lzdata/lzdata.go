@@ -0,0 +1,274 @@
+// Package lzdata - fast LZ77 compression of snappy class, stream of blocks of blockSize,
+// matches are found by hash of 4 bytes within block, block is stored as is if it is not compressible
+package lzdata
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	blockSize = 64 * 1024
+	minMatch  = 4
+	tableBits = 14
+
+	blockStored     byte = 0
+	blockCompressed byte = 1
+	blockEnd        byte = 0xff
+
+	opLiteral uint64 = 0
+	opCopy    uint64 = 1
+)
+
+var (
+	magic = []byte{'G', 'K', 'L', 'Z'}
+
+	ErrCorrupt = errors.New("error, lz stream is corrupted")
+)
+
+type (
+	lzDecWr struct {
+		w   io.Writer
+		buf []byte
+		enc []byte
+	}
+
+	lzDecRd struct {
+		r   *bufio.Reader
+		out []byte
+		pos int
+		in  []byte
+		end bool
+	}
+)
+
+func NewReader() *lzDecRd {
+	return &lzDecRd{}
+}
+
+func NewWriter() *lzDecWr {
+	return &lzDecWr{}
+}
+
+func (lz *lzDecWr) OpenWriter(w io.Writer) (io.Writer, error) {
+	lz.w = w
+	lz.buf = make([]byte, 0, blockSize)
+	_, err := w.Write(magic)
+	return lz, err
+}
+
+func (lz *lzDecWr) Write(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		c := min(blockSize-len(lz.buf), len(p))
+		lz.buf = append(lz.buf, p[:c]...)
+		p = p[c:]
+		n += c
+		if len(lz.buf) == blockSize {
+			if err := lz.flushBlock(); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+func (lz *lzDecWr) flushBlock() error {
+	if len(lz.buf) == 0 {
+		return nil
+	}
+	lz.enc = encodeBlock(lz.enc[:0], lz.buf)
+	kind, payload := blockCompressed, lz.enc
+	if len(lz.enc) >= len(lz.buf) {
+		kind, payload = blockStored, lz.buf
+	}
+	head := []byte{kind}
+	head = binary.AppendUvarint(head, uint64(len(lz.buf)))
+	head = binary.AppendUvarint(head, uint64(len(payload)))
+	if _, err := lz.w.Write(head); err != nil {
+		return err
+	}
+	if _, err := lz.w.Write(payload); err != nil {
+		return err
+	}
+	lz.buf = lz.buf[:0]
+	return nil
+}
+
+func (lz *lzDecWr) CloseWrite() error {
+	if lz.w == nil {
+		return nil
+	}
+	defer func() { lz.w = nil }()
+	if err := lz.flushBlock(); err != nil {
+		return err
+	}
+	_, err := lz.w.Write([]byte{blockEnd})
+	return err
+}
+
+func (lz *lzDecRd) OpenReader(r io.Reader) (io.Reader, error) {
+	lz.r = bufio.NewReader(r)
+	lz.out, lz.pos, lz.end = nil, 0, false
+	head := make([]byte, len(magic))
+	if _, err := io.ReadFull(lz.r, head); err != nil || string(head) != string(magic) {
+		return nil, ErrCorrupt
+	}
+	return lz, nil
+}
+
+func (lz *lzDecRd) Read(p []byte) (int, error) {
+	for lz.pos == len(lz.out) {
+		if lz.end {
+			return 0, io.EOF
+		}
+		if err := lz.readBlock(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, lz.out[lz.pos:])
+	lz.pos += n
+	return n, nil
+}
+
+// readBlock - next block of stream, stream without end block is truncated
+func (lz *lzDecRd) readBlock() error {
+	kind, err := lz.r.ReadByte()
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+	if kind == blockEnd {
+		lz.end = true
+		lz.out, lz.pos = lz.out[:0], 0
+		return nil
+	}
+	size, err := binary.ReadUvarint(lz.r)
+	if err != nil {
+		return unexpected(err)
+	}
+	length, err := binary.ReadUvarint(lz.r)
+	if err != nil {
+		return unexpected(err)
+	}
+	if size == 0 || size > blockSize || length > blockSize || (kind == blockStored && length != size) {
+		return ErrCorrupt
+	}
+	if cap(lz.in) < blockSize {
+		lz.in = make([]byte, blockSize)
+	}
+	in := lz.in[:length]
+	if _, err := io.ReadFull(lz.r, in); err != nil {
+		return unexpected(err)
+	}
+	switch kind {
+	case blockStored:
+		lz.out = append(lz.out[:0], in...)
+	case blockCompressed:
+		lz.out, err = decodeBlock(lz.out[:0], in, int(size))
+		if err != nil {
+			return err
+		}
+	default:
+		return ErrCorrupt
+	}
+	lz.pos = 0
+	return nil
+}
+
+func unexpected(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (lz *lzDecRd) CloseRead() error {
+	lz.r = nil
+	return nil
+}
+
+func hash(v uint32) uint32 {
+	return (v * 0x1e35a7bd) >> (32 - tableBits)
+}
+
+// encodeBlock - src as literals and copies of earlier bytes of src, each op is uvarint of length<<1|op,
+// copy is followed by uvarint of offset back
+func encodeBlock(dst []byte, src []byte) []byte {
+	var table [1 << tableBits]int32
+	lit, i := 0, 0
+	for i+minMatch <= len(src) {
+		v := binary.LittleEndian.Uint32(src[i:])
+		h := hash(v)
+		cand := int(table[h])
+		table[h] = int32(i)
+		if cand >= i || binary.LittleEndian.Uint32(src[cand:]) != v {
+			// incompressible data is skipped faster
+			i += 1 + (i-lit)>>5
+			continue
+		}
+		n := minMatch
+		for i+n < len(src) && src[cand+n] == src[i+n] {
+			n++
+		}
+		dst = appendLiteral(dst, src[lit:i])
+		dst = binary.AppendUvarint(dst, uint64(n)<<1|opCopy)
+		dst = binary.AppendUvarint(dst, uint64(i-cand))
+		i += n
+		lit = i
+	}
+	return appendLiteral(dst, src[lit:])
+}
+
+func appendLiteral(dst []byte, lit []byte) []byte {
+	if len(lit) == 0 {
+		return dst
+	}
+	dst = binary.AppendUvarint(dst, uint64(len(lit))<<1|opLiteral)
+	return append(dst, lit...)
+}
+
+// decodeBlock - block of size bytes decoded from src, offsets and lengths are checked
+func decodeBlock(dst []byte, src []byte, size int) ([]byte, error) {
+	for len(src) > 0 {
+		op, n := binary.Uvarint(src)
+		if n <= 0 {
+			return nil, ErrCorrupt
+		}
+		src = src[n:]
+		length := op >> 1
+		if length == 0 || length > uint64(size-len(dst)) {
+			return nil, ErrCorrupt
+		}
+		if op&1 == opLiteral {
+			if length > uint64(len(src)) {
+				return nil, ErrCorrupt
+			}
+			dst = append(dst, src[:length]...)
+			src = src[length:]
+			continue
+		}
+		offset, n := binary.Uvarint(src)
+		if n <= 0 || offset == 0 || offset > uint64(len(dst)) {
+			return nil, ErrCorrupt
+		}
+		src = src[n:]
+		// copy may overlap itself, it repeats last offset bytes
+		start := len(dst) - int(offset)
+		if offset >= length {
+			dst = append(dst, dst[start:start+int(length)]...)
+			continue
+		}
+		for k := 0; k < int(length); k++ {
+			dst = append(dst, dst[start+k])
+		}
+	}
+	if len(dst) != size {
+		return nil, ErrCorrupt
+	}
+	return dst, nil
+}
@@ -0,0 +1,76 @@
+package lzdata
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compress(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	writer := NewWriter()
+	w, err := writer.OpenWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.CloseWrite())
+	return buf.Bytes()
+}
+
+func decompress(data []byte) ([]byte, error) {
+	reader := NewReader()
+	r, err := reader.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.CloseRead()
+	return io.ReadAll(r)
+}
+
+func Test_RoundTrip(t *testing.T) {
+	random := make([]byte, 3*blockSize+17)
+	rand.New(rand.NewSource(1)).Read(random)
+	tests := []struct {
+		name  string
+		data  []byte
+		small bool
+	}{
+		{name: "Test empty", data: []byte{}},
+		{name: "Test short", data: []byte("abc")},
+		{name: "Test text", data: bytes.Repeat([]byte("gokeeper stores secrets "), 20000), small: true},
+		{name: "Test run", data: bytes.Repeat([]byte{'a'}, blockSize+1), small: true},
+		{name: "Test random", data: random},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := compress(t, tt.data)
+			if tt.small {
+				assert.Less(t, len(enc), len(tt.data)/10)
+			}
+			got, err := decompress(enc)
+			require.NoError(t, err)
+			assert.True(t, bytes.Equal(tt.data, got))
+		})
+	}
+}
+
+func Test_Corrupt(t *testing.T) {
+	enc := compress(t, bytes.Repeat([]byte("gokeeper "), 1000))
+
+	t.Run("Test bad magic", func(t *testing.T) {
+		_, err := decompress([]byte("frewd"))
+		assert.ErrorIs(t, err, ErrCorrupt)
+	})
+	t.Run("Test truncated", func(t *testing.T) {
+		_, err := decompress(enc[:len(enc)-1])
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+	t.Run("Test bad offset", func(t *testing.T) {
+		_, err := decodeBlock(nil, []byte{byte(4<<1 | opCopy), 1}, 4)
+		assert.ErrorIs(t, err, ErrCorrupt)
+	})
+}
@@ -9,6 +9,7 @@ import (
 type (
 	gzipDecWr struct {
 		gzipCompress *gzip.Writer
+		level        int
 	}
 
 	gzipDecRd struct {
@@ -21,7 +22,12 @@ func NewReader() *gzipDecRd {
 }
 
 func NewWriter() *gzipDecWr {
-	return &gzipDecWr{}
+	return &gzipDecWr{level: gzip.DefaultCompression}
+}
+
+// NewWriterLevel - writer with compression level of compress/gzip
+func NewWriterLevel(level int) *gzipDecWr {
+	return &gzipDecWr{level: level}
 }
 
 func (gzipencdec *gzipDecRd) OpenReader(r io.Reader) (io.Reader, error) {
@@ -31,8 +37,9 @@ func (gzipencdec *gzipDecRd) OpenReader(r io.Reader) (io.Reader, error) {
 }
 
 func (gzipencdec *gzipDecWr) OpenWriter(w io.Writer) (io.Writer, error) {
-	gzipencdec.gzipCompress = gzip.NewWriter(w)
-	return gzipencdec.gzipCompress, nil
+	var err error
+	gzipencdec.gzipCompress, err = gzip.NewWriterLevel(w, gzipencdec.level)
+	return gzipencdec.gzipCompress, err
 }
 
 func (gzipencdec *gzipDecRd) CloseRead() error {
@@ -18,6 +18,10 @@ func Test_NewWriter(t *testing.T) {
 	t.Run("Test NewWriter", func(t *testing.T) {
 		assert.NotNil(t, NewWriter())
 	})
+	t.Run("Test NewWriterLevel error", func(t *testing.T) {
+		_, err := NewWriterLevel(100).OpenWriter(io.Discard)
+		assert.NotNil(t, err)
+	})
 }
 
 func Test_OpenWrite(t *testing.T) {
@@ -9,13 +9,16 @@ import (
 
 type (
 	aesWriter struct {
-		aesW *aescoder.AesWriter
-		key  *aescoder.KeyAES
+		aesW  *aescoder.BlockWriter
+		key   *aescoder.KeyAES
+		index uint64
+		tail  []byte
 	}
 
 	aesReader struct {
-		aesR *aescoder.AesReader
-		key  *aescoder.KeyAES
+		aesR  *aescoder.BlockReader
+		key   *aescoder.KeyAES
+		index uint64
 	}
 )
 
@@ -26,6 +29,15 @@ func NewReader(key *aescoder.KeyAES) *aesReader {
 	return rAes
 }
 
+// NewReaderAt - reader of stream from sealed block index
+func NewReaderAt(key *aescoder.KeyAES, index uint64) *aesReader {
+	rAes := &aesReader{
+		key:   key,
+		index: index,
+	}
+	return rAes
+}
+
 func NewWriter(key *aescoder.KeyAES) *aesWriter {
 	wAes := &aesWriter{
 		key: key,
@@ -33,9 +45,19 @@ func NewWriter(key *aescoder.KeyAES) *aesWriter {
 	return wAes
 }
 
+// NewWriterAt - writer appending to stream of index sealed blocks,
+// tail - plain data of cut short block, it is sealed again first
+func NewWriterAt(key *aescoder.KeyAES, index uint64, tail []byte) *aesWriter {
+	wAes := &aesWriter{
+		key:   key,
+		index: index,
+		tail:  tail,
+	}
+	return wAes
+}
+
 func (a *aesReader) OpenReader(r io.Reader) (io.Reader, error) {
-	readCloser := io.NopCloser(r)
-	rr, err := aescoder.NewReader(readCloser, a.key)
+	rr, err := aescoder.NewBlockReader(r, a.key, a.index)
 	if err != nil {
 		return nil, err
 	}
@@ -44,18 +66,26 @@ func (a *aesReader) OpenReader(r io.Reader) (io.Reader, error) {
 }
 
 func (a *aesReader) CloseRead() error {
-	return a.aesR.Close()
+	return nil
 }
 
 func (a *aesWriter) OpenWriter(w io.Writer) (io.Writer, error) {
-	ww, err := aescoder.NewWriter(w, a.key)
+	ww, err := aescoder.NewBlockWriter(w, a.key, a.index)
 	if err != nil {
 		return nil, err
 	}
 	a.aesW = ww
-	return ww, nil
+	if len(a.tail) > 0 {
+		_, err = ww.Write(a.tail)
+		a.tail = nil
+	}
+	return ww, err
 }
 
 func (a *aesWriter) CloseWrite() error {
+	if a.aesW != nil {
+		defer func() { a.aesW = nil }()
+		return a.aesW.Flush()
+	}
 	return nil
 }
@@ -0,0 +1,62 @@
+// Package sha256stream - checksum of plain stream in conveer
+package sha256stream
+
+import (
+	"crypto/sha256"
+	"encoding"
+	"encoding/hex"
+	"hash"
+	"io"
+)
+
+type (
+	hashWriter struct {
+		w io.Writer
+		h hash.Hash
+	}
+)
+
+func NewWriter() *hashWriter {
+	return &hashWriter{
+		h: sha256.New(),
+	}
+}
+
+// NewWriterState - continue hash from saved state
+func NewWriterState(state []byte) (*hashWriter, error) {
+	hw := NewWriter()
+	if len(state) == 0 {
+		return hw, nil
+	}
+	err := hw.h.(encoding.BinaryUnmarshaler).UnmarshalBinary(state)
+	if err != nil {
+		return nil, err
+	}
+	return hw, nil
+}
+
+func (hw *hashWriter) OpenWriter(w io.Writer) (io.Writer, error) {
+	hw.w = w
+	return hw, nil
+}
+
+func (hw *hashWriter) CloseWrite() error {
+	return nil
+}
+
+func (hw *hashWriter) Write(p []byte) (int, error) {
+	n, err := hw.w.Write(p)
+	hw.h.Write(p[:n])
+	return n, err
+}
+
+// Sum - hex sha256 of written data
+func (hw *hashWriter) Sum() string {
+	return hex.EncodeToString(hw.h.Sum(nil))
+}
+
+// State - hash state of written data
+func (hw *hashWriter) State() []byte {
+	state, _ := hw.h.(encoding.BinaryMarshaler).MarshalBinary()
+	return state
+}
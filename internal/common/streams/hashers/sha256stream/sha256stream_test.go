@@ -0,0 +1,41 @@
+package sha256stream
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Sum(t *testing.T) {
+	data := bytes.Repeat([]byte("abcdefg"), 100)
+	sum := sha256.Sum256(data)
+
+	t.Run("Test Sum", func(t *testing.T) {
+		var buf bytes.Buffer
+		hw := NewWriter()
+		w, err := hw.OpenWriter(&buf)
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+		assert.Equal(t, hex.EncodeToString(sum[:]), hw.Sum())
+		assert.Equal(t, data, buf.Bytes())
+	})
+
+	t.Run("Test continue from state", func(t *testing.T) {
+		var buf bytes.Buffer
+		hw := NewWriter()
+		w, _ := hw.OpenWriter(&buf)
+		_, _ = w.Write(data[:300])
+
+		hwNext, err := NewWriterState(hw.State())
+		require.NoError(t, err)
+		w, _ = hwNext.OpenWriter(&buf)
+		_, err = w.Write(data[300:])
+		require.NoError(t, err)
+		assert.Equal(t, hex.EncodeToString(sum[:]), hwNext.Sum())
+	})
+}
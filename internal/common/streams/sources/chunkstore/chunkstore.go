@@ -0,0 +1,278 @@
+// Package chunkstore - content-defined chunks of streams, every chunk is stored once under its keyed hash
+package chunkstore
+
+import (
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/4aleksei/gokeeper/internal/common/aescoder"
+	"github.com/4aleksei/gokeeper/internal/common/store"
+)
+
+type (
+	// Index - reference count of chunks, count after change is returned
+	Index interface {
+		Acquire(id string) (int64, error)
+		Release(id string) (int64, error)
+	}
+
+	Store struct {
+		mu     sync.Mutex
+		dir    string
+		idKey  []byte
+		encKey []byte
+		index  Index
+		min    int
+		max    int
+		mask   uint64
+	}
+
+	chunkWriter struct {
+		s      *Store
+		buf    []byte
+		h      uint64
+		chunks []store.Chunk
+	}
+
+	chunkReader struct {
+		s      *Store
+		chunks []store.Chunk
+		skip   int64
+		cur    []byte
+	}
+)
+
+const (
+	keySize  int    = 32
+	idInfo   string = "gokeeper chunk id"
+	encInfo  string = "gokeeper chunk data"
+	fileMode        = 0o600
+	dirMode         = 0o700
+)
+
+var (
+	ErrChunkCorrupt = errors.New("error, chunk content differs from its id")
+	ErrBadChunkSize = errors.New("error, average chunk size must be positive")
+)
+
+// gear - random values of bytes for rolling hash
+var gear [256]uint64
+
+func init() {
+	// splitmix64, table is the same on every server
+	x := uint64(0x676f6b6565706572)
+	for i := range gear {
+		x += 0x9e3779b97f4a7c15
+		z := x
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		gear[i] = z ^ (z >> 31)
+	}
+}
+
+// New - store of chunks in dir, secret - server key, ids and encryption keys are derived from it,
+// avg - average chunk size, chunks are from avg/4 to avg*4 bytes
+func New(dir string, secret []byte, index Index, avg int) (*Store, error) {
+	if avg <= 0 {
+		return nil, ErrBadChunkSize
+	}
+	idKey, err := hkdf.Key(sha256.New, secret, nil, idInfo, keySize)
+	if err != nil {
+		return nil, err
+	}
+	encKey, err := hkdf.Key(sha256.New, secret, nil, encInfo, keySize)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{
+		dir:    dir,
+		idKey:  idKey,
+		encKey: encKey,
+		index:  index,
+		min:    max(avg/4, 1),
+		max:    avg * 4,
+		mask:   1<<bits.Len(uint(avg-1)) - 1,
+	}, nil
+}
+
+func (s *Store) path(id string) string {
+	return filepath.Join(s.dir, id[:2], id)
+}
+
+func (s *Store) id(data []byte) string {
+	h := hmac.New(sha256.New, s.idKey)
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// put - acquire chunk, content is written for the first reference only
+func (s *Store) put(data []byte) (store.Chunk, error) {
+	chunk := store.Chunk{Id: s.id(data), Size: int64(len(data))}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	refs, err := s.index.Acquire(chunk.Id)
+	if err != nil {
+		return chunk, err
+	}
+	if refs > 1 {
+		return chunk, nil
+	}
+	err = s.writeFile(chunk.Id, data)
+	if err != nil {
+		_, _ = s.index.Release(chunk.Id)
+	}
+	return chunk, err
+}
+
+// writeFile - sealed chunk is renamed in place, readers never see part of it
+func (s *Store) writeFile(id string, data []byte) error {
+	sealed, err := aescoder.Seal(s.encKey, data)
+	if err != nil {
+		return err
+	}
+	name := s.path(id)
+	err = os.MkdirAll(filepath.Dir(name), dirMode)
+	if err != nil {
+		return err
+	}
+	tmp := name + ".tmp"
+	err = os.WriteFile(tmp, sealed, fileMode)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+func (s *Store) get(id string) ([]byte, error) {
+	sealed, err := os.ReadFile(s.path(id))
+	if err != nil {
+		return nil, err
+	}
+	data, err := aescoder.Open(s.encKey, sealed)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal([]byte(s.id(data)), []byte(id)) {
+		return nil, ErrChunkCorrupt
+	}
+	return data, nil
+}
+
+// Release - drop references of manifest, chunks without references are removed
+func (s *Store) Release(chunks []store.Chunk) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range chunks {
+		refs, err := s.index.Release(c.Id)
+		if err != nil {
+			return err
+		}
+		if refs > 0 {
+			continue
+		}
+		err = os.Remove(s.path(c.Id))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewWriter - destination writer, chunks - manifest of data written before
+func (s *Store) NewWriter(chunks []store.Chunk) *chunkWriter {
+	return &chunkWriter{
+		s:      s,
+		chunks: chunks,
+	}
+}
+
+func (w *chunkWriter) OpenWriter() (io.Writer, error) {
+	return w, nil
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	for i, b := range p {
+		w.buf = append(w.buf, b)
+		w.h = w.h<<1 + gear[b]
+		if len(w.buf) < w.s.min {
+			continue
+		}
+		if w.h&w.s.mask == 0 || len(w.buf) >= w.s.max {
+			if err := w.cut(); err != nil {
+				return i, err
+			}
+		}
+	}
+	return len(p), nil
+}
+
+func (w *chunkWriter) cut() error {
+	chunk, err := w.s.put(w.buf)
+	if err != nil {
+		return err
+	}
+	w.chunks = append(w.chunks, chunk)
+	w.buf = w.buf[:0]
+	w.h = 0
+	return nil
+}
+
+// CloseWrite - rest of data is the last chunk
+func (w *chunkWriter) CloseWrite() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	return w.cut()
+}
+
+// Chunks - manifest of written data
+func (w *chunkWriter) Chunks() []store.Chunk {
+	return w.chunks
+}
+
+// NewReaderAt - source reader of manifest data from offset
+func (s *Store) NewReaderAt(chunks []store.Chunk, offset int64) *chunkReader {
+	for len(chunks) > 0 && offset >= chunks[0].Size {
+		offset -= chunks[0].Size
+		chunks = chunks[1:]
+	}
+	return &chunkReader{
+		s:      s,
+		chunks: chunks,
+		skip:   offset,
+	}
+}
+
+func (r *chunkReader) OpenReader() (io.Reader, error) {
+	return r, nil
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.cur) == 0 {
+		if len(r.chunks) == 0 {
+			return 0, io.EOF
+		}
+		data, err := r.s.get(r.chunks[0].Id)
+		if err != nil {
+			return 0, err
+		}
+		r.chunks = r.chunks[1:]
+		r.cur = data[min(r.skip, int64(len(data))):]
+		r.skip = 0
+	}
+	n := copy(p, r.cur)
+	r.cur = r.cur[n:]
+	return n, nil
+}
+
+func (r *chunkReader) CloseRead() error {
+	return nil
+}
@@ -0,0 +1,101 @@
+package chunkstore
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/4aleksei/gokeeper/internal/common/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapIndex map[string]int64
+
+func (m mapIndex) Acquire(id string) (int64, error) {
+	m[id]++
+	return m[id], nil
+}
+
+func (m mapIndex) Release(id string) (int64, error) {
+	m[id]--
+	if m[id] <= 0 {
+		delete(m, id)
+		return 0, nil
+	}
+	return m[id], nil
+}
+
+func writeChunks(t *testing.T, s *Store, data []byte) []store.Chunk {
+	w := s.NewWriter(nil)
+	ww, err := w.OpenWriter()
+	require.NoError(t, err)
+	_, err = ww.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.CloseWrite())
+	return w.Chunks()
+}
+
+func readChunks(t *testing.T, s *Store, chunks []store.Chunk, offset int64) []byte {
+	r, err := s.NewReaderAt(chunks, offset).OpenReader()
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return data
+}
+
+func Test_Store(t *testing.T) {
+	index := make(mapIndex)
+	s, err := New(t.TempDir(), []byte("secret"), index, 1024)
+	require.NoError(t, err)
+
+	data := make([]byte, 64*1024)
+	rand.New(rand.NewSource(1)).Read(data)
+	shifted := append([]byte("inserted bytes"), data...)
+
+	first := writeChunks(t, s, data)
+	count := len(index)
+
+	t.Run("Test read from offset", func(t *testing.T) {
+		assert.Equal(t, data, readChunks(t, s, first, 0))
+		assert.Equal(t, data[5000:], readChunks(t, s, first, 5000))
+	})
+
+	t.Run("Test same data is stored once", func(t *testing.T) {
+		second := writeChunks(t, s, data)
+		assert.Equal(t, first, second)
+		assert.Len(t, index, count)
+		require.NoError(t, s.Release(second))
+	})
+
+	t.Run("Test shifted data shares chunks", func(t *testing.T) {
+		third := writeChunks(t, s, shifted)
+		assert.Less(t, len(index)-count, count/2)
+		assert.Equal(t, shifted, readChunks(t, s, third, 0))
+		require.NoError(t, s.Release(third))
+	})
+
+	t.Run("Test release removes chunks", func(t *testing.T) {
+		require.NoError(t, s.Release(first))
+		assert.Empty(t, index)
+		var files int
+		_ = filepath.WalkDir(s.dir, func(_ string, d fs.DirEntry, _ error) error {
+			if !d.IsDir() {
+				files++
+			}
+			return nil
+		})
+		assert.Zero(t, files)
+	})
+
+	t.Run("Test corrupt chunk", func(t *testing.T) {
+		chunks := writeChunks(t, s, bytes.Repeat([]byte("a"), 100))
+		require.NoError(t, os.WriteFile(s.path(chunks[0].Id), []byte("bad"), fileMode))
+		_, err := io.ReadAll(s.NewReaderAt(chunks, 0))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,130 @@
+// Package replica - stores of blob files, primary and replica are copied one to another
+package replica
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/4aleksei/gokeeper/internal/common/streams/sources/s3object"
+	"github.com/4aleksei/gokeeper/internal/common/streams/sources/singlefile"
+	"github.com/4aleksei/gokeeper/internal/common/streams/sources/tee"
+)
+
+type (
+	// Store - blob files by base name
+	Store interface {
+		NewWriter(name string) tee.DestinationWriter
+		Open(ctx context.Context, name string) (io.ReadCloser, error)
+		Exists(ctx context.Context, name string) (bool, error)
+		Remove(ctx context.Context, name string) error
+	}
+
+	// Dir - local directory
+	Dir struct {
+		path string
+	}
+
+	// Objects - bucket of object store
+	Objects struct {
+		c *s3object.Client
+	}
+
+	objectBody struct {
+		io.Reader
+		close func() error
+	}
+)
+
+const dirMode = 0o700
+
+func NewDir(path string) (*Dir, error) {
+	err := os.MkdirAll(path, dirMode)
+	if err != nil {
+		return nil, err
+	}
+	return &Dir{path: path}, nil
+}
+
+func NewObjects(c *s3object.Client) *Objects {
+	return &Objects{c: c}
+}
+
+func (d *Dir) NewWriter(name string) tee.DestinationWriter {
+	return singlefile.NewWriter(filepath.Join(d.path, name))
+}
+
+func (d *Dir) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(d.path, name))
+}
+
+func (d *Dir) Exists(ctx context.Context, name string) (bool, error) {
+	_, err := os.Stat(filepath.Join(d.path, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (d *Dir) Remove(ctx context.Context, name string) error {
+	err := os.Remove(filepath.Join(d.path, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (o *Objects) NewWriter(name string) tee.DestinationWriter {
+	return o.c.NewWriter(name)
+}
+
+func (o *Objects) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	src := o.c.NewReaderAt(name, 0)
+	r, err := src.OpenReader()
+	if err != nil {
+		return nil, err
+	}
+	return objectBody{Reader: r, close: src.CloseRead}, nil
+}
+
+func (o *Objects) Exists(ctx context.Context, name string) (bool, error) {
+	return o.c.Exists(ctx, name)
+}
+
+func (o *Objects) Remove(ctx context.Context, name string) error {
+	return o.c.Remove(ctx, name)
+}
+
+func (b objectBody) Close() error {
+	return b.close()
+}
+
+// Copy - blob file name from one store to another
+func Copy(ctx context.Context, from Store, to Store, name string) error {
+	r, err := from.Open(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return Write(ctx, to, name, r)
+}
+
+// Write - blob file name of reader to store, part of failed write is removed
+func Write(ctx context.Context, to Store, name string, r io.Reader) error {
+	dest := to.NewWriter(name)
+	w, err := dest.OpenWriter()
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	errC := dest.CloseWrite()
+	if err == nil {
+		err = errC
+	}
+	if err != nil {
+		_ = to.Remove(ctx, name)
+	}
+	return err
+}
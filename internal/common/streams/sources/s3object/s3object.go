@@ -0,0 +1,397 @@
+// Package s3object - objects of S3-compatible store as source and destination of stream conveer
+package s3object
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	Config struct {
+		Endpoint  string
+		Bucket    string
+		Region    string
+		AccessKey string
+		SecretKey string
+		PartSize  int
+	}
+
+	Client struct {
+		cfg    Config
+		client *http.Client
+	}
+
+	objectWriter struct {
+		c        *Client
+		key      string
+		buf      []byte
+		uploadID string
+		parts    []completedPart
+		err      error
+	}
+
+	objectReader struct {
+		c    *Client
+		key  string
+		pos  int64
+		body io.ReadCloser
+	}
+
+	completedPart struct {
+		PartNumber int    `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+	}
+
+	completeMultipartUpload struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}
+
+	initiateMultipartUploadResult struct {
+		UploadID string `xml:"UploadId"`
+	}
+
+	errorResponse struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+)
+
+const (
+	// MinPartSize - smallest part of multipart upload except the last one
+	MinPartSize     int    = 5 << 20
+	RegionDefault   string = "us-east-1"
+	algorithm       string = "AWS4-HMAC-SHA256"
+	unsignedPayload string = "UNSIGNED-PAYLOAD"
+	timeFormat      string = "20060102T150405Z"
+	dateFormat      string = "20060102"
+)
+
+var (
+	ErrRequest   = errors.New("error, object store request")
+	ErrNoBucket  = errors.New("error, object store bucket is not set")
+	ErrWriteDone = errors.New("error, object is written")
+	ErrNotFound  = errors.New("error, object not found")
+	ErrRange     = errors.New("error, range of object not satisfiable")
+)
+
+func New(cfg Config) (*Client, error) {
+	if cfg.Bucket == "" {
+		return nil, ErrNoBucket
+	}
+	if cfg.Region == "" {
+		cfg.Region = RegionDefault
+	}
+	if cfg.PartSize <= 0 {
+		cfg.PartSize = MinPartSize
+	}
+	cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+	return &Client{
+		cfg:    cfg,
+		client: &http.Client{},
+	}, nil
+}
+
+// NewWriter - destination writer of object, parts are uploaded while data is written
+func (c *Client) NewWriter(key string) *objectWriter {
+	return &objectWriter{
+		c:   c,
+		key: key,
+	}
+}
+
+// NewReaderAt - source reader of object from position pos
+func (c *Client) NewReaderAt(key string, pos int64) *objectReader {
+	return &objectReader{
+		c:   c,
+		key: key,
+		pos: pos,
+	}
+}
+
+func (c *Client) Remove(ctx context.Context, key string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Exists - object is in bucket
+func (c *Client) Exists(ctx context.Context, key string) (bool, error) {
+	resp, err := c.do(ctx, http.MethodHead, key, nil, nil, nil)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	resp.Body.Close()
+	return true, nil
+}
+
+func (w *objectWriter) OpenWriter() (io.Writer, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+	return w, nil
+}
+
+func (w *objectWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	n := len(p)
+	for len(p) > 0 {
+		c := min(w.c.cfg.PartSize-len(w.buf), len(p))
+		w.buf = append(w.buf, p[:c]...)
+		p = p[c:]
+		if len(w.buf) == w.c.cfg.PartSize {
+			if err := w.uploadPart(); err != nil {
+				w.err = err
+				return n - len(p), err
+			}
+		}
+	}
+	return n, nil
+}
+
+func (w *objectWriter) uploadPart() error {
+	ctx := context.Background()
+	if w.uploadID == "" {
+		resp, err := w.c.do(ctx, http.MethodPost, w.key, url.Values{"uploads": {""}}, nil, nil)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		var res initiateMultipartUploadResult
+		if err := xml.NewDecoder(resp.Body).Decode(&res); err != nil {
+			return err
+		}
+		w.uploadID = res.UploadID
+	}
+	num := len(w.parts) + 1
+	query := url.Values{"partNumber": {strconv.Itoa(num)}, "uploadId": {w.uploadID}}
+	resp, err := w.c.do(ctx, http.MethodPut, w.key, query, nil, w.buf)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	w.parts = append(w.parts, completedPart{PartNumber: num, ETag: resp.Header.Get("ETag")})
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// CloseWrite - small object is put at once, multipart upload is completed or aborted on error
+func (w *objectWriter) CloseWrite() error {
+	if errors.Is(w.err, ErrWriteDone) {
+		return nil
+	}
+	defer func() { w.err = ErrWriteDone }()
+	ctx := context.Background()
+	if w.uploadID == "" {
+		if w.err != nil {
+			return w.err
+		}
+		resp, err := w.c.do(ctx, http.MethodPut, w.key, nil, nil, w.buf)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+	err := w.err
+	if err == nil && len(w.buf) > 0 {
+		err = w.uploadPart()
+	}
+	if err == nil {
+		err = w.complete(ctx)
+	}
+	if err != nil {
+		resp, errA := w.c.do(ctx, http.MethodDelete, w.key, url.Values{"uploadId": {w.uploadID}}, nil, nil)
+		if errA == nil {
+			resp.Body.Close()
+		}
+	}
+	return err
+}
+
+// Abort - object is not written, started multipart upload is aborted
+func (w *objectWriter) Abort() error {
+	if errors.Is(w.err, ErrWriteDone) {
+		return nil
+	}
+	w.err = ErrWriteDone
+	if w.uploadID == "" {
+		return nil
+	}
+	resp, err := w.c.do(context.Background(), http.MethodDelete, w.key, url.Values{"uploadId": {w.uploadID}}, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (w *objectWriter) complete(ctx context.Context) error {
+	body, err := xml.Marshal(completeMultipartUpload{Parts: w.parts})
+	if err != nil {
+		return err
+	}
+	resp, err := w.c.do(ctx, http.MethodPost, w.key, url.Values{"uploadId": {w.uploadID}}, nil, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// error of complete can be sent with status 200
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var e errorResponse
+	if xml.Unmarshal(data, &e) == nil && e.Code != "" {
+		return fmt.Errorf("%w: %s: %s", ErrRequest, e.Code, e.Message)
+	}
+	return nil
+}
+
+func (r *objectReader) OpenReader() (io.Reader, error) {
+	var header http.Header
+	if r.pos > 0 {
+		header = http.Header{"Range": {fmt.Sprintf("bytes=%d-", r.pos)}}
+	}
+	resp, err := r.c.do(context.Background(), http.MethodGet, r.key, nil, header, nil)
+	if err != nil {
+		if r.pos > 0 && errors.Is(err, ErrRange) {
+			// position at end of object
+			return bytes.NewReader(nil), nil
+		}
+		return nil, err
+	}
+	r.body = resp.Body
+	return resp.Body, nil
+}
+
+func (r *objectReader) CloseRead() error {
+	if r.body != nil {
+		defer func() { r.body = nil }()
+		return r.body.Close()
+	}
+	return nil
+}
+
+// do - signed request, error for status not 2xx
+func (c *Client) do(ctx context.Context, method string, key string, query url.Values,
+	header http.Header, body []byte) (*http.Response, error) {
+	u, err := url.Parse(c.cfg.Endpoint + "/" + c.cfg.Bucket + "/" + key)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = canonicalQuery(query)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.ContentLength = int64(len(body))
+	c.sign(req, time.Now().UTC())
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 == 2 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	var e errorResponse
+	_ = xml.NewDecoder(resp.Body).Decode(&e)
+	errStatus := ErrRequest
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		errStatus = ErrNotFound
+	case http.StatusRequestedRangeNotSatisfiable:
+		errStatus = ErrRange
+	}
+	return nil, fmt.Errorf("%w: %s %s: %d %s %s", errStatus, method, key, resp.StatusCode, e.Code, e.Message)
+}
+
+// sign - AWS signature version 4, payload is not signed
+func (c *Client) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format(timeFormat)
+	date := now.Format(dateFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	signed := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	canonical := strings.Join([]string{
+		req.Method,
+		canonicalPath(req.URL.Path),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + unsignedPayload + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		strings.Join(signed, ";"),
+		unsignedPayload,
+	}, "\n")
+	scope := date + "/" + c.cfg.Region + "/s3/aws4_request"
+	hash := sha256.Sum256([]byte(canonical))
+	toSign := algorithm + "\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := hmacSHA256([]byte("AWS4"+c.cfg.SecretKey), date)
+	key = hmacSHA256(key, c.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, toSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algorithm, c.cfg.AccessKey, scope, strings.Join(signed, ";"), signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func uriEncode(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func canonicalPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = uriEncode(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		for _, v := range query[k] {
+			parts = append(parts, uriEncode(k)+"="+uriEncode(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
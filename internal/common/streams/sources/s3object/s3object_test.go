@@ -0,0 +1,87 @@
+package s3object
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/4aleksei/gokeeper/internal/common/streams/sources/s3object/s3fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeObject(t *testing.T, c *Client, key string, data []byte) {
+	w := c.NewWriter(key)
+	ww, err := w.OpenWriter()
+	require.NoError(t, err)
+	_, err = ww.Write(data[:len(data)/2])
+	require.NoError(t, err)
+	_, err = ww.Write(data[len(data)/2:])
+	require.NoError(t, err)
+	require.NoError(t, w.CloseWrite())
+}
+
+func readObject(t *testing.T, c *Client, key string, pos int64) []byte {
+	r := c.NewReaderAt(key, pos)
+	rr, err := r.OpenReader()
+	require.NoError(t, err)
+	data, err := io.ReadAll(rr)
+	require.NoError(t, err)
+	require.NoError(t, r.CloseRead())
+	return data
+}
+
+func Test_Client(t *testing.T) {
+	fake := s3fake.New()
+	defer fake.Close()
+
+	c, err := New(Config{Endpoint: fake.URL, Bucket: "blobs", AccessKey: "ak", SecretKey: "sk", PartSize: 1000})
+	require.NoError(t, err)
+	data := bytes.Repeat([]byte("0123456789"), 450)
+
+	t.Run("Test multipart upload", func(t *testing.T) {
+		writeObject(t, c, "big.data", data)
+		stored, ok := fake.Object("blobs/big.data")
+		require.True(t, ok)
+		assert.Equal(t, data, stored)
+		assert.Zero(t, fake.Uploads())
+	})
+
+	t.Run("Test small object", func(t *testing.T) {
+		writeObject(t, c, "small.data", data[:10])
+		stored, _ := fake.Object("blobs/small.data")
+		assert.Equal(t, data[:10], stored)
+	})
+
+	t.Run("Test range read", func(t *testing.T) {
+		assert.Equal(t, data, readObject(t, c, "big.data", 0))
+		assert.Equal(t, data[1234:], readObject(t, c, "big.data", 1234))
+		assert.Empty(t, readObject(t, c, "big.data", int64(len(data))))
+	})
+
+	t.Run("Test remove", func(t *testing.T) {
+		ok, err := c.Exists(context.Background(), "small.data")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		require.NoError(t, c.Remove(context.Background(), "small.data"))
+		ok, err = c.Exists(context.Background(), "small.data")
+		require.NoError(t, err)
+		assert.False(t, ok)
+		_, err = c.NewReaderAt("small.data", 0).OpenReader()
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Test no bucket", func(t *testing.T) {
+		_, err := New(Config{Endpoint: fake.URL})
+		assert.ErrorIs(t, err, ErrNoBucket)
+	})
+}
+
+func Test_Sign(t *testing.T) {
+	// canonical path and query of signature version 4
+	assert.Equal(t, "/blobs/a%20b.data", canonicalPath("/blobs/a b.data"))
+	assert.Equal(t, "partNumber=1&uploadId=a%2Fb&uploads=", canonicalQuery(map[string][]string{
+		"uploads": {""}, "uploadId": {"a/b"}, "partNumber": {"1"},
+	}))
+}
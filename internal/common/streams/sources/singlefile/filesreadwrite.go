@@ -3,6 +3,7 @@ package singlefile
 
 import (
 	"bufio"
+	"errors"
 	"io"
 	"os"
 )
@@ -20,11 +21,15 @@ type (
 	fileReader struct {
 		reader   *consumer
 		filename string
+		pos      int64
 	}
 
 	fileWriter struct {
 		writer   *producer
 		filename string
+		flag     int
+		// start - size of appended file before write
+		start int64
 	}
 )
 
@@ -38,14 +43,31 @@ func NewReader(filename string) *fileReader {
 	}
 }
 
+// NewReaderAt - reader from position pos of file
+func NewReaderAt(filename string, pos int64) *fileReader {
+	return &fileReader{
+		filename: filename,
+		pos:      pos,
+	}
+}
+
 func NewWriter(filename string) *fileWriter {
 	return &fileWriter{
 		filename: filename,
+		flag:     os.O_WRONLY | os.O_CREATE | os.O_TRUNC,
 	}
 }
 
-func newProducer(filename string) (*producer, error) {
-	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, defaultMode)
+// NewAppendWriter - writer to end of existing file
+func NewAppendWriter(filename string) *fileWriter {
+	return &fileWriter{
+		filename: filename,
+		flag:     os.O_WRONLY | os.O_CREATE | os.O_APPEND,
+	}
+}
+
+func newProducer(filename string, flag int) (*producer, error) {
+	file, err := os.OpenFile(filename, flag, defaultMode)
 	if err != nil {
 		return nil, err
 	}
@@ -55,11 +77,17 @@ func newProducer(filename string) (*producer, error) {
 	}, nil
 }
 
-func newConsumer(filename string) (*consumer, error) {
+func newConsumer(filename string, pos int64) (*consumer, error) {
 	file, err := os.OpenFile(filename, os.O_RDONLY, defaultMode)
 	if err != nil {
 		return nil, err
 	}
+	if pos > 0 {
+		if _, err := file.Seek(pos, io.SeekStart); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
 	return &consumer{
 		file:   file,
 		reader: bufio.NewReader(file),
@@ -68,7 +96,7 @@ func newConsumer(filename string) (*consumer, error) {
 
 func (filestor *fileReader) OpenReader() (io.Reader, error) {
 	var err error
-	filestor.reader, err = newConsumer(filestor.filename)
+	filestor.reader, err = newConsumer(filestor.filename, filestor.pos)
 	if err != nil {
 		return nil, err
 	}
@@ -87,13 +115,38 @@ func (filestor *fileReader) CloseRead() error {
 
 func (filestor *fileWriter) OpenWriter() (io.Writer, error) {
 	var err error
-	filestor.writer, err = newProducer(filestor.filename)
+	filestor.writer, err = newProducer(filestor.filename, filestor.flag)
 	if err != nil {
 		return nil, err
 	}
+	if filestor.flag&os.O_APPEND != 0 {
+		info, err := filestor.writer.file.Stat()
+		if err != nil {
+			filestor.writer.file.Close()
+			filestor.writer = nil
+			return nil, err
+		}
+		filestor.start = info.Size()
+	}
 	return filestor.writer.writer, nil
 }
 
+// Abort - written data is dropped, new file is removed, appended file is cut to its size before write
+func (filestor *fileWriter) Abort() error {
+	if filestor.writer != nil {
+		filestor.writer.file.Close()
+		filestor.writer = nil
+	}
+	if filestor.flag&os.O_APPEND != 0 {
+		return os.Truncate(filestor.filename, filestor.start)
+	}
+	err := os.Remove(filestor.filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
 func (filestor *fileWriter) CloseWrite() error {
 	if filestor.writer != nil {
 		defer func() { filestor.writer = nil }()
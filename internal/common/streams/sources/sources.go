@@ -186,18 +186,17 @@ func (sw *SourceWriter) OpenWriter() error {
 	return nil
 }
 
+// CloseWrite - flush from source to destination, outer middle writer first
 func (sw *SourceWriter) CloseWrite() error {
-	err := sw.w.CloseWrite()
-	if err != nil {
-		return err
-	}
-	for _, middle := range sw.m {
-		err = middle.CloseWrite()
-		if err != nil {
-			return err
+	err := sw.f.CloseWrite()
+	for i := len(sw.m) - 1; i >= 0; i-- {
+		if errM := sw.m[i].CloseWrite(); errM != nil && err == nil {
+			err = errM
 		}
 	}
-	sw.f.CloseWrite()
+	if errW := sw.w.CloseWrite(); errW != nil && err == nil {
+		err = errW
+	}
 	return err
 }
 
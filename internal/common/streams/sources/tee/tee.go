@@ -0,0 +1,126 @@
+// Package tee - destination writer of stream to several destinations with quorum
+package tee
+
+import (
+	"errors"
+	"io"
+)
+
+type (
+	DestinationWriter interface {
+		OpenWriter() (io.Writer, error)
+		CloseWrite() error
+	}
+
+	// Aborter - destination drops its output, failed destination leaves no truncated data
+	Aborter interface {
+		Abort() error
+	}
+
+	teeWriter struct {
+		dests   []DestinationWriter
+		writers []io.Writer
+		errs    []error
+		quorum  int
+	}
+)
+
+var (
+	ErrNoQuorum = errors.New("error, write not flushed by quorum of destinations")
+)
+
+// NewWriter - write succeeds when at least quorum of destinations flush,
+// quorum out of range - all destinations
+func NewWriter(quorum int, dests ...DestinationWriter) *teeWriter {
+	if quorum <= 0 || quorum > len(dests) {
+		quorum = len(dests)
+	}
+	return &teeWriter{
+		dests:   dests,
+		writers: make([]io.Writer, len(dests)),
+		errs:    make([]error, len(dests)),
+		quorum:  quorum,
+	}
+}
+
+func (t *teeWriter) alive() int {
+	var n int
+	for _, err := range t.errs {
+		if err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+func (t *teeWriter) noQuorum() error {
+	return errors.Join(append([]error{ErrNoQuorum}, t.errs...)...)
+}
+
+func (t *teeWriter) OpenWriter() (io.Writer, error) {
+	for i, d := range t.dests {
+		t.writers[i], t.errs[i] = d.OpenWriter()
+	}
+	if t.alive() < t.quorum {
+		_ = t.CloseWrite()
+		return nil, t.noQuorum()
+	}
+	return t, nil
+}
+
+// drop - output of failed destination is aborted
+func (t *teeWriter) drop(i int) {
+	if a, ok := t.dests[i].(Aborter); ok {
+		_ = a.Abort()
+	} else if t.writers[i] != nil {
+		_ = t.dests[i].CloseWrite()
+	}
+	t.writers[i] = nil
+}
+
+// Write - failed destination is dropped, write fails when quorum is lost
+func (t *teeWriter) Write(p []byte) (int, error) {
+	for i, w := range t.writers {
+		if t.errs[i] != nil {
+			continue
+		}
+		n, err := w.Write(p)
+		if err == nil && n < len(p) {
+			err = io.ErrShortWrite
+		}
+		t.errs[i] = err
+		if err != nil {
+			t.drop(i)
+		}
+	}
+	if t.alive() < t.quorum {
+		return 0, t.noQuorum()
+	}
+	return len(p), nil
+}
+
+// CloseWrite - destination failed on flush is aborted
+func (t *teeWriter) CloseWrite() error {
+	for i, d := range t.dests {
+		if t.writers[i] == nil {
+			continue
+		}
+		err := d.CloseWrite()
+		t.writers[i] = nil
+		if t.errs[i] == nil {
+			t.errs[i] = err
+		}
+		if t.errs[i] != nil {
+			t.drop(i)
+		}
+	}
+	if t.alive() < t.quorum {
+		return t.noQuorum()
+	}
+	return nil
+}
+
+// Failed - errors of destinations, nil for flushed destination
+func (t *teeWriter) Failed() []error {
+	return t.errs
+}
@@ -0,0 +1,80 @@
+package tee
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bufDest struct {
+	buf      bytes.Buffer
+	errOpen  error
+	errW     error
+	errClose error
+	aborted  bool
+}
+
+func (b *bufDest) OpenWriter() (io.Writer, error) {
+	if b.errOpen != nil {
+		return nil, b.errOpen
+	}
+	return b, nil
+}
+
+func (b *bufDest) Write(p []byte) (int, error) {
+	if b.errW != nil {
+		return 0, b.errW
+	}
+	return b.buf.Write(p)
+}
+
+func (b *bufDest) CloseWrite() error {
+	return b.errClose
+}
+
+func (b *bufDest) Abort() error {
+	b.aborted = true
+	b.buf.Reset()
+	return nil
+}
+
+func Test_Tee(t *testing.T) {
+	errDisk := errors.New("disk")
+	tests := []struct {
+		name    string
+		quorum  int
+		second  *bufDest
+		wantErr bool
+	}{
+		{name: "Test both flush", second: &bufDest{}},
+		{name: "Test second fails, quorum all", second: &bufDest{errW: errDisk}, wantErr: true},
+		{name: "Test second fails, quorum one", quorum: 1, second: &bufDest{errW: errDisk}},
+		{name: "Test second not opened, quorum one", quorum: 1, second: &bufDest{errOpen: errDisk}},
+		{name: "Test second not flushed, quorum one", quorum: 1, second: &bufDest{errClose: errDisk}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := &bufDest{}
+			tw := NewWriter(tt.quorum, first, tt.second)
+			w, err := tw.OpenWriter()
+			require.NoError(t, err)
+			_, err = w.Write([]byte("data"))
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrNoQuorum)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, tw.CloseWrite())
+			assert.Equal(t, "data", first.buf.String())
+			failed := tw.Failed()[1] != nil
+			assert.Equal(t, tt.second.errW != nil || tt.second.errOpen != nil || tt.second.errClose != nil, failed)
+			// output of failed destination is dropped, not left truncated
+			assert.Equal(t, failed && tt.second.errOpen == nil, tt.second.aborted)
+			assert.False(t, first.aborted)
+		})
+	}
+}
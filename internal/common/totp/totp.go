@@ -0,0 +1,81 @@
+// Package totp - time-based one-time codes of RFC 6238, HMAC-SHA1, 30 seconds step, 6 digits
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/4aleksei/gokeeper/internal/common/utils/random"
+)
+
+const (
+	Period     = 30 * time.Second
+	Digits     = 6
+	secretSize = 20
+)
+
+var (
+	ErrBadSecret = errors.New("error, totp secret is not base32")
+
+	encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// GenerateSecret - random base32 secret
+func GenerateSecret() (string, error) {
+	b, err := random.GenerateRandom(secretSize)
+	if err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// Step - number of time step of t
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period/time.Second)
+}
+
+// CodeAt - code of secret at time step
+func CodeAt(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", ErrBadSecret
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	h := hmac.New(sha1.New, key)
+	h.Write(msg[:])
+	sum := h.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", Digits, value%1000000), nil
+}
+
+// Validate - time step of code valid at t or one step around t, false - code is not valid
+func Validate(secret string, code string, t time.Time) (int64, bool) {
+	step := Step(t)
+	for _, s := range []int64{step, step - 1, step + 1} {
+		c, err := CodeAt(secret, s)
+		if err != nil {
+			return 0, false
+		}
+		if hmac.Equal([]byte(c), []byte(code)) {
+			return s, true
+		}
+	}
+	return 0, false
+}
+
+// URI - otpauth URI of secret for authenticator apps
+func URI(issuer string, account string, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	return "otpauth://totp/" + url.PathEscape(issuer+":"+account) + "?" + v.Encode()
+}
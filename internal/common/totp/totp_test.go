@@ -0,0 +1,42 @@
+package totp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret - key "12345678901234567890" of test vectors of RFC 6238
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func Test_CodeAt(t *testing.T) {
+	for unix, code := range map[int64]string{59: "287082", 1111111109: "081804", 1234567890: "005924", 2000000000: "279037"} {
+		c, err := CodeAt(rfcSecret, Step(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, code, c)
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	t.Run("Test code of previous step", func(t *testing.T) {
+		step, ok := Validate(rfcSecret, "081804", now.Add(Period))
+		assert.True(t, ok)
+		assert.Equal(t, Step(now), step)
+	})
+	t.Run("Test old code", func(t *testing.T) {
+		_, ok := Validate(rfcSecret, "081804", now.Add(3*Period))
+		assert.False(t, ok)
+	})
+	t.Run("Test bad secret", func(t *testing.T) {
+		_, ok := Validate("1!", "081804", now)
+		assert.False(t, ok)
+	})
+}
+
+func Test_URI(t *testing.T) {
+	assert.Equal(t, "otpauth://totp/gokeeper:user1?issuer=gokeeper&secret=ABC", URI("gokeeper", "user1", "ABC"))
+}
@@ -33,11 +33,19 @@ func Run() error {
 		return errC
 	}
 
-	gService := service.New(storageRes.Store, storageRes.Enc, l.Logger, cfg)
+	gService, errS := service.New(storageRes.Store, storageRes.Enc, l.Logger, cfg)
+	if errS != nil {
+		l.Logger.Error("Error service construct:", zap.Error(errS))
+		return errS
+	}
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
 	defer stop()
 
+	go gService.RunRepair(ctx)
+	go gService.RunGC(ctx)
+	go gService.RunSweeper(ctx)
+
 	grpcServ, errG := grpcserver.New(gService, l, cfg)
 	if errG != nil {
 		l.Logger.Error("Error server grpc construct:", zap.Error(errG))
@@ -2,7 +2,10 @@
 package config
 
 import (
+	"errors"
 	"flag"
+	"os"
+	"time"
 )
 
 type Config struct {
@@ -13,6 +16,34 @@ type Config struct {
 	ConfigJsonFile  string
 	GrcpAddress     string
 	PrivateCertFile string
+	ChunkStore      bool
+	ChunkSize       int
+	S3Endpoint      string
+	S3Bucket        string
+	S3Region        string
+	S3AccessKey     string
+	S3SecretKey     string
+	S3PartSize      int
+	ReplicaDir      string
+	ReplicaS3       bool
+	ReplicaQuorum   int
+	ReplicaRepair   time.Duration
+	GCInterval      time.Duration
+	GCGrace         time.Duration
+	GCQuarantine    string
+	GCDryRun        bool
+	QuotaItems      int64
+	QuotaBytes      int64
+	MaxUploadSize   int64
+	SweepInterval   time.Duration
+	SecretTTL       time.Duration
+	EmergencyWait   time.Duration
+	EmergencyGrant  time.Duration
+	TokenTTL        time.Duration
+	RefreshTTL      time.Duration
+	LoginAttempts   int
+	LoginLockout    time.Duration
+	TrustedProxies  string
 }
 
 const (
@@ -26,6 +57,37 @@ const (
 	RestoreDefault         bool   = true
 	PrivateKeyFileDefault  string = ""
 	PrivateCertFileDefault string = ""
+	ChunkStoreDefault      bool   = false
+	ChunkSizeDefault       int    = 1 << 20
+	S3EndpointDefault      string = ""
+	S3BucketDefault        string = "gokeeper"
+	S3RegionDefault        string = "us-east-1"
+	S3PartSizeDefault      int    = 5 << 20
+	ReplicaDirDefault      string = ""
+	ReplicaS3Default       bool   = false
+	ReplicaQuorumDefault   int    = 2
+	ReplicaRepairDefault          = 10 * time.Minute
+	GCIntervalDefault             = time.Hour
+	GCGraceDefault                = 24 * time.Hour
+	GCQuarantineDefault    string = ""
+	GCDryRunDefault        bool   = false
+	QuotaItemsDefault      int64  = 0
+	QuotaBytesDefault      int64  = 0
+	MaxUploadSizeDefault   int64  = 0
+	SweepIntervalDefault          = time.Minute
+	SecretTTLDefault              = 24 * time.Hour
+	EmergencyWaitDefault          = 7 * 24 * time.Hour
+	EmergencyGrantDefault         = 30 * 24 * time.Hour
+	TokenTTLDefault               = 15 * time.Minute
+	RefreshTTLDefault             = 30 * 24 * time.Hour
+	LoginAttemptsDefault   int    = 5
+	LoginLockoutDefault           = time.Minute
+	TrustedProxiesDefault  string = ""
+)
+
+var (
+	ErrChunksReplica   = errors.New("error, chunk store can not be used with replica of blobs")
+	ErrReplicaConflict = errors.New("error, object store and directory can not both be replica of blobs")
 )
 
 func initDefaultCfg() *Config {
@@ -37,6 +99,34 @@ func initDefaultCfg() *Config {
 	cfg.ConfigJsonFile = ConfigDefaultJson
 	cfg.PrivateKeyFile = PrivateKeyFileDefault
 	cfg.PrivateCertFile = PrivateCertFileDefault
+	cfg.ChunkStore = ChunkStoreDefault
+	cfg.ChunkSize = ChunkSizeDefault
+	cfg.S3Endpoint = S3EndpointDefault
+	cfg.S3Bucket = S3BucketDefault
+	cfg.S3Region = S3RegionDefault
+	cfg.S3AccessKey = os.Getenv("AWS_ACCESS_KEY_ID")
+	cfg.S3SecretKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	cfg.S3PartSize = S3PartSizeDefault
+	cfg.ReplicaDir = ReplicaDirDefault
+	cfg.ReplicaS3 = ReplicaS3Default
+	cfg.ReplicaQuorum = ReplicaQuorumDefault
+	cfg.ReplicaRepair = ReplicaRepairDefault
+	cfg.GCInterval = GCIntervalDefault
+	cfg.GCGrace = GCGraceDefault
+	cfg.GCQuarantine = GCQuarantineDefault
+	cfg.GCDryRun = GCDryRunDefault
+	cfg.QuotaItems = QuotaItemsDefault
+	cfg.QuotaBytes = QuotaBytesDefault
+	cfg.MaxUploadSize = MaxUploadSizeDefault
+	cfg.SweepInterval = SweepIntervalDefault
+	cfg.SecretTTL = SecretTTLDefault
+	cfg.EmergencyWait = EmergencyWaitDefault
+	cfg.EmergencyGrant = EmergencyGrantDefault
+	cfg.TokenTTL = TokenTTLDefault
+	cfg.RefreshTTL = RefreshTTLDefault
+	cfg.LoginAttempts = LoginAttemptsDefault
+	cfg.LoginLockout = LoginLockoutDefault
+	cfg.TrustedProxies = TrustedProxiesDefault
 	return cfg
 }
 func New() (*Config, error) {
@@ -52,8 +142,52 @@ func New() (*Config, error) {
 	flag.StringVar(&cfg.Key, "k", cfg.Key, "key for signature")
 	flag.StringVar(&cfg.PrivateKeyFile, "crypto-key", cfg.PrivateKeyFile, "Private key file name (pem)")
 	flag.StringVar(&cfg.PrivateCertFile, "crypto-cert", cfg.PrivateCertFile, "Private cert file name (pem)")
+	flag.DurationVar(&cfg.TokenTTL, "token-ttl", cfg.TokenTTL, "Lifetime of access token")
+	flag.DurationVar(&cfg.RefreshTTL, "refresh-ttl", cfg.RefreshTTL, "Lifetime of refresh token, it is rotated on each refresh")
+	flag.IntVar(&cfg.LoginAttempts, "login-attempts", cfg.LoginAttempts, "Failed logins of address or account before lockout, 0 - no lockout")
+	flag.DurationVar(&cfg.LoginLockout, "login-lockout", cfg.LoginLockout, "First lockout after failed logins, it doubles on each next lockout")
+	flag.StringVar(&cfg.TrustedProxies, "trusted-proxies", cfg.TrustedProxies,
+		"Comma-separated addresses or networks of proxies, X-Real-IP of their requests is address of client, empty - X-Real-IP is ignored")
+
+	flag.BoolVar(&cfg.ChunkStore, "chunks", cfg.ChunkStore, "Store binary data as deduplicated content-defined chunks, it can not be used with replica")
+	flag.IntVar(&cfg.ChunkSize, "chunk-size", cfg.ChunkSize, "Average size of chunk, bytes")
+
+	flag.StringVar(&cfg.S3Endpoint, "s3-endpoint", cfg.S3Endpoint, "S3-compatible object store for blobs, empty - local files")
+	flag.StringVar(&cfg.S3Bucket, "s3-bucket", cfg.S3Bucket, "Bucket of object store")
+	flag.StringVar(&cfg.S3Region, "s3-region", cfg.S3Region, "Region of object store")
+	flag.StringVar(&cfg.S3AccessKey, "s3-access-key", cfg.S3AccessKey, "Access key of object store (AWS_ACCESS_KEY_ID)")
+	flag.StringVar(&cfg.S3SecretKey, "s3-secret-key", cfg.S3SecretKey, "Secret key of object store (AWS_SECRET_ACCESS_KEY)")
+	flag.IntVar(&cfg.S3PartSize, "s3-part-size", cfg.S3PartSize, "Part size of multipart upload, bytes")
+
+	flag.StringVar(&cfg.ReplicaDir, "replica-dir", cfg.ReplicaDir, "Directory of blob replicas")
+	flag.BoolVar(&cfg.ReplicaS3, "replica-s3", cfg.ReplicaS3, "Object store is replica of local blobs, not primary store")
+	flag.IntVar(&cfg.ReplicaQuorum, "replica-quorum", cfg.ReplicaQuorum, "Count of stores, 1 or 2, to flush blob for upload success")
+	flag.DurationVar(&cfg.ReplicaRepair, "replica-repair", cfg.ReplicaRepair, "Interval of repair of missing blob copies")
+
+	flag.DurationVar(&cfg.GCInterval, "gc-interval", cfg.GCInterval, "Interval of scan for orphaned blob files, 0 - disabled")
+	flag.DurationVar(&cfg.GCGrace, "gc-grace", cfg.GCGrace, "Age of orphaned blob file before it is collected")
+	flag.StringVar(&cfg.GCQuarantine, "gc-quarantine", cfg.GCQuarantine, "Directory orphaned blob files are moved to, empty - deleted")
+	flag.BoolVar(&cfg.GCDryRun, "gc-dry-run", cfg.GCDryRun, "Only report orphaned blob files")
+
+	flag.Int64Var(&cfg.QuotaItems, "quota-items", cfg.QuotaItems, "Maximum count of items of user, 0 - unlimited")
+	flag.Int64Var(&cfg.QuotaBytes, "quota-bytes", cfg.QuotaBytes, "Maximum stored bytes of user, 0 - unlimited")
+	flag.Int64Var(&cfg.MaxUploadSize, "max-upload", cfg.MaxUploadSize, "Maximum size of one upload, bytes, 0 - unlimited")
+
+	flag.DurationVar(&cfg.SweepInterval, "sweep-interval", cfg.SweepInterval, "Interval of deletion of expired items, 0 - disabled")
+	flag.DurationVar(&cfg.SecretTTL, "secret-ttl", cfg.SecretTTL, "Default and maximum lifetime of one-time secret link")
+
+	flag.DurationVar(&cfg.EmergencyWait, "emergency-wait", cfg.EmergencyWait, "Default waiting period of emergency access request")
+	flag.DurationVar(&cfg.EmergencyGrant, "emergency-grant", cfg.EmergencyGrant, "Lifetime of approved emergency access")
 
 	flag.Parse()
 
+	// chunks are not replicated, replica of chunk store would be empty
+	if cfg.ChunkStore && (cfg.ReplicaDir != "" || (cfg.ReplicaS3 && cfg.S3Endpoint != "")) {
+		return nil, ErrChunksReplica
+	}
+	// one replica of blobs, directory would replace object store
+	if cfg.ReplicaDir != "" && cfg.ReplicaS3 && cfg.S3Endpoint != "" {
+		return nil, ErrReplicaConflict
+	}
 	return cfg, nil
 }
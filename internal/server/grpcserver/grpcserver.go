@@ -19,13 +19,19 @@ import (
 type (
 	KeeperServiceService struct {
 		pb.UnimplementedKeeperServiceServer
-		srv  *grpc.Server
-		l    *logger.ZapLogger
-		serv *service.HandlerService
+		srv     *grpc.Server
+		l       *logger.ZapLogger
+		serv    *service.HandlerService
+		proxies interceptor.Proxies
 	}
 )
 
 func New(s *service.HandlerService, l *logger.ZapLogger, c *config.Config) (*KeeperServiceService, error) {
+	proxies, err := interceptor.ParseProxies(c.TrustedProxies)
+	if err != nil {
+		l.Logger.Debug("trusted proxies error: ", zap.Error(err))
+		return nil, err
+	}
 	listen, err := net.Listen("tcp", c.GrcpAddress)
 	if err != nil {
 		l.Logger.Debug("gRCP Listen Error: ", zap.Error(err))
@@ -37,9 +43,11 @@ func New(s *service.HandlerService, l *logger.ZapLogger, c *config.Config) (*Kee
 	}
 
 	auth := interceptor.NewAuthInterceptor(s)
+	limit := interceptor.NewLimitInterceptor(c.LoginAttempts, c.LoginLockout, proxies, s, l.Logger)
 
 	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
 		logging.UnaryServerInterceptor(interceptor.InterceptorLogger(l.Logger), opts...),
+		grpc.UnaryServerInterceptor(limit.UnaryLimitMiddleware),
 		grpc.UnaryServerInterceptor(auth.UnaryAuthMiddleware),
 	),
 		grpc.ChainStreamInterceptor(
@@ -49,8 +57,9 @@ func New(s *service.HandlerService, l *logger.ZapLogger, c *config.Config) (*Kee
 	)
 
 	server := &KeeperServiceService{serv: s,
-		srv: grpcServer,
-		l:   l,
+		srv:     grpcServer,
+		l:       l,
+		proxies: proxies,
 	}
 
 	pb.RegisterKeeperServiceServer(grpcServer, server)
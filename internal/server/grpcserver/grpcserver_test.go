@@ -2,19 +2,33 @@ package grpcserver
 
 import (
 	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
+	"math/rand"
 	"net"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"sync"
 
+	"github.com/4aleksei/gokeeper/internal/common/aescoder"
 	"github.com/4aleksei/gokeeper/internal/common/cryptocerts"
 	"github.com/4aleksei/gokeeper/internal/common/datacrypto"
 	"github.com/4aleksei/gokeeper/internal/common/logger"
+	"github.com/4aleksei/gokeeper/internal/common/search"
 	"github.com/4aleksei/gokeeper/internal/common/store"
 	"github.com/4aleksei/gokeeper/internal/common/store/cache"
+	"github.com/4aleksei/gokeeper/internal/common/streams/sources/s3object/s3fake"
+	"github.com/4aleksei/gokeeper/internal/common/totp"
+	"github.com/4aleksei/gokeeper/internal/common/utils/random"
 	"github.com/4aleksei/gokeeper/internal/server/config"
 	"github.com/4aleksei/gokeeper/internal/server/grpcserver/interceptor"
 	"github.com/4aleksei/gokeeper/internal/server/service"
@@ -25,6 +39,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 )
@@ -36,20 +51,44 @@ var onceCfg sync.Once
 type (
 	testServer struct {
 		lis        *bufconn.Listener
+		cfg        *config.Config
 		st         *service.HandlerService
 		l          *logger.ZapLogger
 		client     pb.KeeperServiceClient
 		grpcServer *grpc.Server
 		conn       *grpc.ClientConn
 	}
+
+	// loopbackListener - connections of bufconn with loopback address of peer, it is trusted as proxy
+	loopbackListener struct {
+		*bufconn.Listener
+	}
+
+	loopbackConn struct {
+		net.Conn
+	}
 )
 
-func newTestServer() *testServer {
+func (l loopbackListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return loopbackConn{conn}, nil
+}
+
+func (loopbackConn) RemoteAddr() net.Addr {
+	return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 40000}
+}
+
+func newTestServer(opts ...func(*config.Config)) *testServer {
 	l := createLogger()
-	st := createService(l)
+	c := createConfig(opts...)
+	st := createService(l, c)
 	tt := &testServer{
 		lis: bufconn.Listen(bufSize),
 		l:   createLogger(),
+		cfg: c,
 		st:  st,
 	}
 	tt.client = tt.startGRCPServerClient()
@@ -68,38 +107,56 @@ func createLogger() *logger.ZapLogger {
 
 var cfg *config.Config
 
-func createService(l *logger.ZapLogger) *service.HandlerService {
+func createConfig(opts ...func(*config.Config)) *config.Config {
 	onceCfg.Do(func() {
 		cfg, _ = config.New()
+		dir, _ := os.MkdirTemp("", "gokeeper")
+		cfg.FilePath = dir + string(os.PathSeparator)
 	})
+	c := *cfg
+	for _, o := range opts {
+		o(&c)
+	}
+	return &c
+}
+
+func createService(l *logger.ZapLogger, c *config.Config) *service.HandlerService {
 
 	pr, pub, _ := cryptocerts.GenerateKey()
 	crypto := datacrypto.New(pr, pub)
 
-	return service.New(cache.New(l.Logger), crypto, l.Logger, cfg)
+	st, _ := service.New(cache.New(l.Logger), crypto, l.Logger, c)
+	return st
 }
 
 func (tt *testServer) startGRCPServerClient() pb.KeeperServiceClient {
 
-	interceptor := interceptor.NewAuthInterceptor(tt.st)
+	auth := interceptor.NewAuthInterceptor(tt.st)
+	proxies, err := interceptor.ParseProxies(tt.cfg.TrustedProxies)
+	if err != nil {
+		log.Fatal(err)
+	}
+	limit := interceptor.NewLimitInterceptor(tt.cfg.LoginAttempts, tt.cfg.LoginLockout, proxies, tt.st, tt.l.Logger)
 	tt.grpcServer = grpc.NewServer(grpc.ChainUnaryInterceptor(
-
-		grpc.UnaryServerInterceptor(interceptor.UnaryAuthMiddleware),
+		grpc.UnaryServerInterceptor(limit.UnaryLimitMiddleware),
+		grpc.UnaryServerInterceptor(auth.UnaryAuthMiddleware),
 	),
-		grpc.ChainStreamInterceptor())
+		grpc.ChainStreamInterceptor(
+			grpc.StreamServerInterceptor(auth.StreamAuthMiddleware),
+		))
 
 	pb.RegisterKeeperServiceServer(tt.grpcServer, KeeperServiceService{serv: tt.st,
-		srv: tt.grpcServer,
-		l:   tt.l,
+		srv:     tt.grpcServer,
+		l:       tt.l,
+		proxies: proxies,
 	})
 
 	go func() {
-		if err := tt.grpcServer.Serve(tt.lis); err != nil {
+		if err := tt.grpcServer.Serve(loopbackListener{tt.lis}); err != nil {
 			log.Fatal(err)
 		}
 	}()
 	//	defer grpcServer.Stop() // Ensure server is stopped after test
-	var err error
 	tt.conn, err = grpc.NewClient("passthrough:///bufnet", grpc.WithContextDialer(tt.bufDialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		log.Fatal(err)
@@ -128,7 +185,7 @@ func TestServerSingle(t *testing.T) {
 
 		{name: "Test N2 LoginUser", oper: "LoginUser", user: store.User{Name: "aa", HashPass: "bbb"}, wantId: 1},
 
-		{name: "Test N3 Error LoginUser", oper: "LoginUser", user: store.User{Name: "cc", HashPass: "bbb"}, wantId: 1, errcode: codes.NotFound},
+		{name: "Test N3 Error LoginUser", oper: "LoginUser", user: store.User{Name: "cc", HashPass: "bbb"}, wantId: 1, errcode: codes.Unauthenticated},
 	}
 
 	for _, tt := range tests {
@@ -354,3 +411,2239 @@ func TestInsertStreamData(t *testing.T) {
 	}
 
 }
+
+func TestSearchData(t *testing.T) {
+	testServ := newTestServer()
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+
+	md := metadata.New(map[string]string{"authorization": login.GetToken()})
+	ctxReq := metadata.NewOutgoingContext(context.Background(), md)
+
+	key := []byte("blind index key")
+	items := []string{"github work account", "bank card", "github"}
+	uuids := make([]string, 0, len(items))
+	for _, meta := range items {
+		val, err := testServ.client.AddData(ctxReq, &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "a:b", Metadata: meta,
+			Tokens: search.BlindTokens(key, meta)})
+		require.NoError(t, err)
+		uuids = append(uuids, val.GetUuid())
+	}
+
+	recvAll := func(req *pb.SearchRequest) []*pb.SearchResult {
+		stream, err := testServ.client.Search(ctxReq, req)
+		require.NoError(t, err)
+		var res []*pb.SearchResult
+		for {
+			r, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			res = append(res, r)
+		}
+		return res
+	}
+
+	t.Run("Test N1 Search plaintext", func(t *testing.T) {
+		res := recvAll(&pb.SearchRequest{Query: "GitHub acc"})
+		require.Len(t, res, 2)
+		assert.Equal(t, uuids[0], res[0].GetUuid())
+		assert.Equal(t, "*github* work *account*", res[0].GetMetadata())
+		assert.Equal(t, uuids[2], res[1].GetUuid())
+	})
+
+	t.Run("Test N2 Search blind index", func(t *testing.T) {
+		res := recvAll(&pb.SearchRequest{Tokens: search.BlindTokens(key, "card")})
+		require.Len(t, res, 1)
+		assert.Equal(t, uuids[1], res[0].GetUuid())
+		assert.Equal(t, "bank card", res[0].GetMetadata())
+	})
+
+	t.Run("Test N3 Search nothing", func(t *testing.T) {
+		res := recvAll(&pb.SearchRequest{Query: "mail"})
+		assert.Empty(t, res)
+	})
+}
+
+func uploadTest(ctx context.Context, client pb.KeeperServiceClient, chunks ...*pb.DataChunk) (*pb.ResponseAddData, error) {
+	stream, err := client.UploadData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range chunks {
+		if err := stream.Send(c); err != nil {
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+func TestAttachments(t *testing.T) {
+	testServ := newTestServer()
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	md := metadata.New(map[string]string{"authorization": login.GetToken()})
+	ctxReq := metadata.NewOutgoingContext(context.Background(), md)
+
+	parent, err := testServ.client.AddData(ctxReq, &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "a:b", Metadata: "bank"})
+	require.NoError(t, err)
+
+	file, _ := generateTest(5000)
+	att, err := uploadTest(ctxReq, testServ.client,
+		&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Metadata: "codes.pdf", Parent: parent.GetUuid(), Data: file[:1000]},
+		&pb.DataChunk{Data: file[1000:]})
+	require.NoError(t, err)
+
+	t.Run("Test N1 attachment of attachment", func(t *testing.T) {
+		_, err := uploadTest(ctxReq, testServ.client, &pb.DataChunk{Type: pb.TypeData_BINARYDATA, Parent: att.GetUuid(), Data: file})
+		assert.Error(t, err)
+	})
+
+	t.Run("Test N2 GetData lists attachments", func(t *testing.T) {
+		val, err := testServ.client.GetData(ctxReq, &pb.DownloadRequest{Uuid: parent.GetUuid()})
+		require.NoError(t, err)
+		require.Len(t, val.GetAttachments(), 1)
+		assert.Equal(t, att.GetUuid(), val.GetAttachments()[0].GetUuid())
+		assert.Equal(t, "codes.pdf", val.GetAttachments()[0].GetName())
+		assert.Equal(t, int64(len(file)), val.GetAttachments()[0].GetSize())
+	})
+
+	t.Run("Test N3 DeleteData cascades", func(t *testing.T) {
+		del, err := testServ.client.DeleteData(ctxReq, &pb.DownloadRequest{Uuid: parent.GetUuid()})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{parent.GetUuid(), att.GetUuid()}, del.GetUuids())
+		_, err = testServ.client.GetData(ctxReq, &pb.DownloadRequest{Uuid: att.GetUuid()})
+		assert.Error(t, err)
+	})
+}
+
+func downloadTest(ctx context.Context, client pb.KeeperServiceClient, req *pb.DownloadRequest) ([]*pb.DataChunk, []byte, error) {
+	stream, err := client.DownloadData(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	var chunks []*pb.DataChunk
+	var data []byte
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return chunks, data, nil
+		}
+		if err != nil {
+			return chunks, data, err
+		}
+		chunks = append(chunks, chunk)
+		data = append(data, chunk.GetData()...)
+	}
+}
+
+func TestResumableUpload(t *testing.T) {
+	testServ := newTestServer()
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	md := metadata.New(map[string]string{"authorization": login.GetToken()})
+	ctxReq := metadata.NewOutgoingContext(context.Background(), md)
+
+	file, _ := generateTest(10000)
+	size := int64(len(file))
+
+	t.Run("Test N1 broken stream keeps progress", func(t *testing.T) {
+		_, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Metadata: "image", Session: "s1", Size: size, Data: file[:5000]},
+			&pb.DataChunk{Offset: 9000, Data: file[9000:]})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		q, err := testServ.client.QueryUpload(ctxReq, &pb.QueryUploadRequest{Session: "s1"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(5000), q.GetOffset())
+		assert.Equal(t, size, q.GetSize())
+	})
+
+	t.Run("Test N2 resume from other offset", func(t *testing.T) {
+		_, err := uploadTest(ctxReq, testServ.client, &pb.DataChunk{Session: "s1", Offset: 4000, Data: file[4000:]})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("Test N3 resume and commit", func(t *testing.T) {
+		val, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Session: "s1", Offset: 5000, Data: file[5000:8000]},
+			&pb.DataChunk{Offset: 8000, Data: file[8000:]})
+		require.NoError(t, err)
+
+		chunks, data, err := downloadTest(ctxReq, testServ.client, &pb.DownloadRequest{Uuid: val.GetUuid()})
+		require.NoError(t, err)
+		assert.Equal(t, "image", chunks[0].GetMetadata())
+		assert.Equal(t, file, data)
+
+		_, err = testServ.client.QueryUpload(ctxReq, &pb.QueryUploadRequest{Session: "s1"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Test N4 size mismatch is not committed", func(t *testing.T) {
+		_, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Session: "s2", Size: size, Data: file[:100]})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		q, err := testServ.client.QueryUpload(ctxReq, &pb.QueryUploadRequest{Session: "s2"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), q.GetOffset())
+	})
+
+	t.Run("Test N5 session of other user is not visible", func(t *testing.T) {
+		login2, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user2", Password: "abcd"})
+		require.NoError(t, err)
+		ctxReq2 := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"authorization": login2.GetToken()}))
+
+		_, err = testServ.client.QueryUpload(ctxReq2, &pb.QueryUploadRequest{Session: "s2"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		val, err := uploadTest(ctxReq2, testServ.client,
+			&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Session: "s2", Size: size, Data: file})
+		require.NoError(t, err)
+		_, data, err := downloadTest(ctxReq2, testServ.client, &pb.DownloadRequest{Uuid: val.GetUuid()})
+		require.NoError(t, err)
+		assert.Equal(t, file, data)
+
+		q, err := testServ.client.QueryUpload(ctxReq, &pb.QueryUploadRequest{Session: "s2"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), q.GetOffset())
+	})
+}
+
+func TestRangedDownload(t *testing.T) {
+	testServ := newTestServer()
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	md := metadata.New(map[string]string{"authorization": login.GetToken()})
+	ctxReq := metadata.NewOutgoingContext(context.Background(), md)
+
+	file, _ := generateTest(10000)
+	size := int64(len(file))
+	val, err := uploadTest(ctxReq, testServ.client,
+		&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Metadata: "blob", Data: file[:4000]},
+		&pb.DataChunk{Data: file[4000:]})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		offset  int64
+		length  int64
+		want    []byte
+		errcode codes.Code
+	}{
+		{name: "Test N1 whole", want: file},
+		{name: "Test N2 resume", offset: 5000, want: file[5000:]},
+		{name: "Test N3 beginning", length: 100, want: file[:100]},
+		{name: "Test N4 range over blocks", offset: 4090, length: 20, want: file[4090:4110]},
+		{name: "Test N5 empty range at end", offset: size},
+		{name: "Test N6 out of range", offset: size + 1, errcode: codes.OutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks, data, err := downloadTest(ctxReq, testServ.client, &pb.DownloadRequest{Uuid: val.GetUuid(), Offset: tt.offset, Length: tt.length})
+			if tt.errcode != codes.OK {
+				assert.Equal(t, tt.errcode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, chunks)
+			assert.Equal(t, tt.offset, chunks[0].GetOffset())
+			assert.Equal(t, size, chunks[0].GetSize())
+			assert.Equal(t, "blob", chunks[0].GetMetadata())
+			assert.Equal(t, len(tt.want), len(data))
+			assert.Equal(t, tt.want, data[:len(tt.want)])
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	testServ := newTestServer()
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	md := metadata.New(map[string]string{"authorization": login.GetToken()})
+	ctxReq := metadata.NewOutgoingContext(context.Background(), md)
+
+	file, _ := generateTest(10000)
+	sum := sha256.Sum256(file)
+	checksum := hex.EncodeToString(sum[:])
+
+	t.Run("Test N1 checksum in first download chunk", func(t *testing.T) {
+		val, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Data: file[:6000]},
+			&pb.DataChunk{Data: file[6000:], Checksum: checksum})
+		require.NoError(t, err)
+
+		chunks, _, err := downloadTest(ctxReq, testServ.client, &pb.DownloadRequest{Uuid: val.GetUuid(), Offset: 100})
+		require.NoError(t, err)
+		assert.Equal(t, checksum, chunks[0].GetChecksum())
+	})
+
+	t.Run("Test N2 checksum mismatch", func(t *testing.T) {
+		_, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Data: file[1:], Checksum: checksum})
+		assert.Equal(t, codes.DataLoss, status.Code(err))
+	})
+
+	t.Run("Test N3 checksum of resumed upload", func(t *testing.T) {
+		_, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Session: "s1", Size: int64(len(file)), Data: file[:5000]})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		val, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Session: "s1", Offset: 5000, Data: file[5000:], Checksum: checksum})
+		require.NoError(t, err)
+
+		chunks, data, err := downloadTest(ctxReq, testServ.client, &pb.DownloadRequest{Uuid: val.GetUuid()})
+		require.NoError(t, err)
+		assert.Equal(t, file, data)
+		assert.Equal(t, checksum, chunks[0].GetChecksum())
+	})
+}
+
+func dirSize(dir string) int64 {
+	var size int64
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if info, err := e.Info(); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
+
+func TestCompression(t *testing.T) {
+	testServ := newTestServer()
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	md := metadata.New(map[string]string{"authorization": login.GetToken()})
+	ctxReq := metadata.NewOutgoingContext(context.Background(), md)
+
+	file, _ := generateTest(20000)
+	png := append([]byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a}, file...)
+
+	tests := []struct {
+		name        string
+		data        []byte
+		compression string
+		compressed  bool
+		errcode     codes.Code
+	}{
+		{name: "Test N1 auto text", data: file, compressed: true},
+		{name: "Test N2 auto compressed format", data: png},
+		{name: "Test N3 none", data: file, compression: "none"},
+		{name: "Test N4 fast", data: file, compression: "gzip-fast", compressed: true},
+		{name: "Test N5 lz", data: file, compression: "lz", compressed: true},
+		{name: "Test N6 unknown", data: file, compression: "lzma", errcode: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := dirSize(cfg.FilePath)
+			val, err := uploadTest(ctxReq, testServ.client,
+				&pb.DataChunk{Type: pb.TypeData_TEXTDATA, Compression: tt.compression, Data: tt.data[:5000]},
+				&pb.DataChunk{Data: tt.data[5000:]})
+			if tt.errcode != codes.OK {
+				assert.Equal(t, tt.errcode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			stored := dirSize(cfg.FilePath) - before
+			assert.Equal(t, tt.compressed, stored < int64(len(tt.data)))
+
+			_, data, err := downloadTest(ctxReq, testServ.client, &pb.DownloadRequest{Uuid: val.GetUuid()})
+			require.NoError(t, err)
+			assert.Equal(t, tt.data, data)
+
+			_, data, err = downloadTest(ctxReq, testServ.client, &pb.DownloadRequest{Uuid: val.GetUuid(), Offset: 9000, Length: 100})
+			require.NoError(t, err)
+			assert.Equal(t, tt.data[9000:9100], data)
+		})
+	}
+
+	t.Run("Test N6 resumed compressed upload", func(t *testing.T) {
+		sum := sha256.Sum256(file)
+		_, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Type: pb.TypeData_TEXTDATA, Session: "s1", Size: int64(len(file)), Data: file[:5000]})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		_, err = uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Session: "s1", Offset: 5000, Data: file[5000:7000]},
+			&pb.DataChunk{Offset: 9000, Data: file[9000:]})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		val, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Session: "s1", Offset: 7000, Data: file[7000:], Checksum: hex.EncodeToString(sum[:])})
+		require.NoError(t, err)
+
+		_, data, err := downloadTest(ctxReq, testServ.client, &pb.DownloadRequest{Uuid: val.GetUuid()})
+		require.NoError(t, err)
+		assert.Equal(t, file, data)
+	})
+}
+
+func countFiles(dir string) int {
+	var files int
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, _ error) error {
+		if d != nil && !d.IsDir() {
+			files++
+		}
+		return nil
+	})
+	return files
+}
+
+func TestChunkStore(t *testing.T) {
+	dir := t.TempDir() + string(os.PathSeparator)
+	testServ := newTestServer(func(c *config.Config) {
+		c.FilePath = dir
+		c.ChunkStore = true
+		c.ChunkSize = 1024
+	})
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	md := metadata.New(map[string]string{"authorization": login.GetToken()})
+	ctxReq := metadata.NewOutgoingContext(context.Background(), md)
+
+	file := make([]byte, 64*1024)
+	rand.New(rand.NewSource(1)).Read(file)
+	chunksDir := dir + ".chunks"
+
+	upload := func(data []byte) string {
+		val, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Data: data[:10000]},
+			&pb.DataChunk{Data: data[10000:]})
+		require.NoError(t, err)
+		return val.GetUuid()
+	}
+
+	first := upload(file)
+	count := countFiles(chunksDir)
+	require.Greater(t, count, 1)
+
+	second := upload(file)
+	shifted := upload(append([]byte("new header"), file...))
+
+	t.Run("Test N1 same content is stored once", func(t *testing.T) {
+		assert.Less(t, countFiles(chunksDir)-count, count/2)
+		assert.Zero(t, countFiles(dir)-countFiles(chunksDir))
+	})
+
+	t.Run("Test N2 download chunked data", func(t *testing.T) {
+		_, data, err := downloadTest(ctxReq, testServ.client, &pb.DownloadRequest{Uuid: second})
+		require.NoError(t, err)
+		assert.Equal(t, file, data)
+
+		_, data, err = downloadTest(ctxReq, testServ.client, &pb.DownloadRequest{Uuid: shifted, Offset: 30010, Length: 5000})
+		require.NoError(t, err)
+		assert.Equal(t, file[30000:35000], data)
+	})
+
+	t.Run("Test N3 delete frees chunks", func(t *testing.T) {
+		_, err := testServ.client.DeleteData(ctxReq, &pb.DownloadRequest{Uuid: first})
+		require.NoError(t, err)
+		_, data, err := downloadTest(ctxReq, testServ.client, &pb.DownloadRequest{Uuid: second})
+		require.NoError(t, err)
+		assert.Equal(t, file, data)
+
+		for _, uuid := range []string{second, shifted} {
+			_, err = testServ.client.DeleteData(ctxReq, &pb.DownloadRequest{Uuid: uuid})
+			require.NoError(t, err)
+		}
+		assert.Zero(t, countFiles(chunksDir))
+	})
+}
+
+func TestObjectStore(t *testing.T) {
+	fake := s3fake.New()
+	defer fake.Close()
+	dir := t.TempDir() + string(os.PathSeparator)
+	testServ := newTestServer(func(c *config.Config) {
+		c.FilePath = dir
+		c.S3Endpoint = fake.URL
+		c.S3Bucket = "blobs"
+		c.S3AccessKey = "ak"
+		c.S3SecretKey = "sk"
+		c.S3PartSize = 4096
+	})
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	md := metadata.New(map[string]string{"authorization": login.GetToken()})
+	ctxReq := metadata.NewOutgoingContext(context.Background(), md)
+
+	file := make([]byte, 30000)
+	rand.New(rand.NewSource(2)).Read(file)
+	var uuids []string
+
+	t.Run("Test N1 stream to object store", func(t *testing.T) {
+		val, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Data: file[:10000]},
+			&pb.DataChunk{Data: file[10000:]})
+		require.NoError(t, err)
+		uuids = append(uuids, val.GetUuid())
+		assert.Len(t, fake.Keys(), 1)
+		assert.Zero(t, countFiles(dir))
+	})
+
+	t.Run("Test N2 resumed upload is pushed on commit", func(t *testing.T) {
+		_, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Session: "s1", Size: int64(len(file)), Data: file[:10000]})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, 1, countFiles(dir))
+
+		val, err := uploadTest(ctxReq, testServ.client, &pb.DataChunk{Session: "s1", Offset: 10000, Data: file[10000:]})
+		require.NoError(t, err)
+		uuids = append(uuids, val.GetUuid())
+		assert.Len(t, fake.Keys(), 2)
+		assert.Zero(t, countFiles(dir))
+	})
+
+	t.Run("Test N3 range of object", func(t *testing.T) {
+		for _, uuid := range uuids {
+			_, data, err := downloadTest(ctxReq, testServ.client, &pb.DownloadRequest{Uuid: uuid})
+			require.NoError(t, err)
+			assert.Equal(t, file, data)
+
+			_, data, err = downloadTest(ctxReq, testServ.client, &pb.DownloadRequest{Uuid: uuid, Offset: 12345, Length: 1000})
+			require.NoError(t, err)
+			assert.Equal(t, file[12345:13345], data)
+		}
+	})
+
+	t.Run("Test N4 delete removes objects", func(t *testing.T) {
+		for _, uuid := range uuids {
+			_, err := testServ.client.DeleteData(ctxReq, &pb.DownloadRequest{Uuid: uuid})
+			require.NoError(t, err)
+		}
+		assert.Empty(t, fake.Keys())
+	})
+}
+
+func TestReplication(t *testing.T) {
+	dir := t.TempDir() + string(os.PathSeparator)
+	replicaDir := t.TempDir()
+	testServ := newTestServer(func(c *config.Config) {
+		c.FilePath = dir
+		c.ReplicaDir = replicaDir
+	})
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	md := metadata.New(map[string]string{"authorization": login.GetToken()})
+	ctxReq := metadata.NewOutgoingContext(context.Background(), md)
+
+	file := make([]byte, 30000)
+	rand.New(rand.NewSource(3)).Read(file)
+	var uuids []string
+
+	t.Run("Test N1 stream is written to replica", func(t *testing.T) {
+		val, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Data: file[:10000]},
+			&pb.DataChunk{Data: file[10000:]})
+		require.NoError(t, err)
+		uuids = append(uuids, val.GetUuid())
+		assert.Equal(t, 1, countFiles(dir))
+		assert.Equal(t, 1, countFiles(replicaDir))
+	})
+
+	t.Run("Test N2 resumed upload is copied on commit", func(t *testing.T) {
+		_, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Session: "s1", Size: int64(len(file)), Data: file[:10000]})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, 1, countFiles(replicaDir))
+
+		val, err := uploadTest(ctxReq, testServ.client, &pb.DataChunk{Session: "s1", Offset: 10000, Data: file[10000:]})
+		require.NoError(t, err)
+		uuids = append(uuids, val.GetUuid())
+		assert.Equal(t, 2, countFiles(dir))
+		assert.Equal(t, 2, countFiles(replicaDir))
+	})
+
+	t.Run("Test N3 repair of lost copies", func(t *testing.T) {
+		primary, _ := filepath.Glob(dir + "*.data")
+		require.Len(t, primary, 2)
+		require.NoError(t, os.Remove(primary[0]))
+		require.NoError(t, os.Remove(filepath.Join(replicaDir, filepath.Base(primary[1]))))
+
+		report, err := testServ.st.RepairReplicas(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, service.RepairReport{Checked: 2, Primary: 1, Replica: 1}, *report)
+		assert.Equal(t, 2, countFiles(dir))
+		assert.Equal(t, 2, countFiles(replicaDir))
+
+		for _, uuid := range uuids {
+			_, data, err := downloadTest(ctxReq, testServ.client, &pb.DownloadRequest{Uuid: uuid})
+			require.NoError(t, err)
+			assert.Equal(t, file, data)
+		}
+	})
+
+	t.Run("Test N4 repair of truncated and corrupted copies", func(t *testing.T) {
+		primary, _ := filepath.Glob(dir + "*.data")
+		require.Len(t, primary, 2)
+		info, err := os.Stat(primary[0])
+		require.NoError(t, err)
+		require.NoError(t, os.Truncate(primary[0], info.Size()/2))
+		copied := filepath.Join(replicaDir, filepath.Base(primary[1]))
+		stored, err := os.ReadFile(copied)
+		require.NoError(t, err)
+		stored[len(stored)/2] ^= 0xff
+		require.NoError(t, os.WriteFile(copied, stored, 0600))
+
+		report, err := testServ.st.RepairReplicas(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, service.RepairReport{Checked: 2, Primary: 1, Replica: 1, Corrupt: 2}, *report)
+
+		for _, uuid := range uuids {
+			_, data, err := downloadTest(ctxReq, testServ.client, &pb.DownloadRequest{Uuid: uuid})
+			require.NoError(t, err)
+			assert.Equal(t, file, data)
+		}
+	})
+
+	t.Run("Test N5 blob lost in both stores", func(t *testing.T) {
+		primary, _ := filepath.Glob(dir + "*.data")
+		require.NoError(t, os.Remove(primary[0]))
+		require.NoError(t, os.Remove(filepath.Join(replicaDir, filepath.Base(primary[0]))))
+
+		report, err := testServ.st.RepairReplicas(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Lost)
+		assert.Equal(t, 1, report.Failures)
+	})
+
+	t.Run("Test N6 delete removes replicas", func(t *testing.T) {
+		for _, uuid := range uuids {
+			_, err := testServ.client.DeleteData(ctxReq, &pb.DownloadRequest{Uuid: uuid})
+			require.NoError(t, err)
+		}
+		assert.Zero(t, countFiles(dir))
+		assert.Zero(t, countFiles(replicaDir))
+	})
+}
+
+func TestReplicationQuorum(t *testing.T) {
+	file := make([]byte, 20000)
+	rand.New(rand.NewSource(4)).Read(file)
+
+	for _, tc := range []struct {
+		name   string
+		quorum int
+		ok     bool
+	}{
+		{name: "Test N1 replica failure fails upload of quorum 2", quorum: 2},
+		{name: "Test N2 replica failure is tolerated by quorum 1", quorum: 1, ok: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := s3fake.New()
+			dir := t.TempDir() + string(os.PathSeparator)
+			testServ := newTestServer(func(c *config.Config) {
+				c.FilePath = dir
+				c.S3Endpoint = fake.URL
+				c.S3AccessKey = "ak"
+				c.S3SecretKey = "sk"
+				c.ReplicaS3 = true
+				c.ReplicaQuorum = tc.quorum
+			})
+			defer func() {
+				testServ.conn.Close()
+				testServ.grpcServer.Stop()
+			}()
+			fake.Close()
+
+			login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+			require.NoError(t, err)
+			md := metadata.New(map[string]string{"authorization": login.GetToken()})
+			ctxReq := metadata.NewOutgoingContext(context.Background(), md)
+
+			_, err = uploadTest(ctxReq, testServ.client, &pb.DataChunk{Type: pb.TypeData_BINARYDATA, Data: file})
+			_, errS := uploadTest(ctxReq, testServ.client,
+				&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Session: "s1", Size: int64(len(file)), Data: file})
+			if !tc.ok {
+				assert.Error(t, err)
+				assert.Error(t, errS)
+				assert.Equal(t, 1, countFiles(dir))
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, errS)
+			assert.Equal(t, 2, countFiles(dir))
+		})
+	}
+}
+
+func TestGarbageCollection(t *testing.T) {
+	dir := t.TempDir() + string(os.PathSeparator)
+	quarantine := t.TempDir()
+	testServ := newTestServer(func(c *config.Config) {
+		c.FilePath = dir
+		c.GCQuarantine = quarantine
+	})
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	md := metadata.New(map[string]string{"authorization": login.GetToken()})
+	ctxReq := metadata.NewOutgoingContext(context.Background(), md)
+
+	file := make([]byte, 20000)
+	rand.New(rand.NewSource(5)).Read(file)
+
+	t.Run("Test N1 broken stream removes partial file", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctxReq)
+		stream, err := testServ.client.UploadData(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Data: file[:10000]}))
+		assert.Eventually(t, func() bool { return countFiles(dir) == 1 }, time.Second, 10*time.Millisecond)
+		cancel()
+		assert.Eventually(t, func() bool { return countFiles(dir) == 0 }, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Test N2 broken session keeps file", func(t *testing.T) {
+		_, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Session: "s1", Size: int64(len(file)), Data: file[:10000]})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, 1, countFiles(dir))
+
+		_, err = uploadTest(ctxReq, testServ.client, &pb.DataChunk{Type: pb.TypeData_BINARYDATA, Data: file})
+		require.NoError(t, err)
+		assert.Equal(t, 2, countFiles(dir))
+	})
+
+	orphan := filepath.Join(dir, "orphan.data")
+	require.NoError(t, os.WriteFile(orphan, file, 0o600))
+
+	t.Run("Test N3 young orphan is kept", func(t *testing.T) {
+		report, err := testServ.st.CollectGarbage(context.Background(), false)
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.Scanned)
+		assert.Empty(t, report.Orphans)
+	})
+
+	old := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, os.Chtimes(orphan, old, old))
+	files, _ := filepath.Glob(dir + "*.data")
+	for _, f := range files {
+		require.NoError(t, os.Chtimes(f, old, old))
+	}
+
+	t.Run("Test N4 dry run reports orphan", func(t *testing.T) {
+		report, err := testServ.st.CollectGarbage(context.Background(), true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{orphan}, report.Orphans)
+		assert.Equal(t, int64(len(file)), report.Bytes)
+		assert.Zero(t, report.Quarantined)
+		assert.FileExists(t, orphan)
+	})
+
+	t.Run("Test N5 orphan is quarantined", func(t *testing.T) {
+		report, err := testServ.st.CollectGarbage(context.Background(), false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Quarantined)
+		assert.NoFileExists(t, orphan)
+		assert.FileExists(t, filepath.Join(quarantine, "orphan.data"))
+		assert.Equal(t, 2, countFiles(dir))
+	})
+}
+
+func TestQuota(t *testing.T) {
+	dir := t.TempDir() + string(os.PathSeparator)
+	testServ := newTestServer(func(c *config.Config) {
+		c.FilePath = dir
+		c.QuotaItems = 3
+		c.QuotaBytes = 25000
+		c.MaxUploadSize = 20000
+	})
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	md := metadata.New(map[string]string{"authorization": login.GetToken()})
+	ctxReq := metadata.NewOutgoingContext(context.Background(), md)
+
+	file := make([]byte, 21000)
+	rand.New(rand.NewSource(6)).Read(file)
+	var uuid string
+
+	t.Run("Test N1 usage of items", func(t *testing.T) {
+		_, err := testServ.client.AddData(ctxReq, &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "login:pass", Metadata: "site"})
+		require.NoError(t, err)
+		usage, err := testServ.client.GetUsage(ctxReq, &pb.UsageRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), usage.GetItems())
+		assert.Positive(t, usage.GetBytes())
+		assert.Equal(t, int64(3), usage.GetQuotaItems())
+		assert.Equal(t, int64(25000), usage.GetQuotaBytes())
+		assert.Equal(t, int64(20000), usage.GetMaxUpload())
+	})
+
+	t.Run("Test N2 declared size above max upload", func(t *testing.T) {
+		_, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Session: "s1", Size: int64(len(file)), Data: file[:1000]})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("Test N3 stream above max upload", func(t *testing.T) {
+		_, err := uploadTest(ctxReq, testServ.client,
+			&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Data: file[:15000]},
+			&pb.DataChunk{Data: file[15000:]})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Eventually(t, func() bool { return countFiles(dir) == 0 }, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Test N4 stream above rest of quota", func(t *testing.T) {
+		val, err := uploadTest(ctxReq, testServ.client, &pb.DataChunk{Type: pb.TypeData_BINARYDATA, Data: file[:15000]})
+		require.NoError(t, err)
+		uuid = val.GetUuid()
+		usage, err := testServ.client.GetUsage(ctxReq, &pb.UsageRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), usage.GetItems())
+		assert.Greater(t, usage.GetBytes(), int64(15000))
+
+		_, err = uploadTest(ctxReq, testServ.client, &pb.DataChunk{Type: pb.TypeData_BINARYDATA, Data: file[:15000]})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("Test N5 count of items", func(t *testing.T) {
+		_, err := testServ.client.AddData(ctxReq, &pb.UserData{Type: pb.TypeData_TEXTDATA, Data: "note", Metadata: "n"})
+		require.NoError(t, err)
+		_, err = testServ.client.AddData(ctxReq, &pb.UserData{Type: pb.TypeData_TEXTDATA, Data: "note", Metadata: "n"})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("Test N6 delete frees quota", func(t *testing.T) {
+		_, err := testServ.client.DeleteData(ctxReq, &pb.DownloadRequest{Uuid: uuid})
+		require.NoError(t, err)
+		_, err = uploadTest(ctxReq, testServ.client, &pb.DataChunk{Type: pb.TypeData_BINARYDATA, Data: file[:15000]})
+		require.NoError(t, err)
+	})
+}
+
+func TestExpiry(t *testing.T) {
+	dir := t.TempDir() + string(os.PathSeparator)
+	testServ := newTestServer(func(c *config.Config) {
+		c.FilePath = dir
+	})
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	md := metadata.New(map[string]string{"authorization": login.GetToken()})
+	ctxReq := metadata.NewOutgoingContext(context.Background(), md)
+
+	file := make([]byte, 5000)
+	rand.New(rand.NewSource(7)).Read(file)
+	expiry := time.Now().Add(time.Second).Unix() + 1
+	var secret, blob, attachment, permanent string
+
+	t.Run("Test N1 expiry in past", func(t *testing.T) {
+		_, err := testServ.client.AddData(ctxReq, &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "a:b", Metadata: "old",
+			ExpiresAt: time.Now().Add(-time.Hour).Unix()})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = uploadTest(ctxReq, testServ.client, &pb.DataChunk{Type: pb.TypeData_BINARYDATA, Data: file,
+			ExpiresAt: time.Now().Add(-time.Hour).Unix()})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Test N2 items before expiry", func(t *testing.T) {
+		val, err := testServ.client.AddData(ctxReq, &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "ci:token", Metadata: "ci secret",
+			ExpiresAt: expiry})
+		require.NoError(t, err)
+		secret = val.GetUuid()
+		val, err = uploadTest(ctxReq, testServ.client, &pb.DataChunk{Type: pb.TypeData_BINARYDATA, Data: file, Metadata: "ci file",
+			ExpiresAt: expiry})
+		require.NoError(t, err)
+		blob = val.GetUuid()
+		val, err = uploadTest(ctxReq, testServ.client, &pb.DataChunk{Type: pb.TypeData_BINARYDATA, Data: file, Parent: secret})
+		require.NoError(t, err)
+		attachment = val.GetUuid()
+		val, err = testServ.client.AddData(ctxReq, &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "a:b", Metadata: "ci permanent"})
+		require.NoError(t, err)
+		permanent = val.GetUuid()
+
+		data, err := testServ.client.GetData(ctxReq, &pb.DownloadRequest{Uuid: secret})
+		require.NoError(t, err)
+		assert.Equal(t, expiry, data.GetExpiresAt())
+		require.Len(t, data.GetAttachments(), 1)
+		chunks, _, err := downloadTest(ctxReq, testServ.client, &pb.DownloadRequest{Uuid: attachment})
+		require.NoError(t, err)
+		assert.Equal(t, expiry, chunks[0].GetExpiresAt())
+		assert.Equal(t, 2, countFiles(dir))
+	})
+
+	time.Sleep(time.Until(time.Unix(expiry, 0)))
+
+	t.Run("Test N3 expired items are invisible", func(t *testing.T) {
+		_, err := testServ.client.GetData(ctxReq, &pb.DownloadRequest{Uuid: secret})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		for _, uuid := range []string{blob, attachment} {
+			_, _, err = downloadTest(ctxReq, testServ.client, &pb.DownloadRequest{Uuid: uuid})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		}
+		stream, err := testServ.client.Search(ctxReq, &pb.SearchRequest{Query: "ci"})
+		require.NoError(t, err)
+		res, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, permanent, res.GetUuid())
+		_, err = stream.Recv()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("Test N4 sweeper deletes expired items and blobs", func(t *testing.T) {
+		n, err := testServ.st.SweepExpired(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 3, n)
+		assert.Zero(t, countFiles(dir))
+		_, err = testServ.client.GetData(ctxReq, &pb.DownloadRequest{Uuid: permanent})
+		require.NoError(t, err)
+	})
+}
+
+func TestRotation(t *testing.T) {
+	testServ := newTestServer()
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	md := metadata.New(map[string]string{"authorization": login.GetToken()})
+	ctxReq := metadata.NewOutgoingContext(context.Background(), md)
+
+	var uuids []string
+	for _, meta := range []string{"db", "ci", "mail"} {
+		val, err := testServ.client.AddData(ctxReq, &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "a:b", Metadata: meta})
+		require.NoError(t, err)
+		uuids = append(uuids, val.GetUuid())
+	}
+	note, err := testServ.client.AddData(ctxReq, &pb.UserData{Type: pb.TypeData_TEXTDATA, Data: "text", Metadata: "note"})
+	require.NoError(t, err)
+
+	due := func(days int) []*pb.DueItem {
+		resp, err := testServ.client.DueRotation(ctxReq, &pb.DueRequest{At: time.Now().Add(time.Duration(days) * 24 * time.Hour).Unix()})
+		require.NoError(t, err)
+		return resp.GetItems()
+	}
+
+	t.Run("Test N1 no policy nothing due", func(t *testing.T) {
+		assert.Empty(t, due(1000))
+	})
+
+	t.Run("Test N2 policy of item and default of user", func(t *testing.T) {
+		_, err := testServ.client.SetRotation(ctxReq, &pb.RotationRequest{Uuid: uuids[0], Days: 30})
+		require.NoError(t, err)
+		_, err = testServ.client.SetRotation(ctxReq, &pb.RotationRequest{Days: 90})
+		require.NoError(t, err)
+
+		assert.Empty(t, due(0))
+		items := due(31)
+		require.Len(t, items, 1)
+		assert.Equal(t, uuids[0], items[0].GetUuid())
+		assert.Equal(t, "db", items[0].GetMetadata())
+		assert.Equal(t, int32(30), items[0].GetDays())
+		assert.Len(t, due(91), 3)
+	})
+
+	t.Run("Test N3 changed password restarts period", func(t *testing.T) {
+		_, err := testServ.client.UpdateData(ctxReq, &pb.UpdateRequest{Uuid: uuids[1], Data: "a:new"})
+		require.NoError(t, err)
+		data, err := testServ.client.GetData(ctxReq, &pb.DownloadRequest{Uuid: uuids[1]})
+		require.NoError(t, err)
+		assert.Equal(t, "a:new", data.GetData())
+		assert.Equal(t, "ci", data.GetMetadata())
+
+		items := due(91)
+		require.Len(t, items, 3)
+		assert.Equal(t, uuids[0], items[0].GetUuid())
+		assert.Equal(t, uuids[1], items[2].GetUuid())
+		assert.GreaterOrEqual(t, items[2].GetChangedAt(), items[1].GetChangedAt())
+	})
+
+	t.Run("Test N4 policy of not login item", func(t *testing.T) {
+		_, err := testServ.client.SetRotation(ctxReq, &pb.RotationRequest{Uuid: note.GetUuid(), Days: 30})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = testServ.client.SetRotation(ctxReq, &pb.RotationRequest{Uuid: uuids[0], Days: -1})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Test N5 policy of collection", func(t *testing.T) {
+		org, err := testServ.client.CreateOrg(ctxReq, &pb.OrgRequest{Name: "team"})
+		require.NoError(t, err)
+		vault, err := testServ.client.CreateCollection(ctxReq, &pb.CollectionRequest{Org: org.GetId(), Name: "infra"})
+		require.NoError(t, err)
+		val, err := testServ.client.AddData(ctxReq, &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "a:b", Metadata: "vpn",
+			Collection: vault.GetUuid()})
+		require.NoError(t, err)
+
+		_, err = testServ.client.SetRotation(ctxReq, &pb.RotationRequest{Uuid: vault.GetUuid(), Days: 10})
+		require.NoError(t, err)
+		items := due(11)
+		require.Len(t, items, 1)
+		assert.Equal(t, val.GetUuid(), items[0].GetUuid())
+		assert.Equal(t, int32(10), items[0].GetDays())
+
+		login2, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user2", Password: "abcd"})
+		require.NoError(t, err)
+		ctxOther := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"authorization": login2.GetToken()}))
+		_, err = testServ.client.SetRotation(ctxOther, &pb.RotationRequest{Uuid: vault.GetUuid(), Days: 1})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestSharing(t *testing.T) {
+	testServ := newTestServer()
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	ctxUser := func(name string) context.Context {
+		login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: name, Password: "abcd"})
+		require.NoError(t, err)
+		md := metadata.New(map[string]string{"authorization": login.GetToken()})
+		return metadata.NewOutgoingContext(context.Background(), md)
+	}
+	ctxOwner := ctxUser("owner")
+	ctxColleague := ctxUser("colleague")
+
+	prv, pub, err := cryptocerts.GenerateKey()
+	require.NoError(t, err)
+	public, err := cryptocerts.EncodePublicKey(pub)
+	require.NoError(t, err)
+	_, err = testServ.client.SetKeys(ctxColleague, &pb.SetKeysRequest{PublicKey: public, PrivateKey: "wrapped"})
+	require.NoError(t, err)
+
+	item, err := testServ.client.AddData(ctxOwner, &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "db:secret", Metadata: "database"})
+	require.NoError(t, err)
+	file, _ := generateTest(5000)
+	att, err := uploadTest(ctxOwner, testServ.client,
+		&pb.DataChunk{Type: pb.TypeData_BINARYDATA, Metadata: "dump.sql", Parent: item.GetUuid(), Data: file})
+	require.NoError(t, err)
+
+	t.Run("Test N1 not shared item", func(t *testing.T) {
+		_, err := testServ.client.GetData(ctxColleague, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = testServ.client.GetSealedData(ctxColleague, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = testServ.client.ShareItem(ctxColleague, &pb.ShareRequest{Uuid: item.GetUuid(), Recipient: "owner"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Test N2 recipient without key", func(t *testing.T) {
+		ctxUser("nokey")
+		_, err := testServ.client.ShareItem(ctxOwner, &pb.ShareRequest{Uuid: item.GetUuid(), Recipient: "nokey"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		_, err = testServ.client.ShareItem(ctxOwner, &pb.ShareRequest{Uuid: item.GetUuid(), Recipient: "nobody"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Test N3 read-only share", func(t *testing.T) {
+		share, err := testServ.client.ShareItem(ctxOwner, &pb.ShareRequest{Uuid: item.GetUuid(), Recipient: "colleague",
+			Permission: pb.Permission_READ})
+		require.NoError(t, err)
+		key, err := aescoder.UnwrapFor(prv, share.GetWrappedKey())
+		require.NoError(t, err)
+		sealed, err := testServ.client.GetSealedData(ctxColleague, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		require.NoError(t, err)
+		plain, err := key.OpenOne(sealed.GetData())
+		require.NoError(t, err)
+		assert.Equal(t, "db:secret", string(plain))
+		_, err = testServ.client.GetSealedData(ctxColleague, &pb.DownloadRequest{Uuid: att.GetUuid()})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		val, err := testServ.client.GetData(ctxColleague, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		require.NoError(t, err)
+		assert.Equal(t, "db:secret", val.GetData())
+		require.Len(t, val.GetAttachments(), 1)
+		_, data, err := downloadTest(ctxColleague, testServ.client, &pb.DownloadRequest{Uuid: att.GetUuid()})
+		require.NoError(t, err)
+		assert.Equal(t, file, data)
+
+		_, err = testServ.client.UpdateData(ctxColleague, &pb.UpdateRequest{Uuid: item.GetUuid(), Data: "db:changed"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = testServ.client.DeleteData(ctxColleague, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		assert.Error(t, err)
+	})
+
+	t.Run("Test N4 read-write share", func(t *testing.T) {
+		share, err := testServ.client.ShareItem(ctxOwner, &pb.ShareRequest{Uuid: item.GetUuid(), Recipient: "colleague",
+			Permission: pb.Permission_READWRITE})
+		require.NoError(t, err)
+		_, err = testServ.client.UpdateData(ctxColleague, &pb.UpdateRequest{Uuid: item.GetUuid(), Data: "db:changed"})
+		require.NoError(t, err)
+		val, err := testServ.client.GetData(ctxOwner, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		require.NoError(t, err)
+		assert.Equal(t, "db:changed", val.GetData())
+
+		// item key is kept on update, key wrapped for share opens changed item
+		key, err := aescoder.UnwrapFor(prv, share.GetWrappedKey())
+		require.NoError(t, err)
+		sealed, err := testServ.client.GetSealedData(ctxColleague, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		require.NoError(t, err)
+		plain, err := key.OpenOne(sealed.GetData())
+		require.NoError(t, err)
+		assert.Equal(t, "db:changed", string(plain))
+		assert.Equal(t, "database", val.GetMetadata())
+	})
+
+	t.Run("Test N5 SharedWithMe", func(t *testing.T) {
+		resp, err := testServ.client.SharedWithMe(ctxColleague, &pb.SharedRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.GetItems(), 1)
+		assert.Equal(t, item.GetUuid(), resp.GetItems()[0].GetUuid())
+		assert.Equal(t, "owner", resp.GetItems()[0].GetOwner())
+		assert.Equal(t, "database", resp.GetItems()[0].GetMetadata())
+		assert.Equal(t, pb.Permission_READWRITE, resp.GetItems()[0].GetPermission())
+
+		resp, err = testServ.client.SharedWithMe(ctxOwner, &pb.SharedRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, resp.GetItems())
+	})
+
+	t.Run("Test N6 Unshare revokes access", func(t *testing.T) {
+		_, err := testServ.client.Unshare(ctxOwner, &pb.UnshareRequest{Uuid: item.GetUuid(), Recipient: "colleague"})
+		require.NoError(t, err)
+		_, err = testServ.client.GetData(ctxColleague, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, _, err = downloadTest(ctxColleague, testServ.client, &pb.DownloadRequest{Uuid: att.GetUuid()})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Test N7 deleted item is not shared", func(t *testing.T) {
+		_, err := testServ.client.ShareItem(ctxOwner, &pb.ShareRequest{Uuid: item.GetUuid(), Recipient: "colleague"})
+		require.NoError(t, err)
+		_, err = testServ.client.DeleteData(ctxOwner, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		require.NoError(t, err)
+		_, err = testServ.client.Unshare(ctxColleague, &pb.UnshareRequest{Uuid: item.GetUuid(), Recipient: "colleague"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestOrganizations(t *testing.T) {
+	testServ := newTestServer()
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	keys := make(map[string]*rsa.PrivateKey)
+	ctxUser := func(name string) context.Context {
+		login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: name, Password: "abcd"})
+		require.NoError(t, err)
+		md := metadata.New(map[string]string{"authorization": login.GetToken()})
+		ctx := metadata.NewOutgoingContext(context.Background(), md)
+		prv, pub, err := cryptocerts.GenerateKey()
+		require.NoError(t, err)
+		public, err := cryptocerts.EncodePublicKey(pub)
+		require.NoError(t, err)
+		_, err = testServ.client.SetKeys(ctx, &pb.SetKeysRequest{PublicKey: public})
+		require.NoError(t, err)
+		keys[name] = prv
+		return ctx
+	}
+	ctxOwner := ctxUser("owner")
+	ctxAdmin := ctxUser("admin")
+	ctxMember := ctxUser("member")
+	ctxReader := ctxUser("reader")
+	ctxOutsider := ctxUser("outsider")
+
+	org, err := testServ.client.CreateOrg(ctxOwner, &pb.OrgRequest{Name: "team"})
+	require.NoError(t, err)
+	vault, err := testServ.client.CreateCollection(ctxOwner, &pb.CollectionRequest{Org: org.GetId(), Name: "infra"})
+	require.NoError(t, err)
+
+	collection := func(ctx context.Context) *pb.Collection {
+		resp, err := testServ.client.ListCollections(ctx, &pb.CollectionsRequest{})
+		require.NoError(t, err)
+		if len(resp.GetCollections()) == 0 {
+			return nil
+		}
+		return resp.GetCollections()[0]
+	}
+	var item *pb.ResponseAddData
+
+	t.Run("Test N1 membership by roles", func(t *testing.T) {
+		_, err := testServ.client.AddMember(ctxOwner, &pb.MemberRequest{Org: org.GetId(), User: "admin", Role: pb.Role_ADMIN})
+		require.NoError(t, err)
+		_, err = testServ.client.AddMember(ctxAdmin, &pb.MemberRequest{Org: org.GetId(), User: "member", Role: pb.Role_MEMBER})
+		require.NoError(t, err)
+		_, err = testServ.client.AddMember(ctxAdmin, &pb.MemberRequest{Org: org.GetId(), User: "reader", Role: pb.Role_READONLY})
+		require.NoError(t, err)
+
+		_, err = testServ.client.AddMember(ctxAdmin, &pb.MemberRequest{Org: org.GetId(), User: "outsider", Role: pb.Role_OWNER})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = testServ.client.AddMember(ctxMember, &pb.MemberRequest{Org: org.GetId(), User: "outsider", Role: pb.Role_READONLY})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = testServ.client.ListMembers(ctxOutsider, &pb.MembersRequest{Org: org.GetId()})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		members, err := testServ.client.ListMembers(ctxReader, &pb.MembersRequest{Org: org.GetId()})
+		require.NoError(t, err)
+		assert.Len(t, members.GetMembers(), 4)
+	})
+
+	t.Run("Test N2 collection key wrapped for each member", func(t *testing.T) {
+		for name, ctx := range map[string]context.Context{"owner": ctxOwner, "member": ctxMember, "reader": ctxReader} {
+			c := collection(ctx)
+			require.NotNil(t, c)
+			assert.Equal(t, vault.GetUuid(), c.GetUuid())
+			assert.Equal(t, int32(3), c.GetVersion())
+			_, err := aescoder.UnwrapFor(keys[name], c.GetWrappedKey())
+			assert.NoError(t, err)
+		}
+		assert.Nil(t, collection(ctxOutsider))
+	})
+
+	t.Run("Test N3 items of collection by roles", func(t *testing.T) {
+		var err error
+		item, err = testServ.client.AddData(ctxMember, &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "db:secret", Metadata: "database",
+			Collection: vault.GetUuid()})
+		require.NoError(t, err)
+		_, err = testServ.client.AddData(ctxReader, &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "x", Collection: vault.GetUuid()})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = testServ.client.AddData(ctxOutsider, &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "x", Collection: vault.GetUuid()})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		val, err := testServ.client.GetData(ctxReader, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		require.NoError(t, err)
+		assert.Equal(t, "db:secret", val.GetData())
+		_, err = testServ.client.GetData(ctxOutsider, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		_, err = testServ.client.UpdateData(ctxReader, &pb.UpdateRequest{Uuid: item.GetUuid(), Data: "db:changed"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = testServ.client.UpdateData(ctxOwner, &pb.UpdateRequest{Uuid: item.GetUuid(), Data: "db:changed"})
+		require.NoError(t, err)
+
+		items, err := testServ.client.CollectionItems(ctxReader, &pb.CollectionItemsRequest{Uuid: vault.GetUuid()})
+		require.NoError(t, err)
+		require.Len(t, items.GetItems(), 1)
+		assert.Equal(t, "member", items.GetItems()[0].GetOwner())
+		assert.Equal(t, "database", items.GetItems()[0].GetMetadata())
+	})
+
+	t.Run("Test N4 removed member loses access, key is rotated", func(t *testing.T) {
+		_, err := testServ.client.RemoveMember(ctxAdmin, &pb.MemberRequest{Org: org.GetId(), User: "owner"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = testServ.client.RemoveMember(ctxOwner, &pb.MemberRequest{Org: org.GetId(), User: "owner"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		user, err := testServ.st.LoginUser(context.Background(), "member", "abcd")
+		require.NoError(t, err)
+		itemKey := func() string {
+			list, err := testServ.st.ExportAccount(context.Background(), user.Id)
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			return list[0].EnKey
+		}
+		held := itemKey()
+
+		_, err = testServ.client.RemoveMember(ctxAdmin, &pb.MemberRequest{Org: org.GetId(), User: "member"})
+		require.NoError(t, err)
+		assert.NotEqual(t, held, itemKey())
+		_, err = testServ.client.GetData(ctxMember, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Nil(t, collection(ctxMember))
+		assert.Equal(t, int32(4), collection(ctxReader).GetVersion())
+
+		val, err := testServ.client.GetData(ctxReader, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		require.NoError(t, err)
+		assert.Equal(t, "db:changed", val.GetData())
+	})
+
+	t.Run("Test N5 admin deletes item of collection", func(t *testing.T) {
+		_, err := testServ.client.DeleteData(ctxReader, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		assert.Error(t, err)
+		_, err = testServ.client.DeleteData(ctxAdmin, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		require.NoError(t, err)
+		items, err := testServ.client.CollectionItems(ctxReader, &pb.CollectionItemsRequest{Uuid: vault.GetUuid()})
+		require.NoError(t, err)
+		assert.Empty(t, items.GetItems())
+	})
+}
+
+func TestSecretShare(t *testing.T) {
+	testServ := newTestServer()
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	md := metadata.New(map[string]string{"authorization": login.GetToken()})
+	ctxReq := metadata.NewOutgoingContext(context.Background(), md)
+
+	key, err := random.GenerateRandom(32)
+	require.NoError(t, err)
+	sealed, err := aescoder.Seal(key, []byte("db:secret"))
+	require.NoError(t, err)
+	ciphertext := hex.EncodeToString(sealed)
+
+	t.Run("Test N1 destroyed after first view", func(t *testing.T) {
+		secret, err := testServ.client.CreateSecretShare(ctxReq, &pb.SecretRequest{Ciphertext: ciphertext})
+		require.NoError(t, err)
+
+		resp, err := testServ.client.RedeemShare(context.Background(), &pb.RedeemRequest{Id: secret.GetId()})
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), resp.GetViewsLeft())
+		c, err := hex.DecodeString(resp.GetCiphertext())
+		require.NoError(t, err)
+		plain, err := aescoder.Open(key, c)
+		require.NoError(t, err)
+		assert.Equal(t, "db:secret", string(plain))
+
+		_, err = testServ.client.RedeemShare(context.Background(), &pb.RedeemRequest{Id: secret.GetId()})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Test N2 view limit", func(t *testing.T) {
+		secret, err := testServ.client.CreateSecretShare(ctxReq, &pb.SecretRequest{Ciphertext: ciphertext, MaxViews: 2})
+		require.NoError(t, err)
+		for _, left := range []int32{1, 0} {
+			resp, err := testServ.client.RedeemShare(context.Background(), &pb.RedeemRequest{Id: secret.GetId()})
+			require.NoError(t, err)
+			assert.Equal(t, left, resp.GetViewsLeft())
+		}
+		_, err = testServ.client.RedeemShare(context.Background(), &pb.RedeemRequest{Id: secret.GetId()})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Test N3 bad requests", func(t *testing.T) {
+		_, err := testServ.client.CreateSecretShare(context.Background(), &pb.SecretRequest{Ciphertext: ciphertext})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = testServ.client.CreateSecretShare(ctxReq, &pb.SecretRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = testServ.client.CreateSecretShare(ctxReq, &pb.SecretRequest{Ciphertext: ciphertext, MaxViews: -1})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = testServ.client.CreateSecretShare(ctxReq, &pb.SecretRequest{Ciphertext: ciphertext, ExpiresAt: time.Now().Add(-time.Hour).Unix()})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Test N4 expired secret", func(t *testing.T) {
+		shortServ := newTestServer(func(c *config.Config) { c.SecretTTL = 50 * time.Millisecond })
+		defer func() {
+			shortServ.conn.Close()
+			shortServ.grpcServer.Stop()
+		}()
+		login, err := shortServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+		require.NoError(t, err)
+		ctxShort := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"authorization": login.GetToken()}))
+
+		secret, err := shortServ.client.CreateSecretShare(ctxShort, &pb.SecretRequest{Ciphertext: ciphertext,
+			ExpiresAt: time.Now().Add(time.Hour).Unix()})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, secret.GetExpiresAt(), time.Now().Add(time.Second).Unix())
+		time.Sleep(100 * time.Millisecond)
+		_, err = shortServ.client.RedeemShare(context.Background(), &pb.RedeemRequest{Id: secret.GetId()})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestEmergencyAccess(t *testing.T) {
+	testServ := newTestServer(func(c *config.Config) {
+		c.EmergencyWait = 50 * time.Millisecond
+		c.EmergencyGrant = time.Second
+	})
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	ctxUser := func(name string) context.Context {
+		login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: name, Password: "abcd"})
+		require.NoError(t, err)
+		md := metadata.New(map[string]string{"authorization": login.GetToken()})
+		return metadata.NewOutgoingContext(context.Background(), md)
+	}
+	ctxOwner := ctxUser("owner")
+	ctxContact := ctxUser("contact")
+
+	prv, pub, err := cryptocerts.GenerateKey()
+	require.NoError(t, err)
+	public, err := cryptocerts.EncodePublicKey(pub)
+	require.NoError(t, err)
+	_, err = testServ.client.SetKeys(ctxContact, &pb.SetKeysRequest{PublicKey: public, PrivateKey: "wrapped"})
+	require.NoError(t, err)
+
+	item, err := testServ.client.AddData(ctxOwner, &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "db:secret", Metadata: "database"})
+	require.NoError(t, err)
+
+	t.Run("Test N1 bad invites", func(t *testing.T) {
+		ctxUser("nokey")
+		_, err := testServ.client.InviteEmergency(ctxOwner, &pb.EmergencyInvite{Contact: "nokey"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		_, err = testServ.client.InviteEmergency(ctxOwner, &pb.EmergencyInvite{Contact: "nobody"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = testServ.client.InviteEmergency(ctxContact, &pb.EmergencyInvite{Contact: "contact"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	invite, err := testServ.client.InviteEmergency(ctxOwner, &pb.EmergencyInvite{Contact: "contact", WaitSeconds: 3600})
+	require.NoError(t, err)
+	step := &pb.EmergencyRequest{Id: invite.GetId()}
+
+	t.Run("Test N2 no access before approval", func(t *testing.T) {
+		assert.Equal(t, pb.EmergencyState_INVITED, invite.GetState())
+		_, err := testServ.client.EmergencyAccess(ctxContact, step)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		_, err = testServ.client.GetData(ctxContact, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = testServ.client.ApproveEmergency(ctxOwner, &pb.EmergencyRequest{Id: invite.GetId(), Approve: true})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		_, err = testServ.client.RequestEmergency(ctxOwner, step)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Test N3 request denied by owner", func(t *testing.T) {
+		e, err := testServ.client.RequestEmergency(ctxContact, step)
+		require.NoError(t, err)
+		assert.Equal(t, pb.EmergencyState_REQUESTED, e.GetState())
+		_, err = testServ.client.ApproveEmergency(ctxContact, &pb.EmergencyRequest{Id: invite.GetId(), Approve: true})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		e, err = testServ.client.ApproveEmergency(ctxOwner, &pb.EmergencyRequest{Id: invite.GetId()})
+		require.NoError(t, err)
+		assert.Equal(t, pb.EmergencyState_DENIED, e.GetState())
+		_, err = testServ.client.EmergencyAccess(ctxContact, step)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("Test N4 request approved by owner", func(t *testing.T) {
+		_, err := testServ.client.RequestEmergency(ctxContact, step)
+		require.NoError(t, err)
+		e, err := testServ.client.ApproveEmergency(ctxOwner, &pb.EmergencyRequest{Id: invite.GetId(), Approve: true})
+		require.NoError(t, err)
+		assert.Equal(t, pb.EmergencyState_APPROVED, e.GetState())
+
+		items, err := testServ.client.EmergencyAccess(ctxContact, step)
+		require.NoError(t, err)
+		require.Len(t, items.GetItems(), 1)
+		assert.Equal(t, "database", items.GetItems()[0].GetMetadata())
+		_, err = aescoder.UnwrapFor(prv, items.GetItems()[0].GetWrappedKey())
+		assert.NoError(t, err)
+
+		val, err := testServ.client.GetData(ctxContact, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		require.NoError(t, err)
+		assert.Equal(t, "db:secret", val.GetData())
+		_, err = testServ.client.UpdateData(ctxContact, &pb.UpdateRequest{Uuid: item.GetUuid(), Data: "db:changed"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Test N5 approved access expires", func(t *testing.T) {
+		time.Sleep(1100 * time.Millisecond)
+		list, err := testServ.client.ListEmergency(ctxOwner, &pb.EmergencyListRequest{})
+		require.NoError(t, err)
+		require.Len(t, list.GetEmergency(), 1)
+		assert.Equal(t, pb.EmergencyState_EXPIRED, list.GetEmergency()[0].GetState())
+		assert.Equal(t, "contact", list.GetEmergency()[0].GetContact())
+		_, err = testServ.client.GetData(ctxContact, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Test N6 approved after waiting period", func(t *testing.T) {
+		other, err := testServ.client.InviteEmergency(ctxOwner, &pb.EmergencyInvite{Contact: "contact"})
+		require.NoError(t, err)
+		_, err = testServ.client.RequestEmergency(ctxContact, &pb.EmergencyRequest{Id: other.GetId()})
+		require.NoError(t, err)
+		time.Sleep(100 * time.Millisecond)
+
+		_, err = testServ.client.GetData(ctxContact, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		require.NoError(t, err)
+		_, err = testServ.client.ApproveEmergency(ctxOwner, &pb.EmergencyRequest{Id: other.GetId()})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		_, err = testServ.client.RevokeEmergency(ctxOwner, &pb.EmergencyRequest{Id: other.GetId()})
+		require.NoError(t, err)
+		_, err = testServ.client.GetData(ctxContact, &pb.DownloadRequest{Uuid: item.GetUuid()})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestRefreshToken(t *testing.T) {
+	testServ := newTestServer(func(c *config.Config) { c.TokenTTL = time.Second })
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	ctxToken := func(token string) context.Context {
+		return metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"authorization": token}))
+	}
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	require.NotEmpty(t, login.GetRefreshToken())
+	assert.LessOrEqual(t, login.GetExpiresAt(), time.Now().Add(time.Second).Unix())
+
+	t.Run("Test N1 expired access token", func(t *testing.T) {
+		time.Sleep(2 * time.Second)
+		_, err := testServ.client.GetUsage(ctxToken(login.GetToken()), &pb.UsageRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("Test N2 refresh token is rotated", func(t *testing.T) {
+		resp, err := testServ.client.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: login.GetRefreshToken()})
+		require.NoError(t, err)
+		assert.NotEqual(t, login.GetRefreshToken(), resp.GetRefreshToken())
+		_, err = testServ.client.GetUsage(ctxToken(resp.GetToken()), &pb.UsageRequest{})
+		assert.NoError(t, err)
+
+		_, err = testServ.client.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: login.GetRefreshToken()})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = testServ.client.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: resp.GetRefreshToken()})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Test N3 unknown refresh token", func(t *testing.T) {
+		_, err := testServ.client.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: "bad"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+func TestLogout(t *testing.T) {
+	testServ := newTestServer()
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	ctxToken := func(token string) context.Context {
+		return metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"authorization": token}))
+	}
+
+	_, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	login := func() *pb.LoginResponse {
+		resp, err := testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("Test N1 logout revokes only its session", func(t *testing.T) {
+		first, second := login(), login()
+		_, err := testServ.client.Logout(ctxToken(first.GetToken()), &pb.LogoutRequest{RefreshToken: first.GetRefreshToken()})
+		require.NoError(t, err)
+
+		_, err = testServ.client.GetUsage(ctxToken(first.GetToken()), &pb.UsageRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = testServ.client.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: first.GetRefreshToken()})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = testServ.client.GetUsage(ctxToken(second.GetToken()), &pb.UsageRequest{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Test N2 revoke all sessions", func(t *testing.T) {
+		first, second := login(), login()
+		_, err := testServ.client.RevokeAllSessions(ctxToken(first.GetToken()), &pb.RevokeAllRequest{})
+		require.NoError(t, err)
+
+		for _, resp := range []*pb.LoginResponse{first, second} {
+			_, err = testServ.client.GetUsage(ctxToken(resp.GetToken()), &pb.UsageRequest{})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			_, err = testServ.client.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: resp.GetRefreshToken()})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		}
+		_, err = testServ.client.GetUsage(ctxToken(login().GetToken()), &pb.UsageRequest{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Test N3 concurrent change of user keeps revocation", func(t *testing.T) {
+		first := login()
+		user, err := testServ.st.LoginUser(context.Background(), "user1", "abcd")
+		require.NoError(t, err)
+		var wg sync.WaitGroup
+		for i := range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, testServ.st.SetRotation(context.Background(), user.Id, "", i))
+			}()
+		}
+		require.NoError(t, testServ.st.RevokeAll(context.Background(), user.Id))
+		wg.Wait()
+		_, err = testServ.client.GetUsage(ctxToken(first.GetToken()), &pb.UsageRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+func TestSessions(t *testing.T) {
+	testServ := newTestServer(func(c *config.Config) { c.TrustedProxies = "127.0.0.0/8" })
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	ctxToken := func(token string) context.Context {
+		return metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"authorization": token}))
+	}
+
+	_, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	ctxIP := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"X-Real-IP": "10.0.0.7"}))
+	laptop, err := testServ.client.LoginUser(ctxIP, &pb.LoginRequest{Name: "user1", Password: "abcd", Device: "laptop",
+		ClientVersion: "v1.2.0"})
+	require.NoError(t, err)
+	desktop, err := testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd", Device: "desktop"})
+	require.NoError(t, err)
+
+	var lost string
+	t.Run("Test N1 list sessions", func(t *testing.T) {
+		resp, err := testServ.client.ListSessions(ctxToken(desktop.GetToken()), &pb.SessionsRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.GetSessions(), 3)
+		for _, session := range resp.GetSessions() {
+			switch session.GetDevice() {
+			case "laptop":
+				lost = session.GetId()
+				assert.Equal(t, "v1.2.0", session.GetClientVersion())
+				assert.Equal(t, "10.0.0.7", session.GetAddress())
+				assert.False(t, session.GetCurrent())
+			case "desktop":
+				assert.True(t, session.GetCurrent())
+			}
+		}
+		require.NotEmpty(t, lost)
+	})
+
+	t.Run("Test N2 revoke session of lost device", func(t *testing.T) {
+		_, err := testServ.client.RevokeSession(ctxToken(desktop.GetToken()), &pb.RevokeSessionRequest{Id: lost})
+		require.NoError(t, err)
+
+		_, err = testServ.client.GetUsage(ctxToken(laptop.GetToken()), &pb.UsageRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = testServ.client.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: laptop.GetRefreshToken()})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = testServ.client.GetUsage(ctxToken(desktop.GetToken()), &pb.UsageRequest{})
+		assert.NoError(t, err)
+
+		_, err = testServ.client.RevokeSession(ctxToken(desktop.GetToken()), &pb.RevokeSessionRequest{Id: lost})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Test N3 session of other user", func(t *testing.T) {
+		other, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user2", Password: "abcd"})
+		require.NoError(t, err)
+		resp, err := testServ.client.ListSessions(ctxToken(desktop.GetToken()), &pb.SessionsRequest{})
+		require.NoError(t, err)
+		_, err = testServ.client.RevokeSession(ctxToken(other.GetToken()), &pb.RevokeSessionRequest{Id: resp.GetSessions()[0].GetId()})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestTwoFactor(t *testing.T) {
+	// failed codes of cases are not locking out address
+	testServ := newTestServer(func(c *config.Config) {
+		c.LoginAttempts = 10
+	})
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	ctxToken := func(token string) context.Context {
+		return metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"authorization": token}))
+	}
+
+	user, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+
+	var secret string
+	var step int64
+	var recovery []string
+	t.Run("Test N1 enroll and verify", func(t *testing.T) {
+		_, err := testServ.client.VerifyTOTP(ctxToken(user.GetToken()), &pb.VerifyTOTPRequest{Code: "123456"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		resp, err := testServ.client.EnrollTOTP(ctxToken(user.GetToken()), &pb.EnrollRequest{})
+		require.NoError(t, err)
+		secret = resp.GetSecret()
+		assert.Contains(t, resp.GetUri(), "otpauth://totp/")
+
+		step = totp.Step(time.Now())
+		code, err := totp.CodeAt(secret, step)
+		require.NoError(t, err)
+		_, err = testServ.client.VerifyTOTP(ctxToken(user.GetToken()), &pb.VerifyTOTPRequest{Code: "x" + code})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		verified, err := testServ.client.VerifyTOTP(ctxToken(user.GetToken()), &pb.VerifyTOTPRequest{Code: code})
+		require.NoError(t, err)
+		recovery = verified.GetRecoveryCodes()
+		assert.Len(t, recovery, 10)
+	})
+
+	t.Run("Test N2 login waits for code", func(t *testing.T) {
+		resp, err := testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+		require.NoError(t, err)
+		assert.Empty(t, resp.GetToken())
+		require.NotEmpty(t, resp.GetChallenge())
+
+		_, err = testServ.client.GetUsage(ctxToken(resp.GetChallenge()), &pb.UsageRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		_, err = testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Challenge: "bad", Otp: "123456"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		// code of verification is used, next code is valid once
+		used, err := totp.CodeAt(secret, step)
+		require.NoError(t, err)
+		_, err = testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Challenge: resp.GetChallenge(), Otp: used})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		next, err := totp.CodeAt(secret, step+1)
+		require.NoError(t, err)
+		login, err := testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Challenge: resp.GetChallenge(), Otp: next})
+		require.NoError(t, err)
+		_, err = testServ.client.GetUsage(ctxToken(login.GetToken()), &pb.UsageRequest{})
+		assert.NoError(t, err)
+
+		_, err = testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd", Otp: next})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("Test N3 recovery code is used once", func(t *testing.T) {
+		login, err := testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd", Otp: recovery[0]})
+		require.NoError(t, err)
+		assert.NotEmpty(t, login.GetToken())
+
+		_, err = testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd", Otp: recovery[0]})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("Test N4 enrollment again requires code or password", func(t *testing.T) {
+		used, err := totp.CodeAt(secret, step+1)
+		require.NoError(t, err)
+		for _, req := range []*pb.EnrollRequest{{}, {Password: "bad"}, {Code: used}} {
+			_, err = testServ.client.EnrollTOTP(ctxToken(user.GetToken()), req)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		}
+
+		_, err = testServ.client.EnrollTOTP(ctxToken(user.GetToken()), &pb.EnrollRequest{Password: "abcd"})
+		assert.NoError(t, err)
+
+		other, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user2", Password: "abcd"})
+		require.NoError(t, err)
+		resp, err := testServ.client.EnrollTOTP(ctxToken(other.GetToken()), &pb.EnrollRequest{})
+		require.NoError(t, err)
+		now := totp.Step(time.Now())
+		prev, err := totp.CodeAt(resp.GetSecret(), now-1)
+		require.NoError(t, err)
+		_, err = testServ.client.VerifyTOTP(ctxToken(other.GetToken()), &pb.VerifyTOTPRequest{Code: prev})
+		require.NoError(t, err)
+		code, err := totp.CodeAt(resp.GetSecret(), now)
+		require.NoError(t, err)
+		_, err = testServ.client.EnrollTOTP(ctxToken(other.GetToken()), &pb.EnrollRequest{Code: code})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Test N5 challenge issued before revocation is not valid", func(t *testing.T) {
+		resp, err := testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.GetChallenge())
+		_, err = testServ.client.RevokeAllSessions(ctxToken(user.GetToken()), &pb.RevokeAllRequest{})
+		require.NoError(t, err)
+		_, err = testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Challenge: resp.GetChallenge(), Otp: recovery[1]})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		resp, err = testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+		require.NoError(t, err)
+		_, err = testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Challenge: resp.GetChallenge(), Otp: recovery[1]})
+		assert.NoError(t, err)
+	})
+}
+
+func TestLoginLockout(t *testing.T) {
+	testServ := newTestServer(func(c *config.Config) {
+		c.LoginAttempts = 3
+		c.LoginLockout = time.Second
+	})
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	_, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+
+	t.Run("Test N1 unknown user and wrong password are same error", func(t *testing.T) {
+		_, err := testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "nobody", Password: "abcd"})
+		unknown, _ := status.FromError(err)
+		_, err = testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "bad"})
+		wrong, _ := status.FromError(err)
+		assert.Equal(t, codes.Unauthenticated, unknown.Code())
+		assert.Equal(t, unknown.Code(), wrong.Code())
+		assert.Equal(t, unknown.Message(), wrong.Message())
+	})
+
+	t.Run("Test N2 lockout with retry-after", func(t *testing.T) {
+		// third failure of address locks it
+		_, err := testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "bad"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		var header metadata.MD
+		_, err = testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"}, grpc.Header(&header))
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, []string{"1"}, header.Get(interceptor.RetryAfterMD))
+
+		_, err = testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user2", Password: "abcd"})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("Test N3 lockout doubles", func(t *testing.T) {
+		time.Sleep(time.Second)
+		_, err := testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+		require.NoError(t, err)
+
+		for range 3 {
+			_, err = testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "bad"})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		}
+		var header metadata.MD
+		_, err = testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"}, grpc.Header(&header))
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, []string{"2"}, header.Get(interceptor.RetryAfterMD))
+	})
+}
+
+func TestSecondFactorLockout(t *testing.T) {
+	testServ := newTestServer(func(c *config.Config) {
+		c.LoginAttempts = 3
+		c.LoginLockout = time.Minute
+		c.TrustedProxies = "127.0.0.1"
+	})
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	ctxIP := func(ip string) context.Context {
+		return metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"X-Real-IP": ip}))
+	}
+
+	user, err := testServ.client.RegisterUser(ctxIP("10.0.0.1"), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	ctxToken := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"authorization": user.GetToken()}))
+	enrollment, err := testServ.client.EnrollTOTP(ctxToken, &pb.EnrollRequest{})
+	require.NoError(t, err)
+	code, err := totp.CodeAt(enrollment.GetSecret(), totp.Step(time.Now()))
+	require.NoError(t, err)
+	_, err = testServ.client.VerifyTOTP(ctxToken, &pb.VerifyTOTPRequest{Code: code})
+	require.NoError(t, err)
+
+	t.Run("Test N1 bad codes from different addresses lock account", func(t *testing.T) {
+		resp, err := testServ.client.LoginUser(ctxIP("10.0.0.1"), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.GetChallenge())
+
+		for _, ip := range []string{"10.0.0.2", "10.0.0.3", "10.0.0.4"} {
+			_, err = testServ.client.LoginUser(ctxIP(ip), &pb.LoginRequest{Challenge: resp.GetChallenge(), Otp: "000000"})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		}
+		_, err = testServ.client.LoginUser(ctxIP("10.0.0.5"), &pb.LoginRequest{Challenge: resp.GetChallenge(), Otp: "000000"})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		_, err = testServ.client.LoginUser(ctxIP("10.0.0.6"), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("Test N2 X-Real-IP of untrusted peer is ignored", func(t *testing.T) {
+		addr := interceptor.Proxies{}.ClientAddress(peer.NewContext(
+			metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-real-ip", "10.0.0.9")),
+			&peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(192, 168, 1, 2), Port: 5000}}))
+		assert.Equal(t, "192.168.1.2", addr)
+	})
+}
+
+func TestAPITokens(t *testing.T) {
+	testServ := newTestServer()
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	ctxToken := func(token string) context.Context {
+		return metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"authorization": token}))
+	}
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	uuids := make([]string, 0, 2)
+	for _, meta := range []string{"ci deploy key", "personal bank"} {
+		val, err := testServ.client.AddData(ctxToken(login.GetToken()), &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "a:b", Metadata: meta})
+		require.NoError(t, err)
+		uuids = append(uuids, val.GetUuid())
+	}
+
+	searchUuids := func(token string) []string {
+		stream, err := testServ.client.Search(ctxToken(token), &pb.SearchRequest{Query: "ci bank"})
+		require.NoError(t, err)
+		var res []string
+		for {
+			r, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			res = append(res, r.GetUuid())
+		}
+		return res
+	}
+
+	var ci string
+	t.Run("Test N1 read-only token of one item", func(t *testing.T) {
+		resp, err := testServ.client.CreateAPIToken(ctxToken(login.GetToken()), &pb.APITokenRequest{Name: "ci",
+			Permission: pb.Permission_READ, Items: []string{uuids[0]}, ExpiresIn: 3600})
+		require.NoError(t, err)
+		ci = resp.GetToken()
+		assert.True(t, strings.HasPrefix(ci, service.APITokenPrefix))
+
+		data, err := testServ.client.GetData(ctxToken(ci), &pb.DownloadRequest{Uuid: uuids[0]})
+		require.NoError(t, err)
+		assert.Equal(t, "ci deploy key", data.GetMetadata())
+		_, err = testServ.client.GetData(ctxToken(ci), &pb.DownloadRequest{Uuid: uuids[1]})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Equal(t, []string{uuids[0]}, searchUuids(ci))
+		assert.Len(t, searchUuids(login.GetToken()), 2)
+
+		_, err = testServ.client.AddData(ctxToken(ci), &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "a:b", Metadata: "x"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = testServ.client.ListSessions(ctxToken(ci), &pb.SessionsRequest{})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = testServ.client.CreateAPIToken(ctxToken(ci), &pb.APITokenRequest{Name: "more", Permission: pb.Permission_READWRITE})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Test N2 read-write token of all items", func(t *testing.T) {
+		resp, err := testServ.client.CreateAPIToken(ctxToken(login.GetToken()), &pb.APITokenRequest{Name: "sync",
+			Permission: pb.Permission_READWRITE})
+		require.NoError(t, err)
+		_, err = testServ.client.AddData(ctxToken(resp.GetToken()), &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "a:b", Metadata: "new"})
+		require.NoError(t, err)
+		_, err = testServ.client.DeleteData(ctxToken(resp.GetToken()), &pb.DownloadRequest{Uuid: uuids[1]})
+		require.NoError(t, err)
+
+		_, err = testServ.client.CreateAPIToken(ctxToken(login.GetToken()), &pb.APITokenRequest{Name: "sync"})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		_, err = testServ.client.CreateAPIToken(ctxToken(login.GetToken()), &pb.APITokenRequest{Name: "bad", Items: []string{"unknown"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("Test N3 list and revoke", func(t *testing.T) {
+		resp, err := testServ.client.ListAPITokens(ctxToken(login.GetToken()), &pb.APITokensRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.GetTokens(), 2)
+		assert.Equal(t, "ci", resp.GetTokens()[0].GetName())
+		assert.NotZero(t, resp.GetTokens()[0].GetLastUsed())
+		assert.NotZero(t, resp.GetTokens()[0].GetExpiresAt())
+		assert.Zero(t, resp.GetTokens()[1].GetExpiresAt())
+
+		_, err = testServ.client.RevokeAPIToken(ctxToken(login.GetToken()), &pb.RevokeAPITokenRequest{Name: "ci"})
+		require.NoError(t, err)
+		_, err = testServ.client.GetData(ctxToken(ci), &pb.DownloadRequest{Uuid: uuids[0]})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = testServ.client.RevokeAPIToken(ctxToken(login.GetToken()), &pb.RevokeAPITokenRequest{Name: "ci"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Test N4 token of tags", func(t *testing.T) {
+		sealed, err := testServ.client.AddData(ctxToken(login.GetToken()), &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "a:b",
+			Metadata: "sealed", Tokens: []string{"blinddeploy"}})
+		require.NoError(t, err)
+		resp, err := testServ.client.CreateAPIToken(ctxToken(login.GetToken()), &pb.APITokenRequest{Name: "deploy",
+			Permission: pb.Permission_READWRITE, Tags: []string{" Deploy", "blinddeploy"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"deploy", "blinddeploy"}, resp.GetInfo().GetTags())
+		deploy := resp.GetToken()
+
+		_, err = testServ.client.GetData(ctxToken(deploy), &pb.DownloadRequest{Uuid: uuids[0]})
+		require.NoError(t, err)
+		_, err = testServ.client.GetData(ctxToken(deploy), &pb.DownloadRequest{Uuid: sealed.GetUuid()})
+		require.NoError(t, err)
+		assert.Equal(t, []string{uuids[0]}, searchUuids(deploy))
+
+		_, err = testServ.client.AddData(ctxToken(deploy), &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "a:b", Metadata: "deploy hook"})
+		assert.NoError(t, err)
+		_, err = testServ.client.AddData(ctxToken(deploy), &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "a:b", Metadata: "bank"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		_, err = testServ.client.CreateAPIToken(ctxToken(login.GetToken()), &pb.APITokenRequest{Name: "empty", Tags: []string{" "}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Test N5 rotation policy out of scope", func(t *testing.T) {
+		org, err := testServ.client.CreateOrg(ctxToken(login.GetToken()), &pb.OrgRequest{Name: "team"})
+		require.NoError(t, err)
+		vault, err := testServ.client.CreateCollection(ctxToken(login.GetToken()), &pb.CollectionRequest{Org: org.GetId(), Name: "infra"})
+		require.NoError(t, err)
+		resp, err := testServ.client.CreateAPIToken(ctxToken(login.GetToken()), &pb.APITokenRequest{Name: "rotation",
+			Permission: pb.Permission_READWRITE, Tags: []string{"deploy"}})
+		require.NoError(t, err)
+
+		_, err = testServ.client.SetRotation(ctxToken(resp.GetToken()), &pb.RotationRequest{Days: 30})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = testServ.client.SetRotation(ctxToken(resp.GetToken()), &pb.RotationRequest{Uuid: vault.GetUuid(), Days: 30})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = testServ.client.SetRotation(ctxToken(login.GetToken()), &pb.RotationRequest{Uuid: vault.GetUuid(), Days: 30})
+		assert.NoError(t, err)
+	})
+}
+
+func TestChangePassword(t *testing.T) {
+	testServ := newTestServer(func(c *config.Config) {
+		c.LoginAttempts = 10
+	})
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	ctxToken := func(token string) context.Context {
+		return metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"authorization": token}))
+	}
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd", VaultKey: "vault1"})
+	require.NoError(t, err)
+
+	t.Run("Test N1 change password", func(t *testing.T) {
+		_, err := testServ.client.ChangePassword(ctxToken(login.GetToken()), &pb.ChangePasswordRequest{OldPassword: "bad",
+			Password: "efgh", VaultKey: "vault2"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = testServ.client.ChangePassword(ctxToken(login.GetToken()), &pb.ChangePasswordRequest{OldPassword: "abcd",
+			Password: "efgh"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		changed, err := testServ.client.ChangePassword(ctxToken(login.GetToken()), &pb.ChangePasswordRequest{OldPassword: "abcd",
+			Password: "efgh", VaultKey: "vault2"})
+		require.NoError(t, err)
+		_, err = testServ.client.GetUsage(ctxToken(changed.GetToken()), &pb.UsageRequest{})
+		assert.NoError(t, err)
+		_, err = testServ.client.GetUsage(ctxToken(login.GetToken()), &pb.UsageRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		_, err = testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		relogin, err := testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "efgh"})
+		require.NoError(t, err)
+		assert.Equal(t, "vault2", relogin.GetVaultKey())
+		login = relogin
+	})
+
+	t.Run("Test N2 recovery key", func(t *testing.T) {
+		_, err := testServ.client.OpenRecovery(context.Background(), &pb.RecoveryRequest{Name: "user1", Recovery: "secret"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		_, err = testServ.client.SetRecovery(ctxToken(login.GetToken()), &pb.SetRecoveryRequest{Recovery: "secret",
+			VaultKey: "vault-recovery"})
+		require.NoError(t, err)
+
+		_, err = testServ.client.OpenRecovery(context.Background(), &pb.RecoveryRequest{Name: "user1", Recovery: "wrong"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		keys, err := testServ.client.OpenRecovery(context.Background(), &pb.RecoveryRequest{Name: "user1", Recovery: "secret"})
+		require.NoError(t, err)
+		assert.Equal(t, "vault-recovery", keys.GetVaultKey())
+
+		recovered, err := testServ.client.RecoverAccount(context.Background(), &pb.RecoveryRequest{Name: "user1", Recovery: "secret",
+			Password: "ijkl", VaultKey: "vault3"})
+		require.NoError(t, err)
+		assert.Equal(t, "vault3", recovered.GetVaultKey())
+		_, err = testServ.client.GetUsage(ctxToken(login.GetToken()), &pb.UsageRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		relogin, err := testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "ijkl"})
+		require.NoError(t, err)
+		assert.Equal(t, "vault3", relogin.GetVaultKey())
+		login = relogin
+	})
+
+	t.Run("Test N3 recovery key with second factor requires code", func(t *testing.T) {
+		resp, err := testServ.client.EnrollTOTP(ctxToken(login.GetToken()), &pb.EnrollRequest{})
+		require.NoError(t, err)
+		code, err := totp.CodeAt(resp.GetSecret(), totp.Step(time.Now()))
+		require.NoError(t, err)
+		verified, err := testServ.client.VerifyTOTP(ctxToken(login.GetToken()), &pb.VerifyTOTPRequest{Code: code})
+		require.NoError(t, err)
+
+		_, err = testServ.client.RecoverAccount(context.Background(), &pb.RecoveryRequest{Name: "user1", Recovery: "secret",
+			Password: "mnop", VaultKey: "vault4"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		_, err = testServ.client.RecoverAccount(context.Background(), &pb.RecoveryRequest{Name: "user1", Recovery: "secret",
+			Password: "mnop", VaultKey: "vault4", Otp: "bad"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "ijkl",
+			Otp: verified.GetRecoveryCodes()[1]})
+		require.NoError(t, err)
+
+		recovered, err := testServ.client.RecoverAccount(context.Background(), &pb.RecoveryRequest{Name: "user1", Recovery: "secret",
+			Password: "mnop", VaultKey: "vault4", Otp: verified.GetRecoveryCodes()[0]})
+		require.NoError(t, err)
+		assert.NotEmpty(t, recovered.GetToken())
+	})
+}
+
+func TestAccountExportDelete(t *testing.T) {
+	dir := t.TempDir() + string(os.PathSeparator)
+	testServ := newTestServer(func(c *config.Config) {
+		c.FilePath = dir
+	})
+	defer func() {
+		testServ.conn.Close()
+		testServ.grpcServer.Stop()
+	}()
+
+	ctxToken := func(token string) context.Context {
+		return metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"authorization": token}))
+	}
+
+	login, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+	require.NoError(t, err)
+	other, err := testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user2", Password: "abcd"})
+	require.NoError(t, err)
+
+	item, err := testServ.client.AddData(ctxToken(login.GetToken()), &pb.UserData{Type: pb.TypeData_LOGINDATA, Data: "a:b", Metadata: "bank"})
+	require.NoError(t, err)
+	file := make([]byte, 10000)
+	rand.New(rand.NewSource(7)).Read(file)
+	blob, err := uploadTest(ctxToken(login.GetToken()),
+		testServ.client, &pb.DataChunk{Type: pb.TypeData_BINARYDATA, Metadata: "photo", Parent: item.GetUuid(), Data: file})
+	require.NoError(t, err)
+	_, err = testServ.client.AddData(ctxToken(other.GetToken()), &pb.UserData{Type: pb.TypeData_TEXTDATA, Data: "text", Metadata: "note"})
+	require.NoError(t, err)
+	api, err := testServ.client.CreateAPIToken(ctxToken(login.GetToken()), &pb.APITokenRequest{Name: "ci",
+		Permission: pb.Permission_READWRITE})
+	require.NoError(t, err)
+
+	export := func(ctx context.Context) ([]*pb.ExportChunk, error) {
+		stream, err := testServ.client.ExportAccount(ctx, &pb.ExportRequest{})
+		if err != nil {
+			return nil, err
+		}
+		var res []*pb.ExportChunk
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				return res, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, chunk)
+		}
+	}
+
+	t.Run("Test N1 export account", func(t *testing.T) {
+		chunks, err := export(ctxToken(login.GetToken()))
+		require.NoError(t, err)
+		require.NotEmpty(t, chunks)
+		assert.Equal(t, item.GetUuid(), chunks[0].GetUuid())
+		assert.Equal(t, "a:b", chunks[0].GetData())
+		assert.Equal(t, "bank", chunks[0].GetMetadata())
+		assert.False(t, chunks[0].GetIsBlob())
+
+		var data []byte
+		for _, c := range chunks[1:] {
+			assert.Equal(t, blob.GetUuid(), c.GetUuid())
+			data = append(data, c.GetBlob()...)
+		}
+		assert.True(t, chunks[1].GetIsBlob())
+		assert.Equal(t, "photo", chunks[1].GetMetadata())
+		assert.Equal(t, item.GetUuid(), chunks[1].GetParent())
+		assert.Equal(t, int64(len(file)), chunks[1].GetSize())
+		assert.Equal(t, file, data)
+
+		_, err = export(ctxToken(api.GetToken()))
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Test N2 delete account", func(t *testing.T) {
+		_, err := testServ.client.DeleteAccount(ctxToken(login.GetToken()), &pb.DeleteAccountRequest{Password: "bad"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		require.Equal(t, 1, countFiles(dir))
+
+		org, err := testServ.client.CreateOrg(ctxToken(login.GetToken()), &pb.OrgRequest{Name: "team"})
+		require.NoError(t, err)
+		secret, err := testServ.client.CreateSecretShare(ctxToken(login.GetToken()), &pb.SecretRequest{Ciphertext: "abcd"})
+		require.NoError(t, err)
+		_, err = testServ.client.DeleteAccount(ctxToken(login.GetToken()), &pb.DeleteAccountRequest{Password: "abcd"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.Equal(t, 1, countFiles(dir))
+		_, err = testServ.client.AddMember(ctxToken(login.GetToken()), &pb.MemberRequest{Org: org.GetId(), User: "user2", Role: pb.Role_OWNER})
+		require.NoError(t, err)
+
+		_, err = testServ.client.DeleteAccount(ctxToken(login.GetToken()), &pb.DeleteAccountRequest{Password: "abcd"})
+		require.NoError(t, err)
+		assert.Equal(t, 0, countFiles(dir))
+		members, err := testServ.client.ListMembers(ctxToken(other.GetToken()), &pb.MembersRequest{Org: org.GetId()})
+		require.NoError(t, err)
+		require.Len(t, members.GetMembers(), 1)
+		assert.Equal(t, "user2", members.GetMembers()[0].GetName())
+		_, err = testServ.client.RedeemShare(context.Background(), &pb.RedeemRequest{Id: secret.GetId()})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = testServ.client.GetData(ctxToken(login.GetToken()), &pb.DownloadRequest{Uuid: item.GetUuid()})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = testServ.client.GetData(ctxToken(api.GetToken()), &pb.DownloadRequest{Uuid: item.GetUuid()})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = testServ.client.RefreshToken(context.Background(), &pb.RefreshRequest{RefreshToken: login.GetRefreshToken()})
+		assert.Error(t, err)
+		_, err = testServ.client.LoginUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "abcd"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		chunks, err := export(ctxToken(other.GetToken()))
+		require.NoError(t, err)
+		assert.Len(t, chunks, 1)
+
+		_, err = testServ.client.RegisterUser(context.Background(), &pb.LoginRequest{Name: "user1", Password: "efgh"})
+		assert.NoError(t, err)
+	})
+}
@@ -2,15 +2,18 @@
 package grpcserver
 
 import (
+	"errors"
 	"io"
 
 	"github.com/4aleksei/gokeeper/internal/common/datafile"
 	"github.com/4aleksei/gokeeper/internal/common/store"
+	"github.com/4aleksei/gokeeper/internal/common/streams/compressors/codecs"
 
 	pb "github.com/4aleksei/gokeeper/pkg/api/proto"
 	"google.golang.org/grpc/codes"
 
 	"github.com/4aleksei/gokeeper/internal/server/grpcserver/interceptor"
+	"github.com/4aleksei/gokeeper/internal/server/service"
 	"google.golang.org/grpc/status"
 )
 
@@ -18,20 +21,54 @@ func (s KeeperServiceService) UploadData(stream pb.KeeperService_UploadDataServe
 	var blockData *datafile.LongtermfileWrite
 	var uuid string
 	var encData *store.UserDataCrypt
+	var upload *store.UploadSession
+	var checksum string
 	userID, ok := stream.Context().Value(interceptor.UserIdValue{}).(uint64)
 	if !ok {
 		return status.Errorf(codes.Internal, `%s`, "no USERID")
 	}
+	defer func() {
+		if blockData == nil {
+			return
+		}
+		if upload != nil {
+			_ = s.serv.SuspendUpload(stream.Context(), upload, blockData)
+			return
+		}
+		_ = blockData.CloseWrite()
+	}()
 
 	for {
 		req, err := stream.Recv()
 
 		if err == io.EOF {
+			if blockData != nil && upload != nil {
+				var errAdd error
+				uuid, errAdd = s.serv.CommitUpload(stream.Context(), upload, blockData, checksum)
+				blockData = nil
+				if errors.Is(errAdd, service.ErrSizeMismatch) {
+					return status.Errorf(codes.FailedPrecondition, `%v`, errAdd)
+				}
+				if errors.Is(errAdd, service.ErrChecksum) {
+					return status.Errorf(codes.DataLoss, `%v`, errAdd)
+				}
+				if isQuota(errAdd) {
+					return status.Errorf(codes.ResourceExhausted, `%v`, errAdd)
+				}
+				if errAdd != nil {
+					return errAdd
+				}
+			}
 			if blockData != nil && encData != nil {
 				var errAdd error
-				blockData.Success()
-				blockData.CloseWrite()
-				uuid, errAdd = s.serv.AddDataStream(stream.Context(), encData)
+				uuid, errAdd = s.serv.CommitDataStream(stream.Context(), encData, blockData, checksum)
+				blockData = nil
+				if errors.Is(errAdd, service.ErrChecksum) {
+					return status.Errorf(codes.DataLoss, `%v`, errAdd)
+				}
+				if isQuota(errAdd) {
+					return status.Errorf(codes.ResourceExhausted, `%v`, errAdd)
+				}
 				if errAdd != nil {
 					return errAdd
 				}
@@ -47,22 +84,50 @@ func (s KeeperServiceService) UploadData(stream pb.KeeperService_UploadDataServe
 		if err != nil {
 			return err
 		}
+		if req.GetChecksum() != "" {
+			checksum = req.GetChecksum()
+		}
 
 		if blockData == nil {
 			data := &store.UserData{
-				Id:       userID,
-				TypeData: int(req.GetType()),
-				MetaData: req.GetMetadata(),
+				Id:         userID,
+				TypeData:   int(req.GetType()),
+				MetaData:   req.GetMetadata(),
+				Tokens:     req.GetTokens(),
+				Parent:     req.GetParent(),
+				Codec:      req.GetCompression(),
+				ExpiresAt:  expiryTime(req.GetExpiresAt()),
+				Collection: req.GetCollection(),
 			}
 			var errAdd error
-			blockData, encData, errAdd = s.serv.CreateDataStream(stream.Context(), data)
+			if req.GetSession() != "" {
+				blockData, upload, errAdd = s.serv.OpenUploadStream(stream.Context(), data, req.GetSession(), req.GetOffset(), req.GetSize(), req.GetData())
+				if errors.Is(errAdd, service.ErrBadOffset) {
+					return status.Errorf(codes.FailedPrecondition, `%v`, errAdd)
+				}
+			} else {
+				blockData, encData, errAdd = s.serv.CreateDataStream(stream.Context(), data, req.GetData())
+			}
+			if errors.Is(errAdd, codecs.ErrUnknownCodec) || errors.Is(errAdd, service.ErrBadExpiry) {
+				return status.Errorf(codes.InvalidArgument, `%v`, errAdd)
+			}
+			if isQuota(errAdd) {
+				return status.Errorf(codes.ResourceExhausted, `%v`, errAdd)
+			}
+			if isDenied(errAdd) || errors.Is(errAdd, service.ErrNoCollection) || errors.Is(errAdd, service.ErrBadCollection) {
+				return orgError(errAdd)
+			}
 
 			if errAdd != nil {
 				return errAdd
 			}
+		} else if upload != nil && req.GetOffset() != blockData.Size() {
+			return status.Errorf(codes.FailedPrecondition, `%v`, service.ErrBadOffset)
 		}
-		defer blockData.CloseWrite()
 		_, err = blockData.WriteData(req.GetData())
+		if isQuota(err) {
+			return status.Errorf(codes.ResourceExhausted, `%v`, err)
+		}
 		if err != nil {
 			return err
 		}
@@ -70,6 +135,12 @@ func (s KeeperServiceService) UploadData(stream pb.KeeperService_UploadDataServe
 	}
 }
 
+// isQuota - quota of user or size limit of upload is exceeded
+func isQuota(err error) bool {
+	return errors.Is(err, service.ErrQuotaExceeded) || errors.Is(err, service.ErrUploadTooLarge) ||
+		errors.Is(err, datafile.ErrSizeLimit)
+}
+
 func (s KeeperServiceService) DownloadData(req *pb.DownloadRequest, stream pb.KeeperService_DownloadDataServer) error {
 
 	userID, ok := stream.Context().Value(interceptor.UserIdValue{}).(uint64)
@@ -77,39 +148,180 @@ func (s KeeperServiceService) DownloadData(req *pb.DownloadRequest, stream pb.Ke
 		return status.Errorf(codes.Internal, `%s`, "no USERID")
 	}
 
-	data, blockData, err := s.serv.GetDataStream(stream.Context(), userID, req.GetUuid())
+	data, blockData, err := s.serv.GetDataStream(stream.Context(), userID, req.GetUuid(), req.GetOffset(), req.GetLength())
+	if errors.Is(err, service.ErrBadRange) {
+		return status.Errorf(codes.OutOfRange, `%v`, err)
+	}
+	if errors.Is(err, service.ErrNotFound) {
+		return status.Errorf(codes.NotFound, `%v`, err)
+	}
+	if errors.Is(err, service.ErrIncorectUserId) {
+		return status.Errorf(codes.PermissionDenied, `%v`, err)
+	}
 	if err != nil {
 		return err
 	}
+	defer blockData.CloseRead()
 
 	buffer := make([]byte, 4096) // Chunk size
 	var sendMetaData bool
 	var chunk *pb.DataChunk
+	offset := req.GetOffset()
 	for {
 		n, err := blockData.ReadData(buffer)
-		if err == io.EOF {
+		if err == io.EOF && sendMetaData {
 			break // End of file
 		}
-		if err != nil {
+		if err != nil && err != io.EOF {
 			return status.Errorf(codes.Internal, "error reading : %v", err)
 		}
 
 		if !sendMetaData {
+			// first chunk is sent for empty range too
 			sendMetaData = true
 			chunk = &pb.DataChunk{
-				Data:     buffer[:n],
-				Metadata: data.MetaData,
-				Type:     pb.TypeData(data.TypeData),
+				Data:      buffer[:n],
+				Offset:    offset,
+				Size:      data.Size,
+				Metadata:  data.MetaData,
+				Type:      pb.TypeData(data.TypeData),
+				Checksum:  data.Checksum,
+				ExpiresAt: expiryUnix(data.ExpiresAt),
 			}
 		} else {
 			chunk = &pb.DataChunk{
-				Data: buffer[:n],
+				Data:   buffer[:n],
+				Offset: offset,
 			}
 		}
 		if err := stream.Send(chunk); err != nil {
 			return status.Errorf(codes.Internal, "error sending chunk: %v", err)
 		}
+		offset += int64(n)
+		if err == io.EOF {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s KeeperServiceService) Search(req *pb.SearchRequest, stream pb.KeeperService_SearchServer) error {
+	userID, ok := stream.Context().Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	results, err := s.serv.Search(stream.Context(), userID, req.GetQuery(), req.GetTokens())
+	if err != nil {
+		return status.Errorf(codes.Internal, `%v`, err)
+	}
+
+	for _, r := range results {
+		if err := stream.Send(&pb.SearchResult{
+			Uuid:     r.Uuid,
+			Type:     pb.TypeData(r.TypeData),
+			Metadata: r.MetaData,
+			Score:    int32(r.Score),
+			Fields:   r.Fields,
+		}); err != nil {
+			return status.Errorf(codes.Internal, "error sending result: %v", err)
+		}
+	}
+	return nil
+}
+
+func (s KeeperServiceService) ExportAccount(req *pb.ExportRequest, stream pb.KeeperService_ExportAccountServer) error {
+	userID, ok := stream.Context().Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	items, err := s.serv.ExportAccount(stream.Context(), userID)
+	if err != nil {
+		return status.Errorf(codes.Internal, `%v`, err)
+	}
+	for _, item := range items {
+		if item.Blob {
+			err = s.exportBlob(stream, userID, item)
+		} else {
+			err = s.exportItem(stream, userID, item)
+		}
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
+func (s KeeperServiceService) exportItem(stream pb.KeeperService_ExportAccountServer, userID uint64, item *store.UserDataCrypt) error {
+	data, err := s.serv.GetData(stream.Context(), userID, item.Uuid)
+	if err != nil {
+		return status.Errorf(codes.Internal, `%v`, err)
+	}
+	if err := stream.Send(&pb.ExportChunk{
+		Uuid:       item.Uuid,
+		Type:       pb.TypeData(data.TypeData),
+		Data:       data.UserData,
+		Metadata:   data.MetaData,
+		Parent:     data.Parent,
+		Collection: data.Collection,
+		ExpiresAt:  expiryUnix(data.ExpiresAt),
+	}); err != nil {
+		return status.Errorf(codes.Internal, "error sending item: %v", err)
+	}
+	return nil
+}
+
+// exportBlob - blob is sent in chunks, first chunk has fields of item
+func (s KeeperServiceService) exportBlob(stream pb.KeeperService_ExportAccountServer, userID uint64, item *store.UserDataCrypt) error {
+	data, blockData, err := s.serv.GetDataStream(stream.Context(), userID, item.Uuid, 0, 0)
+	if err != nil {
+		return status.Errorf(codes.Internal, `%v`, err)
+	}
+	defer blockData.CloseRead()
+
+	buffer := make([]byte, 4096) // Chunk size
+	var sendMetaData bool
+	var chunk *pb.ExportChunk
+	var offset int64
+	for {
+		n, err := blockData.ReadData(buffer)
+		if err == io.EOF && sendMetaData {
+			break
+		}
+		if err != nil && err != io.EOF {
+			return status.Errorf(codes.Internal, "error reading : %v", err)
+		}
+
+		if !sendMetaData {
+			sendMetaData = true
+			chunk = &pb.ExportChunk{
+				Uuid:       item.Uuid,
+				Type:       pb.TypeData(data.TypeData),
+				Metadata:   data.MetaData,
+				Parent:     data.Parent,
+				Collection: data.Collection,
+				ExpiresAt:  expiryUnix(data.ExpiresAt),
+				IsBlob:     true,
+				Blob:       buffer[:n],
+				Size:       data.Size,
+				Checksum:   data.Checksum,
+			}
+		} else {
+			chunk = &pb.ExportChunk{
+				Uuid:   item.Uuid,
+				Blob:   buffer[:n],
+				Offset: offset,
+			}
+		}
+		if err := stream.Send(chunk); err != nil {
+			return status.Errorf(codes.Internal, "error sending chunk: %v", err)
+		}
+		offset += int64(n)
+		if err == io.EOF {
+			break
+		}
+	}
 	return nil
 }
@@ -3,30 +3,60 @@ package grpcserver
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/4aleksei/gokeeper/internal/common/store"
 
 	pb "github.com/4aleksei/gokeeper/pkg/api/proto"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/4aleksei/gokeeper/internal/server/grpcserver/interceptor"
+	"github.com/4aleksei/gokeeper/internal/server/service"
 	"google.golang.org/grpc/status"
 )
 
 func (s KeeperServiceService) LoginUser(ctx context.Context, in *pb.LoginRequest) (*pb.LoginResponse, error) {
 	var response pb.LoginResponse
-	name := in.GetName()
-	pass := in.GetPassword()
-	user, err := s.serv.LoginUser(ctx, name, pass)
-	if err != nil {
-		return nil, status.Errorf(codes.NotFound, `%s`, err.Error())
+	var user *store.User
+	var err error
+	if in.GetChallenge() != "" {
+		user, err = s.serv.ChallengeUser(ctx, in.GetChallenge())
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, `%v`, err)
+		}
+	} else {
+		user, err = s.serv.LoginUser(ctx, in.GetName(), in.GetPassword())
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, `%v`, err)
+		}
+	}
+	if user.TOTPSecret != "" {
+		if in.GetOtp() == "" {
+			// password is valid, second step of login waits for code
+			challenge, err := s.serv.Challenge(ctx, user)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, `%v`, err)
+			}
+			return &pb.LoginResponse{Challenge: challenge}, nil
+		}
+		err = s.serv.SecondFactor(ctx, user, in.GetOtp())
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, `%v`, err)
+		}
 	}
-	token, err := s.serv.BuildToken(ctx, user)
+	tokens, err := s.startSession(ctx, user.Id, in.GetDevice(), in.GetClientVersion())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, `%s`, err.Error())
 	}
 
-	response.Token = token
+	response.Token = tokens.Access
+	response.RefreshToken = tokens.Refresh
+	response.ExpiresAt = tokens.ExpiresAt.Unix()
+	response.VaultKey = user.VaultKey
+	response.PublicKey = user.PublicKey
+	response.PrivateKey = user.PrivateKey
 	return &response, nil
 }
 
@@ -35,19 +65,298 @@ func (s KeeperServiceService) RegisterUser(ctx context.Context, in *pb.LoginRequ
 
 	name := in.GetName()
 	pass := in.GetPassword()
-	user, err := s.serv.RegisterUser(ctx, name, pass)
+	user, err := s.serv.RegisterUser(ctx, name, pass, in.GetVaultKey())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, `%s`, err.Error())
 	}
-	token, err := s.serv.BuildToken(ctx, user)
+	tokens, err := s.startSession(ctx, user.Id, in.GetDevice(), in.GetClientVersion())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, `%s`, err.Error())
 	}
 
-	response.Token = token
+	response.Token = tokens.Access
+	response.RefreshToken = tokens.Refresh
+	response.ExpiresAt = tokens.ExpiresAt.Unix()
+	return &response, nil
+}
+
+// startSession - session of login with device and address of client, tokens of session
+func (s KeeperServiceService) startSession(ctx context.Context, userID uint64, device string, version string) (*store.Tokens, error) {
+	session, err := s.serv.StartSession(ctx, userID, device, version, s.proxies.ClientAddress(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return s.serv.IssueTokens(ctx, userID, session.Id)
+}
+
+func (s KeeperServiceService) EnrollTOTP(ctx context.Context, in *pb.EnrollRequest) (*pb.EnrollResponse, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	secret, uri, err := s.serv.EnrollTOTP(ctx, userID, in.GetCode(), in.GetPassword())
+	switch {
+	case errors.Is(err, service.ErrNoConfirm):
+		return nil, status.Errorf(codes.PermissionDenied, `%v`, err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	return &pb.EnrollResponse{Secret: secret, Uri: uri}, nil
+}
+
+func (s KeeperServiceService) VerifyTOTP(ctx context.Context, in *pb.VerifyTOTPRequest) (*pb.VerifyTOTPResponse, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	recovery, err := s.serv.VerifyTOTP(ctx, userID, in.GetCode())
+	switch {
+	case errors.Is(err, service.ErrNoEnrollment):
+		return nil, status.Errorf(codes.FailedPrecondition, `%v`, err)
+	case errors.Is(err, service.ErrBadCode):
+		return nil, status.Errorf(codes.InvalidArgument, `%v`, err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	return &pb.VerifyTOTPResponse{RecoveryCodes: recovery}, nil
+}
+
+func (s KeeperServiceService) ListSessions(ctx context.Context, in *pb.SessionsRequest) (*pb.SessionsResponse, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	var token string
+	if v := md.Get("authorization"); len(v) > 0 {
+		token = v[0]
+	}
+
+	sessions, current, err := s.serv.Sessions(ctx, userID, token)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	var response pb.SessionsResponse
+	for _, session := range sessions {
+		response.Sessions = append(response.Sessions, &pb.Session{
+			Id:            session.Id,
+			Device:        session.Device,
+			ClientVersion: session.ClientVersion,
+			Address:       session.Address,
+			CreatedAt:     session.CreatedAt.Unix(),
+			LastSeen:      session.LastSeen.Unix(),
+			Current:       session.Id == current,
+		})
+	}
+	return &response, nil
+}
+
+func (s KeeperServiceService) RevokeSession(ctx context.Context, in *pb.RevokeSessionRequest) (*pb.ResponseLogout, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	err := s.serv.RevokeSession(ctx, userID, in.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, `%v`, err)
+	}
+	return &pb.ResponseLogout{}, nil
+}
+
+func (s KeeperServiceService) ChangePassword(ctx context.Context, in *pb.ChangePasswordRequest) (*pb.LoginResponse, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	err := s.serv.ChangePassword(ctx, userID, in.GetOldPassword(), in.GetPassword(), in.GetVaultKey(), in.GetPrivateKey())
+	switch {
+	case errors.Is(err, service.ErrPassIncorect):
+		return nil, status.Errorf(codes.Unauthenticated, `%v`, err)
+	case errors.Is(err, service.ErrKeysRequired):
+		return nil, status.Errorf(codes.InvalidArgument, `%v`, err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	tokens, err := s.startSession(ctx, userID, in.GetDevice(), in.GetClientVersion())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	return &pb.LoginResponse{Token: tokens.Access, RefreshToken: tokens.Refresh, ExpiresAt: tokens.ExpiresAt.Unix()}, nil
+}
+
+func (s KeeperServiceService) SetRecovery(ctx context.Context, in *pb.SetRecoveryRequest) (*pb.ResponseSetRecovery, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	err := s.serv.SetRecovery(ctx, userID, in.GetRecovery(), in.GetVaultKey(), in.GetPrivateKey())
+	if errors.Is(err, service.ErrBadRecovery) {
+		return nil, status.Errorf(codes.InvalidArgument, `%v`, err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	return &pb.ResponseSetRecovery{}, nil
+}
+
+func (s KeeperServiceService) OpenRecovery(ctx context.Context, in *pb.RecoveryRequest) (*pb.RecoveryResponse, error) {
+	user, err := s.serv.OpenRecovery(ctx, in.GetName(), in.GetRecovery())
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, `%v`, err)
+	}
+	return &pb.RecoveryResponse{VaultKey: user.RecoveryVaultKey, PrivateKey: user.RecoveryPrivateKey}, nil
+}
+
+func (s KeeperServiceService) RecoverAccount(ctx context.Context, in *pb.RecoveryRequest) (*pb.LoginResponse, error) {
+	user, err := s.serv.RecoverAccount(ctx, in.GetName(), in.GetRecovery(), in.GetOtp(), in.GetPassword(), in.GetVaultKey(),
+		in.GetPrivateKey())
+	switch {
+	case errors.Is(err, service.ErrBadRecovery), errors.Is(err, service.ErrBadCode):
+		return nil, status.Errorf(codes.Unauthenticated, `%v`, err)
+	case errors.Is(err, service.ErrCodeRequired):
+		return nil, status.Errorf(codes.FailedPrecondition, `%v`, err)
+	case errors.Is(err, service.ErrKeysRequired):
+		return nil, status.Errorf(codes.InvalidArgument, `%v`, err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	tokens, err := s.startSession(ctx, user.Id, in.GetDevice(), in.GetClientVersion())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	return &pb.LoginResponse{Token: tokens.Access, RefreshToken: tokens.Refresh, ExpiresAt: tokens.ExpiresAt.Unix(),
+		VaultKey: user.VaultKey, PublicKey: user.PublicKey, PrivateKey: user.PrivateKey}, nil
+}
+
+func (s KeeperServiceService) DeleteAccount(ctx context.Context, in *pb.DeleteAccountRequest) (*pb.ResponseDeleteAccount, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	err := s.serv.DeleteAccount(ctx, userID, in.GetPassword())
+	if errors.Is(err, service.ErrPassIncorect) {
+		return nil, status.Errorf(codes.Unauthenticated, `%v`, err)
+	}
+	if errors.Is(err, service.ErrLastOwner) {
+		return nil, status.Errorf(codes.FailedPrecondition, `%v`, err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	return &pb.ResponseDeleteAccount{}, nil
+}
+
+func (s KeeperServiceService) CreateAPIToken(ctx context.Context, in *pb.APITokenRequest) (*pb.APITokenResponse, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	token, t, err := s.serv.CreateAPIToken(ctx, userID, in.GetName(), int(in.GetPermission()), in.GetItems(),
+		in.GetCollections(), in.GetTags(), time.Duration(in.GetExpiresIn())*time.Second)
+	switch {
+	case errors.Is(err, service.ErrBadName), errors.Is(err, service.ErrBadPermission), errors.Is(err, service.ErrBadTag):
+		return nil, status.Errorf(codes.InvalidArgument, `%v`, err)
+	case errors.Is(err, service.ErrTokenExists):
+		return nil, status.Errorf(codes.AlreadyExists, `%v`, err)
+	case isDenied(err):
+		return nil, status.Errorf(codes.PermissionDenied, `%v`, err)
+	case err != nil:
+		return nil, status.Errorf(codes.NotFound, `%v`, err)
+	}
+	return &pb.APITokenResponse{Token: token, Info: apiTokenPb(t)}, nil
+}
+
+func apiTokenPb(t *store.APIToken) *pb.APIToken {
+	res := &pb.APIToken{
+		Name:        t.Name,
+		Permission:  pb.Permission(t.Permission),
+		Items:       t.Items,
+		Collections: t.Collections,
+		Tags:        t.Tags,
+		ExpiresAt:   expiryUnix(t.ExpiresAt),
+		CreatedAt:   t.CreatedAt.Unix(),
+	}
+	if !t.LastUsed.IsZero() {
+		res.LastUsed = t.LastUsed.Unix()
+	}
+	return res
+}
+
+func (s KeeperServiceService) ListAPITokens(ctx context.Context, in *pb.APITokensRequest) (*pb.APITokensResponse, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	tokens, err := s.serv.APITokens(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	var response pb.APITokensResponse
+	for _, t := range tokens {
+		response.Tokens = append(response.Tokens, apiTokenPb(t))
+	}
 	return &response, nil
 }
 
+func (s KeeperServiceService) RevokeAPIToken(ctx context.Context, in *pb.RevokeAPITokenRequest) (*pb.ResponseLogout, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	err := s.serv.RevokeAPIToken(ctx, userID, in.GetName())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, `%v`, err)
+	}
+	return &pb.ResponseLogout{}, nil
+}
+
+func (s KeeperServiceService) RefreshToken(ctx context.Context, in *pb.RefreshRequest) (*pb.RefreshResponse, error) {
+	tokens, err := s.serv.RefreshTokens(ctx, in.GetRefreshToken())
+	if errors.Is(err, service.ErrBadRefresh) {
+		return nil, status.Errorf(codes.Unauthenticated, `%v`, err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	return &pb.RefreshResponse{Token: tokens.Access, RefreshToken: tokens.Refresh, ExpiresAt: tokens.ExpiresAt.Unix()}, nil
+}
+
+func (s KeeperServiceService) Logout(ctx context.Context, in *pb.LogoutRequest) (*pb.ResponseLogout, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	token := md.Get("authorization")
+	if len(token) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, `%s`, "authorization token is not provided")
+	}
+
+	err := s.serv.Logout(ctx, token[0], in.GetRefreshToken())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	return &pb.ResponseLogout{}, nil
+}
+
+func (s KeeperServiceService) RevokeAllSessions(ctx context.Context, in *pb.RevokeAllRequest) (*pb.ResponseLogout, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	err := s.serv.RevokeAll(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	return &pb.ResponseLogout{}, nil
+}
+
 func (s KeeperServiceService) AddData(ctx context.Context, in *pb.UserData) (*pb.ResponseAddData, error) {
 	var response pb.ResponseAddData
 	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
@@ -56,13 +365,25 @@ func (s KeeperServiceService) AddData(ctx context.Context, in *pb.UserData) (*pb
 	}
 
 	data := &store.UserData{
-		Id:       userID,
-		TypeData: int(in.GetType()),
-		UserData: in.GetData(),
-		MetaData: in.GetMetadata(),
+		Id:         userID,
+		TypeData:   int(in.GetType()),
+		UserData:   in.GetData(),
+		MetaData:   in.GetMetadata(),
+		Tokens:     in.GetTokens(),
+		ExpiresAt:  expiryTime(in.GetExpiresAt()),
+		Collection: in.GetCollection(),
 	}
 
 	uuid, err := s.serv.AddData(ctx, data)
+	if errors.Is(err, service.ErrQuotaExceeded) {
+		return nil, status.Errorf(codes.ResourceExhausted, `%v`, err)
+	}
+	if errors.Is(err, service.ErrBadExpiry) {
+		return nil, status.Errorf(codes.InvalidArgument, `%v`, err)
+	}
+	if isDenied(err) || errors.Is(err, service.ErrNoCollection) {
+		return nil, orgError(err)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, `%s`, err.Error())
 	}
@@ -78,11 +399,541 @@ func (s KeeperServiceService) GetData(ctx context.Context, in *pb.DownloadReques
 	}
 
 	data, err := s.serv.GetData(ctx, userID, in.GetUuid())
+	if errors.Is(err, service.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, `%v`, err)
+	}
+	if errors.Is(err, service.ErrIncorectUserId) {
+		return nil, status.Errorf(codes.PermissionDenied, `%v`, err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	attachments, err := s.serv.GetAttachments(ctx, userID, in.GetUuid())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, `%v`, err)
 	}
 	response.Data = data.UserData
 	response.Metadata = data.MetaData
 	response.Type = pb.TypeData(data.TypeData)
+	response.ExpiresAt = expiryUnix(data.ExpiresAt)
+	for _, a := range attachments {
+		response.Attachments = append(response.Attachments, &pb.Attachment{
+			Uuid: a.Uuid,
+			Name: a.MetaData,
+			Size: a.Size,
+		})
+	}
 	return &response, nil
 }
+
+func (s KeeperServiceService) DeleteData(ctx context.Context, in *pb.DownloadRequest) (*pb.ResponseDeleteData, error) {
+	var response pb.ResponseDeleteData
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	uuids, err := s.serv.DeleteData(ctx, userID, in.GetUuid())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, `%v`, err)
+	}
+	response.Uuids = uuids
+	return &response, nil
+}
+
+func (s KeeperServiceService) QueryUpload(ctx context.Context, in *pb.QueryUploadRequest) (*pb.QueryUploadResponse, error) {
+	var response pb.QueryUploadResponse
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	upload, err := s.serv.QueryUpload(ctx, userID, in.GetSession())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, `%v`, err)
+	}
+	response.Session = upload.Id
+	response.Offset = upload.Offset
+	response.Size = upload.Size
+	return &response, nil
+}
+
+// expiryTime - unix time of expiry, zero - never
+func expiryTime(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+func expiryUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func (s KeeperServiceService) GetUsage(ctx context.Context, in *pb.UsageRequest) (*pb.UsageResponse, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	usage, err := s.serv.GetUsage(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	return &pb.UsageResponse{
+		Items:      usage.Items,
+		Bytes:      usage.Bytes,
+		QuotaItems: usage.QuotaItems,
+		QuotaBytes: usage.QuotaBytes,
+		MaxUpload:  usage.MaxUpload,
+	}, nil
+}
+
+func (s KeeperServiceService) UpdateData(ctx context.Context, in *pb.UpdateRequest) (*pb.ResponseUpdateData, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	err := s.serv.UpdateData(ctx, &store.UserData{
+		Id:       userID,
+		Uuid:     in.GetUuid(),
+		UserData: in.GetData(),
+		MetaData: in.GetMetadata(),
+		Tokens:   in.GetTokens(),
+	})
+	if err != nil {
+		return nil, rotationError(err)
+	}
+	return &pb.ResponseUpdateData{}, nil
+}
+
+func (s KeeperServiceService) SetRotation(ctx context.Context, in *pb.RotationRequest) (*pb.ResponseRotation, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	err := s.serv.SetRotation(ctx, userID, in.GetUuid(), int(in.GetDays()))
+	if err != nil {
+		return nil, rotationError(err)
+	}
+	return &pb.ResponseRotation{}, nil
+}
+
+func (s KeeperServiceService) DueRotation(ctx context.Context, in *pb.DueRequest) (*pb.DueResponse, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	at := time.Now()
+	if in.GetAt() != 0 {
+		at = time.Unix(in.GetAt(), 0)
+	}
+	items, err := s.serv.DueRotation(ctx, userID, at)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	var response pb.DueResponse
+	for _, item := range items {
+		response.Items = append(response.Items, &pb.DueItem{
+			Uuid:      item.Uuid,
+			Metadata:  item.MetaData,
+			ChangedAt: item.ChangedAt.Unix(),
+			DueAt:     item.DueAt.Unix(),
+			Days:      int32(item.Rotation),
+		})
+	}
+	return &response, nil
+}
+
+// rotationError - status of update of item or rotation policy
+func rotationError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		return status.Errorf(codes.NotFound, `%v`, err)
+	case errors.Is(err, service.ErrNotLogin), errors.Is(err, service.ErrBadRotation), errors.Is(err, service.ErrBlobUpdate):
+		return status.Errorf(codes.InvalidArgument, `%v`, err)
+	case errors.Is(err, service.ErrQuotaExceeded):
+		return status.Errorf(codes.ResourceExhausted, `%v`, err)
+	case isDenied(err):
+		return status.Errorf(codes.PermissionDenied, `%v`, err)
+	}
+	return status.Errorf(codes.Internal, `%v`, err)
+}
+
+func (s KeeperServiceService) SetKeys(ctx context.Context, in *pb.SetKeysRequest) (*pb.ResponseSetKeys, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	err := s.serv.SetKeys(ctx, userID, in.GetPublicKey(), in.GetPrivateKey())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, `%v`, err)
+	}
+	return &pb.ResponseSetKeys{}, nil
+}
+
+func (s KeeperServiceService) ShareItem(ctx context.Context, in *pb.ShareRequest) (*pb.ResponseShare, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	share, err := s.serv.ShareItem(ctx, userID, in.GetUuid(), in.GetRecipient(), int(in.GetPermission()))
+	if err != nil {
+		return nil, sharingError(err)
+	}
+	return &pb.ResponseShare{WrappedKey: share.WrappedKey}, nil
+}
+
+func (s KeeperServiceService) Unshare(ctx context.Context, in *pb.UnshareRequest) (*pb.ResponseUnshare, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	err := s.serv.Unshare(ctx, userID, in.GetUuid(), in.GetRecipient())
+	if err != nil {
+		return nil, sharingError(err)
+	}
+	return &pb.ResponseUnshare{}, nil
+}
+
+func (s KeeperServiceService) SharedWithMe(ctx context.Context, in *pb.SharedRequest) (*pb.SharedResponse, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	items, err := s.serv.SharedWithMe(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	var response pb.SharedResponse
+	for _, item := range items {
+		response.Items = append(response.Items, &pb.SharedItem{
+			Uuid:       item.Uuid,
+			Owner:      item.Owner,
+			Type:       pb.TypeData(item.TypeData),
+			Metadata:   item.MetaData,
+			Permission: pb.Permission(item.Permission),
+			WrappedKey: item.WrappedKey,
+		})
+	}
+	return &response, nil
+}
+
+func (s KeeperServiceService) GetSealedData(ctx context.Context, in *pb.DownloadRequest) (*pb.SealedItem, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	dataEnc, err := s.serv.SealedData(ctx, userID, in.GetUuid())
+	if errors.Is(err, service.ErrBlobSealed) {
+		return nil, status.Errorf(codes.InvalidArgument, `%v`, err)
+	}
+	if err != nil {
+		return nil, sharingError(err)
+	}
+	return &pb.SealedItem{
+		Uuid:     dataEnc.Uuid,
+		Type:     pb.TypeData(dataEnc.TypeData),
+		Data:     dataEnc.UserDataEn,
+		Metadata: dataEnc.MetaDataEn,
+	}, nil
+}
+
+// sharingError - status of share of item
+func sharingError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrIncorectUserId):
+		return status.Errorf(codes.PermissionDenied, `%v`, err)
+	case errors.Is(err, service.ErrNoPublicKey):
+		return status.Errorf(codes.FailedPrecondition, `%v`, err)
+	case errors.Is(err, service.ErrShareSelf), errors.Is(err, service.ErrBadPermission), errors.Is(err, service.ErrBadParent):
+		return status.Errorf(codes.InvalidArgument, `%v`, err)
+	}
+	return status.Errorf(codes.NotFound, `%v`, err)
+}
+
+func (s KeeperServiceService) CreateOrg(ctx context.Context, in *pb.OrgRequest) (*pb.OrgResponse, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	org, err := s.serv.CreateOrg(ctx, userID, in.GetName())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	return &pb.OrgResponse{Id: org.Id}, nil
+}
+
+func (s KeeperServiceService) AddMember(ctx context.Context, in *pb.MemberRequest) (*pb.ResponseMember, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	err := s.serv.AddMember(ctx, userID, in.GetOrg(), in.GetUser(), int(in.GetRole()))
+	if err != nil {
+		return nil, orgError(err)
+	}
+	return &pb.ResponseMember{}, nil
+}
+
+func (s KeeperServiceService) RemoveMember(ctx context.Context, in *pb.MemberRequest) (*pb.ResponseMember, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	err := s.serv.RemoveMember(ctx, userID, in.GetOrg(), in.GetUser())
+	if err != nil {
+		return nil, orgError(err)
+	}
+	return &pb.ResponseMember{}, nil
+}
+
+func (s KeeperServiceService) ListMembers(ctx context.Context, in *pb.MembersRequest) (*pb.MembersResponse, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	members, err := s.serv.Members(ctx, userID, in.GetOrg())
+	if err != nil {
+		return nil, orgError(err)
+	}
+	var response pb.MembersResponse
+	for _, member := range members {
+		response.Members = append(response.Members, &pb.Member{Name: member.Name, Role: pb.Role(member.Role)})
+	}
+	return &response, nil
+}
+
+func (s KeeperServiceService) CreateCollection(ctx context.Context, in *pb.CollectionRequest) (*pb.CollectionResponse, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	collection, err := s.serv.CreateCollection(ctx, userID, in.GetOrg(), in.GetName())
+	if err != nil {
+		return nil, orgError(err)
+	}
+	return &pb.CollectionResponse{Uuid: collection.Uuid}, nil
+}
+
+func (s KeeperServiceService) ListCollections(ctx context.Context, in *pb.CollectionsRequest) (*pb.CollectionsResponse, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	collections, err := s.serv.Collections(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	var response pb.CollectionsResponse
+	for _, c := range collections {
+		response.Collections = append(response.Collections, &pb.Collection{
+			Uuid:       c.Uuid,
+			Org:        c.Org,
+			Name:       c.Name,
+			Role:       pb.Role(c.Role),
+			Version:    int32(c.Version),
+			WrappedKey: c.WrappedKey,
+		})
+	}
+	return &response, nil
+}
+
+func (s KeeperServiceService) CollectionItems(ctx context.Context, in *pb.CollectionItemsRequest) (*pb.CollectionItemsResponse, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	items, err := s.serv.CollectionItems(ctx, userID, in.GetUuid())
+	if err != nil {
+		return nil, orgError(err)
+	}
+	var response pb.CollectionItemsResponse
+	for _, item := range items {
+		response.Items = append(response.Items, &pb.CollectionItem{
+			Uuid:     item.Uuid,
+			Owner:    item.Owner,
+			Type:     pb.TypeData(item.TypeData),
+			Metadata: item.MetaData,
+		})
+	}
+	return &response, nil
+}
+
+// isDenied - user has no access to item or no role in organization
+func isDenied(err error) bool {
+	return errors.Is(err, service.ErrIncorectUserId) || errors.Is(err, service.ErrNotMember) || errors.Is(err, service.ErrNoRole)
+}
+
+// orgError - status of membership and collection management
+func orgError(err error) error {
+	switch {
+	case isDenied(err):
+		return status.Errorf(codes.PermissionDenied, `%v`, err)
+	case errors.Is(err, service.ErrLastOwner):
+		return status.Errorf(codes.FailedPrecondition, `%v`, err)
+	case errors.Is(err, service.ErrBadRole), errors.Is(err, service.ErrBadCollection):
+		return status.Errorf(codes.InvalidArgument, `%v`, err)
+	case errors.Is(err, service.ErrNoRecipient), errors.Is(err, service.ErrNoOrg), errors.Is(err, service.ErrNoCollection):
+		return status.Errorf(codes.NotFound, `%v`, err)
+	}
+	return status.Errorf(codes.Internal, `%v`, err)
+}
+
+func (s KeeperServiceService) CreateSecretShare(ctx context.Context, in *pb.SecretRequest) (*pb.SecretResponse, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	secret, err := s.serv.CreateSecret(ctx, userID, in.GetCiphertext(), int(in.GetMaxViews()), expiryTime(in.GetExpiresAt()))
+	if errors.Is(err, service.ErrSecretSize) || errors.Is(err, service.ErrBadViews) || errors.Is(err, service.ErrBadExpiry) {
+		return nil, status.Errorf(codes.InvalidArgument, `%v`, err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	return &pb.SecretResponse{Id: secret.Id, ExpiresAt: secret.ExpiresAt.Unix()}, nil
+}
+
+func (s KeeperServiceService) RedeemShare(ctx context.Context, in *pb.RedeemRequest) (*pb.RedeemResponse, error) {
+	secret, err := s.serv.RedeemSecret(ctx, in.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, `%v`, err)
+	}
+	return &pb.RedeemResponse{Ciphertext: secret.Ciphertext, ViewsLeft: int32(secret.MaxViews - secret.Views)}, nil
+}
+
+func (s KeeperServiceService) InviteEmergency(ctx context.Context, in *pb.EmergencyInvite) (*pb.Emergency, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	e, err := s.serv.InviteEmergency(ctx, userID, in.GetContact(), time.Duration(in.GetWaitSeconds())*time.Second)
+	if err != nil {
+		return nil, emergencyError(err)
+	}
+	return emergencyPb(e), nil
+}
+
+func (s KeeperServiceService) RequestEmergency(ctx context.Context, in *pb.EmergencyRequest) (*pb.Emergency, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	e, err := s.serv.RequestEmergency(ctx, userID, in.GetId())
+	if err != nil {
+		return nil, emergencyError(err)
+	}
+	return emergencyPb(e), nil
+}
+
+func (s KeeperServiceService) ApproveEmergency(ctx context.Context, in *pb.EmergencyRequest) (*pb.Emergency, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	e, err := s.serv.DecideEmergency(ctx, userID, in.GetId(), in.GetApprove())
+	if err != nil {
+		return nil, emergencyError(err)
+	}
+	return emergencyPb(e), nil
+}
+
+func (s KeeperServiceService) RevokeEmergency(ctx context.Context, in *pb.EmergencyRequest) (*pb.ResponseEmergency, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	err := s.serv.RevokeEmergency(ctx, userID, in.GetId())
+	if err != nil {
+		return nil, emergencyError(err)
+	}
+	return &pb.ResponseEmergency{}, nil
+}
+
+func (s KeeperServiceService) ListEmergency(ctx context.Context, in *pb.EmergencyListRequest) (*pb.EmergencyList, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	list, err := s.serv.Emergencies(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, `%v`, err)
+	}
+	var response pb.EmergencyList
+	for _, e := range list {
+		response.Emergency = append(response.Emergency, emergencyPb(e))
+	}
+	return &response, nil
+}
+
+func (s KeeperServiceService) EmergencyAccess(ctx context.Context, in *pb.EmergencyRequest) (*pb.EmergencyItems, error) {
+	userID, ok := ctx.Value(interceptor.UserIdValue{}).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, `%s`, "no USERID")
+	}
+
+	items, err := s.serv.EmergencyItems(ctx, userID, in.GetId())
+	if err != nil {
+		return nil, emergencyError(err)
+	}
+	var response pb.EmergencyItems
+	for _, item := range items {
+		response.Items = append(response.Items, &pb.EmergencyItem{
+			Uuid:       item.Uuid,
+			Type:       pb.TypeData(item.TypeData),
+			Metadata:   item.MetaData,
+			WrappedKey: item.WrappedKey,
+		})
+	}
+	return &response, nil
+}
+
+func emergencyPb(e *store.EmergencyContact) *pb.Emergency {
+	return &pb.Emergency{
+		Id:          e.Id,
+		Owner:       e.Owner,
+		Contact:     e.Contact,
+		State:       pb.EmergencyState(e.State),
+		WaitSeconds: int64(e.Wait / time.Second),
+		RequestedAt: expiryUnix(e.RequestedAt),
+		GrantedAt:   expiryUnix(e.GrantedAt),
+	}
+}
+
+// emergencyError - status of step of emergency access
+func emergencyError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrEmergencyState), errors.Is(err, service.ErrNoPublicKey):
+		return status.Errorf(codes.FailedPrecondition, `%v`, err)
+	case errors.Is(err, service.ErrShareSelf), errors.Is(err, service.ErrBadWait):
+		return status.Errorf(codes.InvalidArgument, `%v`, err)
+	case errors.Is(err, service.ErrNoEmergency), errors.Is(err, service.ErrNoRecipient):
+		return status.Errorf(codes.NotFound, `%v`, err)
+	}
+	return status.Errorf(codes.Internal, `%v`, err)
+}
@@ -0,0 +1,72 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Proxies - networks of proxies trusted to set X-Real-IP of client
+type Proxies []netip.Prefix
+
+// ParseProxies - comma-separated addresses or CIDR networks, empty - X-Real-IP is not trusted
+func ParseProxies(list string) (Proxies, error) {
+	var res Proxies
+	for _, s := range strings.Split(list, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if !strings.Contains(s, "/") {
+			addr, err := netip.ParseAddr(s)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, prefix.Masked())
+	}
+	return res, nil
+}
+
+// ClientAddress - address of peer, X-Real-IP if peer is trusted proxy
+func (p Proxies) ClientAddress(ctx context.Context) string {
+	pr, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	addr := pr.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	if !p.trusted(addr) {
+		return addr
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ip := md.Get("x-real-ip"); len(ip) > 0 && ip[0] != "" {
+		return ip[0]
+	}
+	return addr
+}
+
+func (p Proxies) trusted(addr string) bool {
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return false
+	}
+	for _, prefix := range p {
+		if prefix.Contains(ip.Unmap()) {
+			return true
+		}
+	}
+	return false
+}
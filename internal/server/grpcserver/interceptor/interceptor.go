@@ -4,7 +4,9 @@ package interceptor
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/4aleksei/gokeeper/internal/common/store"
 	"github.com/4aleksei/gokeeper/internal/server/service"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 
@@ -71,18 +73,39 @@ func isMethodLoginRegister(info *grpc.UnaryServerInfo) bool {
 		return true
 	case "/grpcgokeeper.KeeperService/RegisterUser":
 		return true
+	case "/grpcgokeeper.KeeperService/RedeemShare":
+		return true
+	case "/grpcgokeeper.KeeperService/RefreshToken":
+		return true
+	case "/grpcgokeeper.KeeperService/OpenRecovery", "/grpcgokeeper.KeeperService/RecoverAccount":
+		return true
 	default:
 		return false
 	}
 }
 
-func (a *authInterceptor) UnaryAuthMiddleware(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-	// get metadata object
-	fmt.Printf("Intercepting call to: %s\n", info.FullMethod)
-	if isMethodLoginRegister(info) {
-		return handler(ctx, req)
-	}
+// apiTokenMethods - methods of api tokens with permission they require, other methods need login
+var apiTokenMethods = map[string]int{
+	"/grpcgokeeper.KeeperService/GetData":         store.PermRead,
+	"/grpcgokeeper.KeeperService/DownloadData":    store.PermRead,
+	"/grpcgokeeper.KeeperService/GetList":         store.PermRead,
+	"/grpcgokeeper.KeeperService/Search":          store.PermRead,
+	"/grpcgokeeper.KeeperService/GetUsage":        store.PermRead,
+	"/grpcgokeeper.KeeperService/DueRotation":     store.PermRead,
+	"/grpcgokeeper.KeeperService/SharedWithMe":    store.PermRead,
+	"/grpcgokeeper.KeeperService/GetSealedData":   store.PermRead,
+	"/grpcgokeeper.KeeperService/ListCollections": store.PermRead,
+	"/grpcgokeeper.KeeperService/CollectionItems": store.PermRead,
+	"/grpcgokeeper.KeeperService/AddData":         store.PermReadWrite,
+	"/grpcgokeeper.KeeperService/UploadData":      store.PermReadWrite,
+	"/grpcgokeeper.KeeperService/QueryUpload":     store.PermReadWrite,
+	"/grpcgokeeper.KeeperService/UpdateData":      store.PermReadWrite,
+	"/grpcgokeeper.KeeperService/DeleteData":      store.PermReadWrite,
+	"/grpcgokeeper.KeeperService/SetRotation":     store.PermReadWrite,
+}
 
+// authorize - context of request with user ID of token, api token adds its scope, its permission is checked by method
+func (a *authInterceptor) authorize(ctx context.Context, method string) (context.Context, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, status.Error(codes.Unauthenticated, "metadata is not provided")
@@ -94,44 +117,53 @@ func (a *authInterceptor) UnaryAuthMiddleware(ctx context.Context, req any, info
 		return nil, status.Error(codes.Unauthenticated, "authorization token is not provided")
 	}
 
-	// validate token and retrieve the userID
+	if strings.HasPrefix(token[0], service.APITokenPrefix) {
+		t, err := a.serv.CheckAPIToken(ctx, token[0])
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		perm, ok := apiTokenMethods[method]
+		if !ok || t.Permission < perm {
+			return nil, status.Error(codes.PermissionDenied, "method is out of scope of api token")
+		}
+		return context.WithValue(a.serv.WithScope(ctx, t), UserIdValue{}, t.User), nil
+	}
+
+	// validate token and retrieve the userID, revoked tokens are rejected
 	userID, err := a.serv.CheckToken(ctx, token[0])
 	if err != nil {
 		return nil, status.Error(codes.Unauthenticated, "invalid token")
 	}
 	// add our user ID to the context, so we can use it in our RPC handler
-	ctx = context.WithValue(ctx, UserIdValue{}, userID)
-
-	// call our handler
-	return handler(ctx, req)
+	return context.WithValue(ctx, UserIdValue{}, userID), nil
 }
 
-func (a *authInterceptor) StreamAuthMiddleware(req any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-	ctx := stream.Context()
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return status.Error(codes.Unauthenticated, "metadata is not provided")
+func (a *authInterceptor) UnaryAuthMiddleware(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	// get metadata object
+	fmt.Printf("Intercepting call to: %s\n", info.FullMethod)
+	if isMethodLoginRegister(info) {
+		return handler(ctx, req)
 	}
 
-	// extract token from authorization header
-	token := md["authorization"]
-	if len(token) == 0 {
-		return status.Error(codes.Unauthenticated, "authorization token is not provided")
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
 	}
 
-	// validate token and retrieve the userID
-	userID, err := a.serv.CheckToken(ctx, token[0])
+	// call our handler
+	return handler(ctx, req)
+}
+
+func (a *authInterceptor) StreamAuthMiddleware(req any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(stream.Context(), info.FullMethod)
 	if err != nil {
-		return status.Error(codes.Unauthenticated, "invalid token")
+		return err
 	}
 
 	serverStream := &grpcmiddleware.WrappedServerStream{
 		ServerStream:   stream,
-		WrappedContext: context.WithValue(ctx, UserIdValue{}, userID),
+		WrappedContext: ctx,
 	}
-	// add our user ID to the context, so we can use it in our RPC handler
-	//ctx = context.WithValue(ctx, UserIdValue{}, userID)
-	//stream.Context().WithValue()
 
 	return handler(req, serverStream)
 }
@@ -0,0 +1,189 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/4aleksei/gokeeper/internal/server/service"
+	pb "github.com/4aleksei/gokeeper/pkg/api/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxLockout   = 24 * time.Hour
+	maxShift     = 20
+	pruneEvery   = time.Minute
+	RetryAfterMD = "retry-after"
+	addrKey      = "ip:"
+	userKey      = "user:"
+)
+
+type (
+	// limitInterceptor - failed logins and registrations per address and per account,
+	// key is locked after attempts failures, lockout doubles on each next lock
+	limitInterceptor struct {
+		mx       sync.Mutex
+		attempts int
+		lockout  time.Duration
+		keys     map[string]*failures
+		pruned   time.Time
+		proxies  Proxies
+		serv     *service.HandlerService
+		l        *zap.Logger
+	}
+
+	failures struct {
+		count int
+		locks int
+		until time.Time
+		last  time.Time
+	}
+)
+
+// NewLimitInterceptor - attempts 0 - limiter is off, X-Real-IP of trusted proxies is address of client,
+// account of second step of login is user of its challenge
+func NewLimitInterceptor(attempts int, lockout time.Duration, proxies Proxies, s *service.HandlerService, l *zap.Logger) *limitInterceptor {
+	return &limitInterceptor{
+		attempts: attempts,
+		lockout:  lockout,
+		keys:     make(map[string]*failures),
+		proxies:  proxies,
+		serv:     s,
+		l:        l,
+	}
+}
+
+func isMethodLimited(info *grpc.UnaryServerInfo) bool {
+	switch info.FullMethod {
+	case "/grpcgokeeper.KeeperService/LoginUser":
+		return true
+	case "/grpcgokeeper.KeeperService/RegisterUser":
+		return true
+	case "/grpcgokeeper.KeeperService/ChangePassword", "/grpcgokeeper.KeeperService/DeleteAccount":
+		return true
+	case "/grpcgokeeper.KeeperService/OpenRecovery", "/grpcgokeeper.KeeperService/RecoverAccount":
+		return true
+	default:
+		return false
+	}
+}
+
+// limitKeys - address of client, name of account for login and recovery,
+// code of second factor is counted for account of challenge, password and code share one key
+func (a *limitInterceptor) limitKeys(ctx context.Context, req any, info *grpc.UnaryServerInfo) []string {
+	keys := make([]string, 0, 2)
+	if addr := a.proxies.ClientAddress(ctx); addr != "" {
+		keys = append(keys, addrKey+addr)
+	}
+	var name string
+	switch in := req.(type) {
+	case *pb.LoginRequest:
+		if info.FullMethod != "/grpcgokeeper.KeeperService/LoginUser" {
+			break
+		}
+		name = in.GetName()
+		if name == "" && in.GetChallenge() != "" && a.serv != nil {
+			if user, err := a.serv.ChallengeUser(ctx, in.GetChallenge()); err == nil {
+				name = user.Name
+			}
+		}
+	case *pb.RecoveryRequest:
+		name = in.GetName()
+	}
+	if name != "" {
+		keys = append(keys, userKey+name)
+	}
+	return keys
+}
+
+// locked - longest lockout left of keys
+func (a *limitInterceptor) locked(keys []string, now time.Time) time.Duration {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+	var left time.Duration
+	for _, k := range keys {
+		if f, ok := a.keys[k]; ok && f.until.After(now) {
+			left = max(left, f.until.Sub(now))
+		}
+	}
+	return left
+}
+
+// fail - failure of keys, key is locked after attempts failures
+func (a *limitInterceptor) fail(keys []string, method string, now time.Time) {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+	a.prune(now)
+	for _, k := range keys {
+		f, ok := a.keys[k]
+		if !ok {
+			f = &failures{}
+			a.keys[k] = f
+		}
+		f.count++
+		f.last = now
+		if f.count < a.attempts {
+			continue
+		}
+		lockout := min(a.lockout<<min(f.locks, maxShift), maxLockout)
+		f.until = now.Add(lockout)
+		f.count = 0
+		f.locks++
+		a.l.Warn("lockout", zap.String("key", k), zap.String("method", method),
+			zap.Int("locks", f.locks), zap.Duration("lockout", lockout))
+	}
+}
+
+// success - login of account resets its failures, failures of address are kept
+func (a *limitInterceptor) success(keys []string) {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+	for _, k := range keys {
+		if strings.HasPrefix(k, userKey) {
+			delete(a.keys, k)
+		}
+	}
+}
+
+// prune - keys without failures for maxLockout after lockout
+func (a *limitInterceptor) prune(now time.Time) {
+	if now.Sub(a.pruned) < pruneEvery {
+		return
+	}
+	a.pruned = now
+	for k, f := range a.keys {
+		if now.Sub(f.last) > maxLockout && !f.until.After(now) {
+			delete(a.keys, k)
+		}
+	}
+}
+
+func (a *limitInterceptor) UnaryLimitMiddleware(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if a.attempts <= 0 || !isMethodLimited(info) {
+		return handler(ctx, req)
+	}
+	keys := a.limitKeys(ctx, req, info)
+	if left := a.locked(keys, time.Now()); left > 0 {
+		retry := int64((left + time.Second - 1) / time.Second)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterMD, strconv.FormatInt(retry, 10)))
+		return nil, status.Error(codes.ResourceExhausted, fmt.Sprintf("too many failed attempts, retry after %ds", retry))
+	}
+	resp, err := handler(ctx, req)
+	if err != nil {
+		a.fail(keys, info.FullMethod, time.Now())
+		return nil, err
+	}
+	// password of login with second factor is not success until code is valid
+	if login, ok := resp.(*pb.LoginResponse); ok && login.GetToken() != "" {
+		a.success(keys)
+	}
+	return resp, nil
+}
@@ -6,16 +6,23 @@ import (
 
 	"github.com/4aleksei/gokeeper/internal/server/config"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 )
 
 type (
 	AuthService struct {
 		secretKey string
+		tokenExp  time.Duration
 	}
 
+	// Claims - ID is jti of token, Version - token version of user, tokens of older version are revoked,
+	// Session - login of token, Partial - challenge of login waiting for second factor, it is not access token
 	Claims struct {
 		jwt.RegisteredClaims
-		UserID uint64
+		UserID  uint64
+		Version int
+		Session string
+		Partial bool `json:",omitempty"`
 	}
 )
 
@@ -25,23 +32,27 @@ var (
 	ErrNoDB                  = errors.New("no db")
 )
 
-const tokenExp = time.Hour * 3
-
 func New(cfg *config.Config) *AuthService {
 	return &AuthService{
 		secretKey: cfg.Key,
+		tokenExp:  cfg.TokenTTL,
 	}
 }
 
-func (a *AuthService) BuildJWT(userID uint64) (string, error) {
+// BuildJWT - access token of user of token version and session valid until expiresAt
+func (a *AuthService) BuildJWT(userID uint64, version int, session string, expiresAt time.Time) (string, error) {
 	// создаём новый токен с алгоритмом подписи HS256 и утверждениями — Claims
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			// когда создан токен
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(tokenExp)),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        uuid.New().String(),
 		},
 		// собственное утверждение
-		UserID: userID,
+		UserID:  userID,
+		Version: version,
+		Session: session,
 	})
 	// создаём строку токена
 	tokenString, err := token.SignedString([]byte(a.secretKey))
@@ -53,6 +64,29 @@ func (a *AuthService) BuildJWT(userID uint64) (string, error) {
 }
 
 func (a *AuthService) GetUserID(tokenString string) (uint64, error) {
+	claims, err := a.GetClaims(tokenString)
+	if err != nil {
+		return 0, err
+	}
+	return claims.UserID, nil
+}
+
+// BuildChallenge - challenge of login of user of token version waiting for second factor valid until expiresAt
+func (a *AuthService) BuildChallenge(userID uint64, version int, expiresAt time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			ID:        uuid.New().String(),
+		},
+		UserID:  userID,
+		Version: version,
+		Partial: true,
+	})
+	return token.SignedString([]byte(a.secretKey))
+}
+
+// GetClaims - claims of token with valid signature and expiry
+func (a *AuthService) GetClaims(tokenString string) (*Claims, error) {
 	// создаём экземпляр структуры с утверждениями
 	claims := &Claims{}
 	// парсим из строки токена tokenString в структуру claims
@@ -64,11 +98,16 @@ func (a *AuthService) GetUserID(tokenString string) (uint64, error) {
 	})
 
 	if err != nil {
-		return 0, ErrTokenError
+		return nil, ErrTokenError
 	}
 
 	if !token.Valid {
-		return 0, ErrTokenError
+		return nil, ErrTokenError
 	}
-	return claims.UserID, nil
+	return claims, nil
+}
+
+// Expiry - expiry time of access token issued now
+func (a *AuthService) Expiry() time.Time {
+	return time.Now().Add(a.tokenExp)
 }
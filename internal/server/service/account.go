@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/4aleksei/gokeeper/internal/common/datafile"
+	"github.com/4aleksei/gokeeper/internal/common/store"
+	"go.uber.org/zap"
+)
+
+// ExportAccount - items of user without expired ones, items go before their attachments,
+// item is read by GetData, blob by GetDataStream
+func (serv *HandlerService) ExportAccount(ctx context.Context, userId uint64) ([]*store.UserDataCrypt, error) {
+	list, err := serv.store.GetListData(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	res := make([]*store.UserDataCrypt, 0, len(list))
+	for _, dataEnc := range list {
+		if !expired(dataEnc, now) {
+			res = append(res, dataEnc)
+		}
+	}
+	slices.SortStableFunc(res, func(a, b *store.UserDataCrypt) int { return a.TimeStamp.Compare(b.TimeStamp) })
+	slices.SortStableFunc(res, parentsFirst)
+	return res, nil
+}
+
+// parentsFirst - items before attachments
+func parentsFirst(a, b *store.UserDataCrypt) int {
+	switch {
+	case a.Parent == "" && b.Parent != "":
+		return -1
+	case a.Parent != "" && b.Parent == "":
+		return 1
+	}
+	return 0
+}
+
+// DeleteAccount - password is confirmed, user is removed with items, blob files, open uploads, shares,
+// one-time secrets, memberships, emergency access, sessions and tokens,
+// last owner of organization transfers ownership before
+func (serv *HandlerService) DeleteAccount(ctx context.Context, userId uint64, password string) error {
+	user, err := serv.store.GetUserByID(ctx, userId)
+	if err != nil {
+		return err
+	}
+	_, err = serv.LoginUser(ctx, user.Name, password)
+	if err != nil {
+		return err
+	}
+	members, err := serv.store.GetMemberships(ctx, userId)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		if member.Role == store.RoleOwner {
+			err = serv.keepOwner(ctx, member.Org, userId)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	err = serv.deleteItems(ctx, userId)
+	if err != nil {
+		return err
+	}
+	err = serv.deleteUploads(ctx, userId)
+	if err != nil {
+		return err
+	}
+	err = serv.deleteLinks(ctx, userId)
+	if err != nil {
+		return err
+	}
+	err = serv.deleteTokens(ctx, userId)
+	if err != nil {
+		return err
+	}
+	err = serv.store.DeleteUser(ctx, userId)
+	if err != nil {
+		return err
+	}
+	serv.l.Info("account deleted", zap.Uint64("user", userId))
+	return nil
+}
+
+// deleteItems - items of user with attachments and blob files
+func (serv *HandlerService) deleteItems(ctx context.Context, userId uint64) error {
+	list, err := serv.store.GetListData(ctx, userId)
+	if err != nil {
+		return err
+	}
+	deleted := make(map[string]bool)
+	// attachments of items of other members are deleted last
+	slices.SortStableFunc(list, parentsFirst)
+	for _, dataEnc := range list {
+		if deleted[dataEnc.Uuid] {
+			continue
+		}
+		uuids, err := serv.deleteItem(ctx, dataEnc)
+		for _, uuid := range uuids {
+			deleted[uuid] = true
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteUploads - open uploads of user with their staged files or chunks
+func (serv *HandlerService) deleteUploads(ctx context.Context, userId uint64) error {
+	uploads, err := serv.store.GetAllUploads(ctx)
+	if err != nil {
+		return err
+	}
+	for _, upload := range uploads {
+		if upload.UserId != userId {
+			continue
+		}
+		switch {
+		case upload.Data.Chunked && serv.chunks != nil:
+			err = serv.chunks.Release(upload.Data.Chunks)
+		case !upload.Data.Chunked:
+			var dataUser *store.UserData
+			dataUser, _, err = serv.encoder.Decrypt(upload.Data)
+			if err == nil {
+				err = datafile.Remove(dataUser.UserData)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		err = serv.store.DeleteUpload(ctx, userId, upload.Id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteLinks - shares to user, one-time secrets of user, memberships in organizations with rotation of keys
+// of their collections and emergency access of user as owner or as contact
+func (serv *HandlerService) deleteLinks(ctx context.Context, userId uint64) error {
+	shares, err := serv.store.GetSharesTo(ctx, userId)
+	if err != nil {
+		return err
+	}
+	for _, share := range shares {
+		err = serv.store.DeleteShare(ctx, share.Uuid, userId)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = serv.store.DeleteSecrets(ctx, userId)
+	if err != nil {
+		return err
+	}
+	members, err := serv.store.GetMemberships(ctx, userId)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		err = serv.leaveOrg(ctx, member)
+		if err != nil {
+			return err
+		}
+	}
+	grants, err := serv.store.GetEmergencies(ctx, userId)
+	if err != nil {
+		return err
+	}
+	for _, e := range grants {
+		err = serv.store.DeleteEmergency(ctx, e.Id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteTokens - sessions, refresh tokens and api tokens of user, access tokens of deleted user are rejected
+func (serv *HandlerService) deleteTokens(ctx context.Context, userId uint64) error {
+	sessions, err := serv.store.GetSessions(ctx, userId)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		_ = serv.store.DeleteSession(ctx, session.Id)
+	}
+	_, err = serv.store.DeleteRefreshTokens(ctx, userId)
+	if err != nil {
+		return err
+	}
+	tokens, err := serv.store.GetAPITokens(ctx, userId)
+	if err != nil {
+		return err
+	}
+	for _, t := range tokens {
+		err = serv.store.DeleteAPIToken(ctx, userId, t.Name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/4aleksei/gokeeper/internal/common/search"
+	"github.com/4aleksei/gokeeper/internal/common/store"
+	"github.com/4aleksei/gokeeper/internal/common/utils/random"
+	"go.uber.org/zap"
+)
+
+// APITokenPrefix - prefix of api tokens, auth tells them from JWT by it
+const APITokenPrefix = "gkt_"
+
+var (
+	ErrBadAPIToken = errors.New("error, api token is unknown or expired")
+	ErrNoAPIToken  = errors.New("error, api token not found")
+	ErrBadName     = errors.New("error, name is empty")
+	ErrTokenExists = errors.New("error, api token with this name exists")
+	ErrBadTag      = errors.New("error, tag is empty")
+)
+
+type (
+	scopeValue struct{}
+
+	// apiScope - api token of request, serv reads metadata of items for scope of tags
+	apiScope struct {
+		t    *store.APIToken
+		serv *HandlerService
+	}
+)
+
+// WithScope - api token of request, its scope restricts items of user
+func (serv *HandlerService) WithScope(ctx context.Context, t *store.APIToken) context.Context {
+	return context.WithValue(ctx, scopeValue{}, &apiScope{t: t, serv: serv})
+}
+
+// scope - api token of request, nil - request of login with full access
+func scope(ctx context.Context) *apiScope {
+	s, _ := ctx.Value(scopeValue{}).(*apiScope)
+	return s
+}
+
+// restricted - scope of token is limited to items, collections or tags
+func (s *apiScope) restricted() bool {
+	return len(s.t.Items) > 0 || len(s.t.Collections) > 0 || len(s.t.Tags) > 0
+}
+
+// inScope - item is in scope of api token of request with permission perm,
+// attachment is in scope of its parent
+func inScope(ctx context.Context, dataEnc *store.UserDataCrypt, perm int) bool {
+	s := scope(ctx)
+	if s == nil {
+		return true
+	}
+	t := s.t
+	if t.Permission < perm {
+		return false
+	}
+	if !s.restricted() {
+		return true
+	}
+	return slices.Contains(t.Items, dataEnc.Uuid) ||
+		(dataEnc.Parent != "" && slices.Contains(t.Items, dataEnc.Parent)) ||
+		(dataEnc.Collection != "" && slices.Contains(t.Collections, dataEnc.Collection)) ||
+		s.tagged(ctx, dataEnc)
+}
+
+// tagged - metadata of item or of its parent has tag of scope, tag is word of metadata
+// or blind token of word sent by client in end-to-end mode
+func (s *apiScope) tagged(ctx context.Context, dataEnc *store.UserDataCrypt) bool {
+	if len(s.t.Tags) == 0 {
+		return false
+	}
+	if dataEnc.Parent != "" {
+		parent, err := s.serv.store.GetData(ctx, dataEnc.Parent)
+		if err != nil {
+			return false
+		}
+		dataEnc = parent
+	}
+	words := dataEnc.Tokens
+	if dataEnc.EnKey != "" {
+		dataUser, _, err := s.serv.encoder.Decrypt(dataEnc)
+		if err == nil {
+			words = append(search.Normalize(dataUser.MetaData), words...)
+		}
+	}
+	return slices.ContainsFunc(s.t.Tags, func(tag string) bool { return slices.Contains(words, tag) })
+}
+
+// collectionInScope - collection is in scope of api token of request with permission perm,
+// token restricted to items or tags has no collections
+func collectionInScope(ctx context.Context, uuid string, perm int) bool {
+	s := scope(ctx)
+	if s == nil {
+		return true
+	}
+	if s.t.Permission < perm {
+		return false
+	}
+	if !s.restricted() {
+		return true
+	}
+	return slices.Contains(s.t.Collections, uuid)
+}
+
+// checkScope - new item is added by api token into collection of its scope, as attachment of item of its scope
+// or with tag of its scope, words of metadata of new item are matched as its tokens
+func checkScope(ctx context.Context, dataUser *store.UserData) error {
+	dataEnc := &store.UserDataCrypt{Parent: dataUser.Parent, Collection: dataUser.Collection,
+		Tokens: append(search.Normalize(dataUser.MetaData), dataUser.Tokens...)}
+	if !inScope(ctx, dataEnc, store.PermReadWrite) {
+		return ErrIncorectUserId
+	}
+	return nil
+}
+
+// CreateAPIToken - named token of user with permission and scope of items, collections and tags, ttl 0 - token does not expire,
+// token is returned once, server keeps its hash
+func (serv *HandlerService) CreateAPIToken(ctx context.Context, userId uint64, name string, perm int,
+	items []string, collections []string, tags []string, ttl time.Duration) (string, *store.APIToken, error) {
+	if name == "" {
+		return "", nil, ErrBadName
+	}
+	if perm != store.PermRead && perm != store.PermReadWrite {
+		return "", nil, ErrBadPermission
+	}
+	tokens, err := serv.store.GetAPITokens(ctx, userId)
+	if err != nil {
+		return "", nil, err
+	}
+	if slices.ContainsFunc(tokens, func(t *store.APIToken) bool { return t.Name == name }) {
+		return "", nil, ErrTokenExists
+	}
+	for _, uuid := range items {
+		_, err := serv.access(ctx, userId, uuid, perm)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	for _, uuid := range collections {
+		_, err := serv.collectionRole(ctx, userId, uuid)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	var normalized []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			return "", nil, ErrBadTag
+		}
+		normalized = append(normalized, tag)
+	}
+	b, err := random.GenerateRandom(refreshTokenSize)
+	if err != nil {
+		return "", nil, err
+	}
+	token := APITokenPrefix + hex.EncodeToString(b)
+	t := &store.APIToken{
+		Id:          secretHash(token),
+		User:        userId,
+		Name:        name,
+		Permission:  perm,
+		Items:       items,
+		Collections: collections,
+		Tags:        normalized,
+	}
+	if ttl > 0 {
+		t.ExpiresAt = time.Now().Add(ttl)
+	}
+	err = serv.store.AddAPIToken(ctx, t)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, t, nil
+}
+
+// CheckAPIToken - api token of request, last use time is updated
+func (serv *HandlerService) CheckAPIToken(ctx context.Context, token string) (*store.APIToken, error) {
+	t, err := serv.store.GetAPIToken(ctx, secretHash(token))
+	if err != nil {
+		return nil, ErrBadAPIToken
+	}
+	now := time.Now()
+	if !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt) {
+		return nil, ErrBadAPIToken
+	}
+	_, err = serv.store.GetUserByID(ctx, t.User)
+	if err != nil {
+		return nil, ErrBadAPIToken
+	}
+	_ = serv.store.TouchAPIToken(ctx, t.Id, now)
+	return t, nil
+}
+
+// APITokens - api tokens of user without expired ones
+func (serv *HandlerService) APITokens(ctx context.Context, userId uint64) ([]*store.APIToken, error) {
+	list, err := serv.store.GetAPITokens(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	res := make([]*store.APIToken, 0, len(list))
+	for _, t := range list {
+		if t.ExpiresAt.IsZero() || now.Before(t.ExpiresAt) {
+			res = append(res, t)
+		}
+	}
+	slices.SortFunc(res, func(a, b *store.APIToken) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return res, nil
+}
+
+// RevokeAPIToken - api token of user by name
+func (serv *HandlerService) RevokeAPIToken(ctx context.Context, userId uint64, name string) error {
+	err := serv.store.DeleteAPIToken(ctx, userId, name)
+	if err != nil {
+		return ErrNoAPIToken
+	}
+	return nil
+}
+
+// sweepAPITokens - delete expired api tokens
+func (serv *HandlerService) sweepAPITokens(ctx context.Context) {
+	n, err := serv.store.DeleteExpiredAPITokens(ctx, time.Now())
+	if err != nil {
+		serv.l.Error("expired api token sweep", zap.Error(err))
+		return
+	}
+	if n > 0 {
+		serv.l.Info("expired api token sweep", zap.Int("deleted", n))
+	}
+}
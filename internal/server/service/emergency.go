@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4aleksei/gokeeper/internal/common/cryptocerts"
+	"github.com/4aleksei/gokeeper/internal/common/store"
+)
+
+var (
+	ErrNoEmergency    = errors.New("error, emergency access not found")
+	ErrEmergencyState = errors.New("error, emergency access is not in state for this step")
+	ErrBadWait        = errors.New("error, waiting period of emergency access out of range")
+)
+
+// emergencyState - state of emergency access at now, request without denial is approved after Wait,
+// approved access expires after EmergencyGrant
+func (serv *HandlerService) emergencyState(e *store.Emergency, now time.Time) (int, time.Time) {
+	state, granted := e.State, e.GrantedAt
+	if state == store.EmergencyRequested && !now.Before(e.RequestedAt.Add(e.Wait)) {
+		state, granted = store.EmergencyApproved, e.RequestedAt.Add(e.Wait)
+	}
+	if state == store.EmergencyApproved && !now.Before(granted.Add(serv.cfg.EmergencyGrant)) {
+		state = store.EmergencyExpired
+	}
+	return state, granted
+}
+
+// current - emergency access with state at now
+func (serv *HandlerService) current(e *store.Emergency, now time.Time) *store.Emergency {
+	res := *e
+	res.State, res.GrantedAt = serv.emergencyState(e, now)
+	return &res
+}
+
+// emergencyContact - emergency access with names of owner and contact
+func (serv *HandlerService) emergencyContact(ctx context.Context, e *store.Emergency) *store.EmergencyContact {
+	res := &store.EmergencyContact{
+		Id:          e.Id,
+		Wait:        e.Wait,
+		State:       e.State,
+		RequestedAt: e.RequestedAt,
+		GrantedAt:   e.GrantedAt,
+	}
+	if user, err := serv.store.GetUserByID(ctx, e.Owner); err == nil {
+		res.Owner = user.Name
+	}
+	if user, err := serv.store.GetUserByID(ctx, e.Contact); err == nil {
+		res.Contact = user.Name
+	}
+	return res
+}
+
+// InviteEmergency - contact of owner for emergency access, wait 0 - EmergencyWait
+func (serv *HandlerService) InviteEmergency(ctx context.Context, userId uint64, contact string, wait time.Duration) (*store.EmergencyContact, error) {
+	if wait == 0 {
+		wait = serv.cfg.EmergencyWait
+	}
+	if wait < 0 {
+		return nil, ErrBadWait
+	}
+	user, err := serv.store.GetUser(ctx, contact)
+	if err != nil {
+		return nil, ErrNoRecipient
+	}
+	if user.Id == userId {
+		return nil, ErrShareSelf
+	}
+	if user.PublicKey == "" {
+		return nil, ErrNoPublicKey
+	}
+	e := &store.Emergency{
+		Owner:   userId,
+		Contact: user.Id,
+		Wait:    wait,
+		State:   store.EmergencyInvited,
+	}
+	err = serv.store.AddEmergency(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	return serv.emergencyContact(ctx, e), nil
+}
+
+// getEmergency - emergency access of owner or of contact at now
+func (serv *HandlerService) getEmergency(ctx context.Context, id string, owner bool, userId uint64, now time.Time) (*store.Emergency, error) {
+	e, err := serv.store.GetEmergency(ctx, id)
+	if err != nil {
+		return nil, ErrNoEmergency
+	}
+	if (owner && e.Owner != userId) || (!owner && e.Contact != userId) {
+		return nil, ErrNoEmergency
+	}
+	return serv.current(e, now), nil
+}
+
+// RequestEmergency - request of contact, waiting period of owner starts
+func (serv *HandlerService) RequestEmergency(ctx context.Context, userId uint64, id string) (*store.EmergencyContact, error) {
+	now := time.Now()
+	e, err := serv.getEmergency(ctx, id, false, userId, now)
+	if err != nil {
+		return nil, err
+	}
+	switch e.State {
+	case store.EmergencyInvited, store.EmergencyDenied, store.EmergencyExpired:
+	default:
+		return nil, ErrEmergencyState
+	}
+	e.State = store.EmergencyRequested
+	e.RequestedAt = now
+	e.GrantedAt = time.Time{}
+	err = serv.store.UpdateEmergency(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	return serv.emergencyContact(ctx, e), nil
+}
+
+// DecideEmergency - owner approves or denies request before end of waiting period
+func (serv *HandlerService) DecideEmergency(ctx context.Context, userId uint64, id string, approve bool) (*store.EmergencyContact, error) {
+	now := time.Now()
+	e, err := serv.getEmergency(ctx, id, true, userId, now)
+	if err != nil {
+		return nil, err
+	}
+	if e.State != store.EmergencyRequested {
+		return nil, ErrEmergencyState
+	}
+	e.State = store.EmergencyDenied
+	if approve {
+		e.State = store.EmergencyApproved
+		e.GrantedAt = now
+	}
+	err = serv.store.UpdateEmergency(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	return serv.emergencyContact(ctx, e), nil
+}
+
+// RevokeEmergency - delete emergency access, by owner or by contact itself
+func (serv *HandlerService) RevokeEmergency(ctx context.Context, userId uint64, id string) error {
+	e, err := serv.store.GetEmergency(ctx, id)
+	if err != nil || (e.Owner != userId && e.Contact != userId) {
+		return ErrNoEmergency
+	}
+	return serv.store.DeleteEmergency(ctx, id)
+}
+
+// Emergencies - emergency access of user as owner and as contact
+func (serv *HandlerService) Emergencies(ctx context.Context, userId uint64) ([]*store.EmergencyContact, error) {
+	list, err := serv.store.GetEmergencies(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	res := make([]*store.EmergencyContact, 0, len(list))
+	for _, e := range list {
+		res = append(res, serv.emergencyContact(ctx, serv.current(e, now)))
+	}
+	return res, nil
+}
+
+// EmergencyItems - personal items of owner with keys wrapped for contact of approved access
+func (serv *HandlerService) EmergencyItems(ctx context.Context, userId uint64, id string) ([]*store.EmergencyItem, error) {
+	now := time.Now()
+	e, err := serv.getEmergency(ctx, id, false, userId, now)
+	if err != nil {
+		return nil, err
+	}
+	if e.State != store.EmergencyApproved {
+		return nil, ErrEmergencyState
+	}
+	user, err := serv.store.GetUserByID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := cryptocerts.ParsePublicKey(user.PublicKey)
+	if err != nil {
+		return nil, ErrNoPublicKey
+	}
+	list, err := serv.store.GetListData(ctx, e.Owner)
+	if err != nil {
+		return nil, err
+	}
+	var res []*store.EmergencyItem
+	for _, dataEnc := range list {
+		if dataEnc.Collection != "" || dataEnc.Parent != "" || expired(dataEnc, now) {
+			continue
+		}
+		dataUser, key, err := serv.encoder.Decrypt(dataEnc)
+		if err != nil {
+			return nil, err
+		}
+		wrapped, err := key.WrapFor(pub)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, &store.EmergencyItem{
+			Uuid:       dataEnc.Uuid,
+			TypeData:   dataEnc.TypeData,
+			MetaData:   dataUser.MetaData,
+			WrappedKey: wrapped,
+		})
+	}
+	return res, nil
+}
+
+// emergencyGranted - contact has approved emergency access to items of owner
+func (serv *HandlerService) emergencyGranted(ctx context.Context, owner uint64, contact uint64) bool {
+	list, err := serv.store.GetEmergencies(ctx, contact)
+	if err != nil {
+		return false
+	}
+	now := time.Now()
+	for _, e := range list {
+		if e.Owner != owner || e.Contact != contact {
+			continue
+		}
+		if state, _ := serv.emergencyState(e, now); state == store.EmergencyApproved {
+			return true
+		}
+	}
+	return false
+}
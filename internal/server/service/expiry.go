@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/4aleksei/gokeeper/internal/common/store"
+	"go.uber.org/zap"
+)
+
+// expired - item with expiry time passed, it is invisible until sweeper deletes it
+func expired(dataEnc *store.UserDataCrypt, now time.Time) bool {
+	return !dataEnc.ExpiresAt.IsZero() && !now.Before(dataEnc.ExpiresAt)
+}
+
+// checkExpiry - expiry of new item is in future, zero - never expires
+func checkExpiry(dataUser *store.UserData) error {
+	if !dataUser.ExpiresAt.IsZero() && !time.Now().Before(dataUser.ExpiresAt) {
+		return ErrBadExpiry
+	}
+	return nil
+}
+
+// SweepExpired - delete expired items with attachments and blob files, count of deleted items
+func (serv *HandlerService) SweepExpired(ctx context.Context) (int, error) {
+	items, err := serv.store.GetAllData(ctx)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	deleted := make(map[string]bool)
+	// parents first, attachments are deleted with parent
+	for _, attachments := range []bool{false, true} {
+		for _, dataEnc := range items {
+			if ctx.Err() != nil {
+				return len(deleted), ctx.Err()
+			}
+			if (dataEnc.Parent != "") != attachments || deleted[dataEnc.Uuid] || !expired(dataEnc, now) {
+				continue
+			}
+			uuids, err := serv.deleteItem(ctx, dataEnc)
+			for _, uuid := range uuids {
+				deleted[uuid] = true
+			}
+			if err != nil {
+				serv.l.Error("expired item sweep", zap.String("uuid", dataEnc.Uuid), zap.Error(err))
+			}
+		}
+	}
+	return len(deleted), nil
+}
+
+// RunSweeper - delete expired items, secrets and refresh tokens every SweepInterval until ctx is done
+func (serv *HandlerService) RunSweeper(ctx context.Context) {
+	if serv.cfg.SweepInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(serv.cfg.SweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			serv.sweepSecrets(ctx)
+			serv.sweepTokens(ctx)
+			serv.sweepAPITokens(ctx)
+			n, err := serv.SweepExpired(ctx)
+			if err != nil {
+				serv.l.Error("expired item sweep", zap.Error(err))
+				continue
+			}
+			if n > 0 {
+				serv.l.Info("expired item sweep", zap.Int("deleted", n))
+			}
+		}
+	}
+}
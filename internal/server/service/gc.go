@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type (
+	// GCReport - result of one scan of blob files, orphans - files not referenced by any item or upload
+	GCReport struct {
+		DryRun      bool
+		Scanned     int
+		Orphans     []string
+		Bytes       int64
+		Removed     int
+		Quarantined int
+		Failures    int
+	}
+)
+
+const blobSuffix = ".data"
+
+// referencedFiles - base names of blob files of all items and open uploads
+func (serv *HandlerService) referencedFiles(ctx context.Context) (map[string]bool, error) {
+	refs := make(map[string]bool)
+	items, err := serv.store.GetAllData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uploads, err := serv.store.GetAllUploads(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, upload := range uploads {
+		items = append(items, upload.Data)
+	}
+	for _, dataEnc := range items {
+		if !dataEnc.Blob || dataEnc.Chunked {
+			continue
+		}
+		dataUser, _, err := serv.encoder.Decrypt(dataEnc)
+		if err != nil {
+			// unknown reference, nothing is safe to collect
+			return nil, err
+		}
+		refs[filepath.Base(dataUser.UserData)] = true
+	}
+	return refs, nil
+}
+
+// blobDirs - directories of blob files and prefix of file names
+func (serv *HandlerService) blobDirs() ([]string, string) {
+	dir, prefix := filepath.Split(serv.cfg.FilePath)
+	if dir == "" {
+		dir = "."
+	}
+	dirs := []string{dir}
+	if serv.cfg.ReplicaDir != "" {
+		dirs = append(dirs, serv.cfg.ReplicaDir)
+	}
+	return dirs, prefix
+}
+
+// CollectGarbage - remove or quarantine blob files older than grace period not referenced by storage,
+// dryRun - only report
+func (serv *HandlerService) CollectGarbage(ctx context.Context, dryRun bool) (*GCReport, error) {
+	// references are taken before scan, files written after it are younger than grace period
+	refs, err := serv.referencedFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := &GCReport{DryRun: dryRun}
+	deadline := time.Now().Add(-serv.cfg.GCGrace)
+	dirs, prefix := serv.blobDirs()
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if e.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, blobSuffix) {
+				continue
+			}
+			report.Scanned++
+			if refs[name] {
+				continue
+			}
+			info, err := e.Info()
+			if err != nil || info.ModTime().After(deadline) {
+				continue
+			}
+			path := filepath.Join(dir, name)
+			report.Orphans = append(report.Orphans, path)
+			report.Bytes += info.Size()
+			if dryRun {
+				continue
+			}
+			err = serv.dropFile(path, report)
+			if err != nil {
+				report.Failures++
+				serv.l.Error("blob gc", zap.String("file", path), zap.Error(err))
+			}
+		}
+	}
+	return report, nil
+}
+
+func (serv *HandlerService) dropFile(path string, report *GCReport) error {
+	if serv.cfg.GCQuarantine == "" {
+		err := os.Remove(path)
+		if err == nil {
+			report.Removed++
+		}
+		return err
+	}
+	err := os.MkdirAll(serv.cfg.GCQuarantine, 0o700)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(path, filepath.Join(serv.cfg.GCQuarantine, filepath.Base(path)))
+	if err == nil {
+		report.Quarantined++
+	}
+	return err
+}
+
+// RunGC - scan of blob files every GCInterval until ctx is done
+func (serv *HandlerService) RunGC(ctx context.Context) {
+	if serv.cfg.GCInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(serv.cfg.GCInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := serv.CollectGarbage(ctx, serv.cfg.GCDryRun)
+			if err != nil {
+				serv.l.Error("blob gc", zap.Error(err))
+				continue
+			}
+			serv.l.Info("blob gc", zap.Bool("dry-run", report.DryRun), zap.Int("scanned", report.Scanned),
+				zap.Strings("orphans", report.Orphans), zap.Int64("bytes", report.Bytes),
+				zap.Int("removed", report.Removed), zap.Int("quarantined", report.Quarantined),
+				zap.Int("failures", report.Failures))
+		}
+	}
+}
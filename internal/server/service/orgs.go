@@ -0,0 +1,421 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4aleksei/gokeeper/internal/common/aescoder"
+	"github.com/4aleksei/gokeeper/internal/common/cryptocerts"
+	"github.com/4aleksei/gokeeper/internal/common/store"
+)
+
+var (
+	ErrNotMember     = errors.New("error, user is not member of organization")
+	ErrNoRole        = errors.New("error, role of user does not permit action")
+	ErrBadRole       = errors.New("error, unknown role")
+	ErrLastOwner     = errors.New("error, organization must keep an owner")
+	ErrNoCollection  = errors.New("error, collection not found")
+	ErrNoOrg         = errors.New("error, organization not found")
+	ErrBadCollection = errors.New("error, attachment is in collection of its parent")
+)
+
+// CreateOrg - organization with user as owner
+func (serv *HandlerService) CreateOrg(ctx context.Context, userId uint64, name string) (*store.Org, error) {
+	org := &store.Org{Name: name}
+	err := serv.store.AddOrg(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	err = serv.store.SetMember(ctx, &store.Member{Org: org.Id, User: userId, Role: store.RoleOwner})
+	if err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// role - role of user in organization
+func (serv *HandlerService) role(ctx context.Context, userId uint64, org string) (int, error) {
+	member, err := serv.store.GetMember(ctx, org, userId)
+	if err != nil {
+		return 0, ErrNotMember
+	}
+	return member.Role, nil
+}
+
+// AddMember - new member or new role of member, admin grants roles not above own,
+// new member gets keys of collections, they are rotated with keys of their items
+func (serv *HandlerService) AddMember(ctx context.Context, userId uint64, org string, name string, role int) error {
+	if role < store.RoleOwner || role > store.RoleReadOnly {
+		return ErrBadRole
+	}
+	own, err := serv.role(ctx, userId, org)
+	if err != nil {
+		return err
+	}
+	if own > store.RoleAdmin || role < own {
+		return ErrNoRole
+	}
+	user, err := serv.store.GetUser(ctx, name)
+	if err != nil {
+		return ErrNoRecipient
+	}
+	old, err := serv.store.GetMember(ctx, org, user.Id)
+	if err == nil {
+		if old.Role < own {
+			return ErrNoRole
+		}
+		if old.Role == store.RoleOwner && role != store.RoleOwner {
+			err = serv.keepOwner(ctx, org, user.Id)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	err = serv.store.SetMember(ctx, &store.Member{Org: org, User: user.Id, Role: role})
+	if err != nil {
+		return err
+	}
+	if old != nil {
+		return nil
+	}
+	return serv.rotateOrg(ctx, org)
+}
+
+// RemoveMember - member leaves organization or is removed by admin, keys of collections and of their items are rotated,
+// blob files keep their item keys
+func (serv *HandlerService) RemoveMember(ctx context.Context, userId uint64, org string, name string) error {
+	user, err := serv.store.GetUser(ctx, name)
+	if err != nil {
+		return ErrNoRecipient
+	}
+	member, err := serv.store.GetMember(ctx, org, user.Id)
+	if err != nil {
+		return ErrNotMember
+	}
+	if user.Id != userId {
+		own, err := serv.role(ctx, userId, org)
+		if err != nil {
+			return err
+		}
+		if own > store.RoleAdmin || member.Role < own {
+			return ErrNoRole
+		}
+	}
+	return serv.leaveOrg(ctx, member)
+}
+
+// leaveOrg - member is deleted, organization keeps an owner, keys of collections are rotated
+func (serv *HandlerService) leaveOrg(ctx context.Context, member *store.Member) error {
+	if member.Role == store.RoleOwner {
+		err := serv.keepOwner(ctx, member.Org, member.User)
+		if err != nil {
+			return err
+		}
+	}
+	err := serv.store.DeleteMember(ctx, member.Org, member.User)
+	if err != nil {
+		return err
+	}
+	return serv.rotateOrg(ctx, member.Org)
+}
+
+// keepOwner - organization has owner other than user
+func (serv *HandlerService) keepOwner(ctx context.Context, org string, userId uint64) error {
+	members, err := serv.store.GetMembers(ctx, org)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		if member.Role == store.RoleOwner && member.User != userId {
+			return nil
+		}
+	}
+	return ErrLastOwner
+}
+
+// Members - members of organization by name
+func (serv *HandlerService) Members(ctx context.Context, userId uint64, org string) ([]*store.OrgMember, error) {
+	_, err := serv.role(ctx, userId, org)
+	if err != nil {
+		return nil, err
+	}
+	members, err := serv.store.GetMembers(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	var res []*store.OrgMember
+	for _, member := range members {
+		user, err := serv.store.GetUserByID(ctx, member.User)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, &store.OrgMember{Name: user.Name, Role: member.Role})
+	}
+	return res, nil
+}
+
+// CreateCollection - team vault of organization, its key is wrapped for each member
+func (serv *HandlerService) CreateCollection(ctx context.Context, userId uint64, org string, name string) (*store.Collection, error) {
+	_, err := serv.store.GetOrg(ctx, org)
+	if err != nil {
+		return nil, ErrNoOrg
+	}
+	own, err := serv.role(ctx, userId, org)
+	if err != nil {
+		return nil, err
+	}
+	if own > store.RoleAdmin {
+		return nil, ErrNoRole
+	}
+	key, err := serv.encoder.NewKey()
+	if err != nil {
+		return nil, err
+	}
+	collection := &store.Collection{Org: org, Name: name, Key: key.GetKey()}
+	collection.Keys, err = serv.wrapForMembers(ctx, org, key)
+	if err != nil {
+		return nil, err
+	}
+	err = serv.store.AddCollection(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// Collections - collections of organizations of user with collection key wrapped for user
+func (serv *HandlerService) Collections(ctx context.Context, userId uint64) ([]*store.CollectionKey, error) {
+	memberships, err := serv.store.GetMemberships(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	var res []*store.CollectionKey
+	for _, member := range memberships {
+		collections, err := serv.store.GetCollections(ctx, member.Org)
+		if err != nil {
+			return nil, err
+		}
+		for _, collection := range collections {
+			if !collectionInScope(ctx, collection.Uuid, store.PermRead) {
+				continue
+			}
+			res = append(res, &store.CollectionKey{
+				Uuid:       collection.Uuid,
+				Org:        collection.Org,
+				Name:       collection.Name,
+				Role:       member.Role,
+				Version:    collection.Version,
+				WrappedKey: collection.Keys[userId],
+			})
+		}
+	}
+	return res, nil
+}
+
+// CollectionItems - items of collection, expired items are hidden
+func (serv *HandlerService) CollectionItems(ctx context.Context, userId uint64, uuid string) ([]*store.CollectionItem, error) {
+	_, err := serv.collectionRole(ctx, userId, uuid)
+	if err != nil {
+		return nil, err
+	}
+	list, err := serv.store.GetCollectionData(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var res []*store.CollectionItem
+	for _, dataEnc := range list {
+		if dataEnc.Parent != "" || expired(dataEnc, now) || !inScope(ctx, dataEnc, store.PermRead) {
+			continue
+		}
+		dataUser, _, err := serv.encoder.Decrypt(dataEnc)
+		if err != nil {
+			return nil, err
+		}
+		var owner string
+		if user, err := serv.store.GetUserByID(ctx, dataEnc.Id); err == nil {
+			owner = user.Name
+		}
+		res = append(res, &store.CollectionItem{
+			Uuid:     dataEnc.Uuid,
+			Owner:    owner,
+			TypeData: dataEnc.TypeData,
+			MetaData: dataUser.MetaData,
+		})
+	}
+	return res, nil
+}
+
+// collectionRole - role of user in organization of collection
+func (serv *HandlerService) collectionRole(ctx context.Context, userId uint64, uuid string) (int, error) {
+	collection, err := serv.store.GetCollection(ctx, uuid)
+	if err != nil {
+		return 0, ErrNoCollection
+	}
+	return serv.role(ctx, userId, collection.Org)
+}
+
+// collectionKey - key of collection of new item, user adds items as member, nil - personal item
+func (serv *HandlerService) collectionKey(ctx context.Context, dataUser *store.UserData) (*aescoder.KeyAES, error) {
+	if dataUser.Collection == "" {
+		return nil, nil
+	}
+	own, err := serv.collectionRole(ctx, dataUser.Id, dataUser.Collection)
+	if err != nil {
+		return nil, err
+	}
+	if own > store.RoleMember {
+		return nil, ErrNoRole
+	}
+	return serv.openCollectionKey(ctx, dataUser.Collection)
+}
+
+// openCollectionKey - key of collection of item, nil - personal item
+func (serv *HandlerService) openCollectionKey(ctx context.Context, uuid string) (*aescoder.KeyAES, error) {
+	if uuid == "" {
+		return nil, nil
+	}
+	collection, err := serv.store.GetCollection(ctx, uuid)
+	if err != nil {
+		return nil, ErrNoCollection
+	}
+	return serv.encoder.OpenKey(collection.Key)
+}
+
+// manages - user manages item of collection: admin of organization or member who added item
+func (serv *HandlerService) manages(ctx context.Context, userId uint64, dataEnc *store.UserDataCrypt) bool {
+	own, err := serv.collectionRole(ctx, userId, dataEnc.Collection)
+	if err != nil {
+		return false
+	}
+	return own <= store.RoleAdmin || (dataEnc.Id == userId && own <= store.RoleMember)
+}
+
+// wrapItemKey - item key wrapped by collection key, empty for personal item
+func wrapItemKey(collectionKey *aescoder.KeyAES, key *aescoder.KeyAES) (string, error) {
+	if collectionKey == nil {
+		return "", nil
+	}
+	return collectionKey.Wrap(key)
+}
+
+// wrapForMembers - collection key wrapped by public keys of members, members without key get none
+func (serv *HandlerService) wrapForMembers(ctx context.Context, org string, key *aescoder.KeyAES) (map[uint64]string, error) {
+	members, err := serv.store.GetMembers(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[uint64]string, len(members))
+	for _, member := range members {
+		user, err := serv.store.GetUserByID(ctx, member.User)
+		if err != nil {
+			return nil, err
+		}
+		if user.PublicKey == "" {
+			continue
+		}
+		pub, err := cryptocerts.ParsePublicKey(user.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		keys[member.User], err = key.WrapFor(pub)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// rotateOrg - new keys of all collections of organization
+func (serv *HandlerService) rotateOrg(ctx context.Context, org string) error {
+	collections, err := serv.store.GetCollections(ctx, org)
+	if err != nil {
+		return err
+	}
+	for _, collection := range collections {
+		err = serv.rotateCollection(ctx, collection)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rotateCollection - new collection key, items get new item keys and their data is encrypted again,
+// keys of shares are wrapped again, blob files are not encrypted again and keep their item keys
+func (serv *HandlerService) rotateCollection(ctx context.Context, collection *store.Collection) error {
+	key, err := serv.encoder.NewKey()
+	if err != nil {
+		return err
+	}
+	list, err := serv.store.GetCollectionData(ctx, collection.Uuid)
+	if err != nil {
+		return err
+	}
+	for _, dataEnc := range list {
+		updated, itemKey, err := serv.rekeyItem(ctx, dataEnc)
+		if err != nil {
+			return err
+		}
+		updated.CollectionKey, err = key.Wrap(itemKey)
+		if err != nil {
+			return err
+		}
+		err = serv.store.UpdateData(ctx, updated)
+		if err != nil {
+			return err
+		}
+	}
+	collection.Keys, err = serv.wrapForMembers(ctx, collection.Org, key)
+	if err != nil {
+		return err
+	}
+	collection.Key = key.GetKey()
+	collection.Version++
+	return serv.store.UpdateCollection(ctx, collection)
+}
+
+// rekeyItem - item encrypted by new item key, shares of item get new key, blob keeps its key
+func (serv *HandlerService) rekeyItem(ctx context.Context, dataEnc *store.UserDataCrypt) (*store.UserDataCrypt, *aescoder.KeyAES, error) {
+	if dataEnc.Blob {
+		itemKey, err := serv.encoder.OpenKey(dataEnc.EnKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		updated := *dataEnc
+		return &updated, itemKey, nil
+	}
+	dataUser, _, err := serv.encoder.Decrypt(dataEnc)
+	if err != nil {
+		return nil, nil, err
+	}
+	updated, itemKey, err := serv.encoder.Encrypt(dataUser)
+	if err != nil {
+		return nil, nil, err
+	}
+	updated.TimeStamp = dataEnc.TimeStamp
+	shares, err := serv.store.GetSharesOf(ctx, dataEnc.Uuid)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, share := range shares {
+		user, err := serv.store.GetUserByID(ctx, share.Recipient)
+		if err != nil {
+			return nil, nil, err
+		}
+		pub, err := cryptocerts.ParsePublicKey(user.PublicKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		share.WrappedKey, err = itemKey.WrapFor(pub)
+		if err != nil {
+			return nil, nil, err
+		}
+		err = serv.store.AddShare(ctx, share)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return updated, itemKey, nil
+}
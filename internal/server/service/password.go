@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+
+	"github.com/4aleksei/gokeeper/internal/common/store"
+	"github.com/4aleksei/gokeeper/internal/common/utils/random"
+)
+
+var (
+	ErrBadRecovery  = errors.New("error, name or recovery key incorect")
+	ErrKeysRequired = errors.New("error, keys of user wrapped by new key are required")
+	ErrCodeRequired = errors.New("error, second factor is enabled, one-time code or recovery code is required")
+)
+
+// rewrap - keys of user wrapped by client by new key, user without key has no new key
+func rewrap(user *store.User, vaultKey string, privateKey string) error {
+	if (user.VaultKey != "" && vaultKey == "") || (user.PrivateKey != "" && privateKey == "") {
+		return ErrKeysRequired
+	}
+	if vaultKey != "" {
+		user.VaultKey = vaultKey
+	}
+	if privateKey != "" {
+		user.PrivateKey = privateKey
+	}
+	return nil
+}
+
+// ChangePassword - old password is verified, keys of user are wrapped by new master key, all sessions are revoked
+func (serv *HandlerService) ChangePassword(ctx context.Context, userId uint64, old string, password string,
+	vaultKey string, privateKey string) error {
+	user, err := serv.store.GetUserByID(ctx, userId)
+	if err != nil {
+		return err
+	}
+	_, err = serv.LoginUser(ctx, user.Name, old)
+	if err != nil {
+		return err
+	}
+	_, err = serv.modifyUser(ctx, userId, func(user *store.User) error {
+		user.HashPass = hex.EncodeToString(random.HashPass([]byte(password), serv.cfg.Key))
+		return rewrap(user, vaultKey, privateKey)
+	})
+	if err != nil {
+		return err
+	}
+	return serv.RevokeAll(ctx, userId)
+}
+
+// SetRecovery - recovery key of user, server keeps hash of secret derived from it and keys wrapped by it
+func (serv *HandlerService) SetRecovery(ctx context.Context, userId uint64, recovery string, vaultKey string, privateKey string) error {
+	if recovery == "" {
+		return ErrBadRecovery
+	}
+	_, err := serv.modifyUser(ctx, userId, func(user *store.User) error {
+		user.RecoveryHash = secretHash(recovery)
+		user.RecoveryVaultKey = vaultKey
+		user.RecoveryPrivateKey = privateKey
+		return nil
+	})
+	return err
+}
+
+// OpenRecovery - user of recovery key with keys wrapped by it, unknown user and wrong key are same error
+func (serv *HandlerService) OpenRecovery(ctx context.Context, name string, recovery string) (*store.User, error) {
+	user, err := serv.store.GetUser(ctx, name)
+	if err != nil || user.RecoveryHash == "" ||
+		subtle.ConstantTimeCompare([]byte(user.RecoveryHash), []byte(secretHash(recovery))) != 1 {
+		return nil, ErrBadRecovery
+	}
+	return user, nil
+}
+
+// RecoverAccount - new password of user by recovery key, keys of user are wrapped by new master key,
+// code of second factor is required if it is enabled, all sessions are revoked, recovery key stays valid
+func (serv *HandlerService) RecoverAccount(ctx context.Context, name string, recovery string, otp string, password string,
+	vaultKey string, privateKey string) (*store.User, error) {
+	user, err := serv.OpenRecovery(ctx, name, recovery)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPSecret != "" {
+		if otp == "" {
+			return nil, ErrCodeRequired
+		}
+		err = serv.SecondFactor(ctx, user, otp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	updated, err := serv.modifyUser(ctx, user.Id, func(user *store.User) error {
+		user.HashPass = hex.EncodeToString(random.HashPass([]byte(password), serv.cfg.Key))
+		return rewrap(user, vaultKey, privateKey)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = serv.RevokeAll(ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
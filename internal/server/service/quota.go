@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4aleksei/gokeeper/internal/common/store"
+)
+
+var (
+	ErrQuotaExceeded  = errors.New("error, storage quota of user exceeded")
+	ErrUploadTooLarge = errors.New("error, upload exceeds maximum size")
+)
+
+// GetUsage - stored items and bytes of user with configured limits
+func (serv *HandlerService) GetUsage(ctx context.Context, userId uint64) (*store.Usage, error) {
+	usage, err := serv.store.GetUsage(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	usage.QuotaItems = serv.cfg.QuotaItems
+	usage.QuotaBytes = serv.cfg.QuotaBytes
+	usage.MaxUpload = serv.cfg.MaxUploadSize
+	return usage, nil
+}
+
+// checkQuota - items and bytes added to stored data of user are in quota
+func (serv *HandlerService) checkQuota(ctx context.Context, userId uint64, items int64, bytes int64) error {
+	usage, err := serv.GetUsage(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if usage.QuotaItems > 0 && usage.Items+items > usage.QuotaItems {
+		return ErrQuotaExceeded
+	}
+	if usage.QuotaBytes > 0 && usage.Bytes+bytes > usage.QuotaBytes {
+		return ErrQuotaExceeded
+	}
+	return nil
+}
+
+// uploadLimit - maximum size of new blob of user, size - declared size, 0 - unknown,
+// result 0 - no limit
+func (serv *HandlerService) uploadLimit(ctx context.Context, userId uint64, size int64) (int64, error) {
+	if serv.cfg.MaxUploadSize > 0 && size > serv.cfg.MaxUploadSize {
+		return 0, ErrUploadTooLarge
+	}
+	err := serv.checkQuota(ctx, userId, 1, size)
+	if err != nil {
+		return 0, err
+	}
+	usage, err := serv.GetUsage(ctx, userId)
+	if err != nil {
+		return 0, err
+	}
+	limit := usage.MaxUpload
+	if usage.QuotaBytes > 0 {
+		rest := usage.QuotaBytes - usage.Bytes
+		if rest <= 0 {
+			return 0, ErrQuotaExceeded
+		}
+		if limit == 0 || rest < limit {
+			limit = rest
+		}
+	}
+	return limit, nil
+}
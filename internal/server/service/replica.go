@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"time"
+
+	"github.com/4aleksei/gokeeper/internal/common/aescoder"
+	"github.com/4aleksei/gokeeper/internal/common/datafile"
+	"github.com/4aleksei/gokeeper/internal/common/store"
+	"github.com/4aleksei/gokeeper/internal/common/streams/sources/replica"
+	"go.uber.org/zap"
+)
+
+type (
+	// RepairReport - result of one pass of replica repair
+	RepairReport struct {
+		Checked  int
+		Primary  int
+		Replica  int
+		Corrupt  int
+		Lost     int
+		Failures int
+	}
+)
+
+var ErrBlobLost = errors.New("error,blob lost in primary and replica")
+
+// pushUpload - copy staged file of upload to replica, move it to object store
+func (serv *HandlerService) pushUpload(ctx context.Context, data *store.UserDataCrypt, f *datafile.LongtermfileWrite) error {
+	if serv.replica != nil {
+		err := f.Push(ctx, serv.replica)
+		if err != nil {
+			if serv.cfg.ReplicaQuorum != 1 {
+				return err
+			}
+			serv.l.Warn("replica of upload not written", zap.Error(err))
+		}
+	}
+	if serv.objects != nil {
+		err := f.Move(ctx, replica.NewObjects(serv.objects))
+		if err != nil {
+			return err
+		}
+		data.Object = true
+	}
+	return nil
+}
+
+// primaryStore - store of blob file of item
+func (serv *HandlerService) primaryStore(dataUser *store.UserData) (replica.Store, error) {
+	if !dataUser.Object {
+		return replica.NewDir(filepath.Dir(dataUser.UserData))
+	}
+	if serv.objects == nil {
+		return nil, ErrNoObjectStore
+	}
+	return replica.NewObjects(serv.objects), nil
+}
+
+// RepairReplicas - copy missing blob files from primary to replica and back
+func (serv *HandlerService) RepairReplicas(ctx context.Context) (*RepairReport, error) {
+	report := &RepairReport{}
+	if serv.replica == nil {
+		return report, nil
+	}
+	items, err := serv.store.GetAllData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, dataEnc := range items {
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+		if !dataEnc.Blob || dataEnc.Chunked {
+			continue
+		}
+		report.Checked++
+		err = serv.repairOne(ctx, dataEnc, report)
+		if err != nil {
+			report.Failures++
+			serv.l.Error("replica repair", zap.String("uuid", dataEnc.Uuid), zap.Error(err))
+		}
+	}
+	return report, nil
+}
+
+func (serv *HandlerService) repairOne(ctx context.Context, dataEnc *store.UserDataCrypt, report *RepairReport) error {
+	dataUser, key, err := serv.encoder.Decrypt(dataEnc)
+	if err != nil {
+		return err
+	}
+	primary, err := serv.primaryStore(dataUser)
+	if err != nil {
+		return err
+	}
+	name := filepath.Base(dataUser.UserData)
+	inPrimary, err := serv.intact(ctx, primary, name, dataEnc, key, dataUser.Codec, report)
+	if err != nil {
+		return err
+	}
+	inReplica, err := serv.intact(ctx, serv.replica, name, dataEnc, key, dataUser.Codec, report)
+	if err != nil {
+		return err
+	}
+	switch {
+	case inPrimary && inReplica:
+		return nil
+	case inPrimary:
+		report.Replica++
+		return replica.Copy(ctx, primary, serv.replica, name)
+	case inReplica:
+		report.Primary++
+		return replica.Copy(ctx, serv.replica, primary, name)
+	}
+	report.Lost++
+	return ErrBlobLost
+}
+
+// intact - blob file exists in store and its plain size and checksum match item,
+// checksum is not compared for items stored before checksums
+func (serv *HandlerService) intact(ctx context.Context, from replica.Store, name string, dataEnc *store.UserDataCrypt,
+	key *aescoder.KeyAES, codec string, report *RepairReport) (bool, error) {
+	ok, err := from.Exists(ctx, name)
+	if err != nil || !ok {
+		return false, err
+	}
+	size, checksum, err := datafile.Verify(ctx, from, name, key, codec)
+	if err == nil && size == dataEnc.Size && (dataEnc.Checksum == "" || checksum == dataEnc.Checksum) {
+		return true, nil
+	}
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	report.Corrupt++
+	serv.l.Warn("replica repair, blob file corrupted", zap.String("uuid", dataEnc.Uuid), zap.Error(err))
+	return false, nil
+}
+
+// RunRepair - repair of replicas every ReplicaRepair interval until ctx is done
+func (serv *HandlerService) RunRepair(ctx context.Context) {
+	if serv.replica == nil || serv.cfg.ReplicaRepair <= 0 {
+		return
+	}
+	ticker := time.NewTicker(serv.cfg.ReplicaRepair)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := serv.RepairReplicas(ctx)
+			if err != nil {
+				serv.l.Error("replica repair", zap.Error(err))
+				continue
+			}
+			serv.l.Info("replica repair", zap.Int("checked", report.Checked),
+				zap.Int("primary", report.Primary), zap.Int("replica", report.Replica),
+				zap.Int("corrupt", report.Corrupt), zap.Int("lost", report.Lost), zap.Int("failures", report.Failures))
+		}
+	}
+}
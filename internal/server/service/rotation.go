@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/4aleksei/gokeeper/internal/common/store"
+)
+
+var (
+	ErrNotLogin    = errors.New("error, rotation policy is set for login items")
+	ErrBadRotation = errors.New("error, rotation policy is negative")
+	ErrBlobUpdate  = errors.New("error, data of blob item is not updated")
+)
+
+const day = 24 * time.Hour
+
+// UpdateData - replace data of item of owner or shared read-write, empty metadata is kept,
+// changed data restarts rotation period, item key is kept, keys wrapped for shares and collection stay valid
+func (serv *HandlerService) UpdateData(ctx context.Context, dataUser *store.UserData) error {
+	dataEnc, err := serv.access(ctx, dataUser.Id, dataUser.Uuid, store.PermReadWrite)
+	if err != nil {
+		return err
+	}
+	dataUser.Id = dataEnc.Id
+	if dataEnc.Blob {
+		return ErrBlobUpdate
+	}
+	old, key, err := serv.encoder.Decrypt(dataEnc)
+	if err != nil {
+		return err
+	}
+	if dataUser.MetaData == "" {
+		dataUser.MetaData = old.MetaData
+		dataUser.Tokens = old.Tokens
+	}
+	err = serv.checkQuota(ctx, dataUser.Id, 0,
+		int64(len(dataUser.UserData)+len(dataUser.MetaData)-len(old.UserData)-len(old.MetaData)))
+	if err != nil {
+		return err
+	}
+	dataUser.TypeData = old.TypeData
+	dataUser.Parent = old.Parent
+	dataUser.ExpiresAt = old.ExpiresAt
+	dataUser.Rotation = old.Rotation
+	dataUser.ChangedAt = old.ChangedAt
+	dataUser.Collection = old.Collection
+	if dataUser.UserData != old.UserData {
+		dataUser.ChangedAt = time.Now()
+	}
+	encDataUser, err := serv.encoder.EncryptWith(dataUser, key)
+	if err != nil {
+		return err
+	}
+	encDataUser.CollectionKey = dataEnc.CollectionKey
+	encDataUser.TimeStamp = dataEnc.TimeStamp
+	return serv.store.UpdateData(ctx, encDataUser)
+}
+
+// SetRotation - rotation policy of login items in days, uuid - login item or collection, collection policy
+// applies to its items without own policy and is set by admin of organization, empty uuid - default policy of user, 0 - none
+func (serv *HandlerService) SetRotation(ctx context.Context, userId uint64, uuid string, days int) error {
+	if days < 0 {
+		return ErrBadRotation
+	}
+	if uuid == "" {
+		// default policy of user is not in scope of api token
+		if scope(ctx) != nil {
+			return ErrIncorectUserId
+		}
+		_, err := serv.modifyUser(ctx, userId, func(user *store.User) error {
+			user.Rotation = days
+			return nil
+		})
+		return err
+	}
+	if collection, err := serv.store.GetCollection(ctx, uuid); err == nil {
+		if !collectionInScope(ctx, uuid, store.PermReadWrite) {
+			return ErrIncorectUserId
+		}
+		own, err := serv.role(ctx, userId, collection.Org)
+		if err != nil {
+			return err
+		}
+		if own > store.RoleAdmin {
+			return ErrNoRole
+		}
+		updated := *collection
+		updated.Rotation = days
+		return serv.store.UpdateCollection(ctx, &updated)
+	}
+	dataEnc, err := serv.getOwnData(ctx, userId, uuid)
+	if err != nil {
+		return err
+	}
+	if dataEnc.TypeData != store.LoginType {
+		return ErrNotLogin
+	}
+	updated := *dataEnc
+	updated.Rotation = days
+	return serv.store.UpdateData(ctx, &updated)
+}
+
+// DueRotation - login items of user with password older than rotation policy at time at, oldest first,
+// policy of item, else policy of its collection, else default policy of user
+func (serv *HandlerService) DueRotation(ctx context.Context, userId uint64, at time.Time) ([]*store.RotationDue, error) {
+	user, err := serv.store.GetUserByID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	list, err := serv.store.GetListData(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	var res []*store.RotationDue
+	collections := make(map[string]int)
+	for _, dataEnc := range list {
+		if dataEnc.TypeData != store.LoginType || expired(dataEnc, time.Now()) || !inScope(ctx, dataEnc, store.PermRead) {
+			continue
+		}
+		days := dataEnc.Rotation
+		if days == 0 && dataEnc.Collection != "" {
+			days = serv.collectionRotation(ctx, dataEnc.Collection, collections)
+		}
+		if days == 0 {
+			days = user.Rotation
+		}
+		if days == 0 {
+			continue
+		}
+		due := dataEnc.ChangedAt.Add(time.Duration(days) * day)
+		if at.Before(due) {
+			continue
+		}
+		dataUser, _, err := serv.encoder.Decrypt(dataEnc)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, &store.RotationDue{
+			Uuid:      dataEnc.Uuid,
+			MetaData:  dataUser.MetaData,
+			ChangedAt: dataEnc.ChangedAt,
+			DueAt:     due,
+			Rotation:  days,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].DueAt.Before(res[j].DueAt) })
+	return res, nil
+}
+
+// collectionRotation - rotation policy of collection, policies are cached in seen
+func (serv *HandlerService) collectionRotation(ctx context.Context, uuid string, seen map[string]int) int {
+	days, ok := seen[uuid]
+	if ok {
+		return days
+	}
+	if collection, err := serv.store.GetCollection(ctx, uuid); err == nil {
+		days = collection.Rotation
+	}
+	seen[uuid] = days
+	return days
+}
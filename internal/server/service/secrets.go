@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4aleksei/gokeeper/internal/common/store"
+	"go.uber.org/zap"
+)
+
+const (
+	maxSecretSize  int = 64 << 10
+	maxSecretViews int = 100
+)
+
+var (
+	ErrSecretSize = errors.New("error, secret is empty or too large")
+	ErrBadViews   = errors.New("error, view limit of secret out of range")
+)
+
+// CreateSecret - one-time secret of link, maxViews 0 - one view, zero expiry - SecretTTL,
+// expiry is not later than SecretTTL
+func (serv *HandlerService) CreateSecret(ctx context.Context, userId uint64, ciphertext string, maxViews int,
+	expiresAt time.Time) (*store.Secret, error) {
+	if len(ciphertext) == 0 || len(ciphertext) > maxSecretSize {
+		return nil, ErrSecretSize
+	}
+	if maxViews == 0 {
+		maxViews = 1
+	}
+	if maxViews < 0 || maxViews > maxSecretViews {
+		return nil, ErrBadViews
+	}
+	now := time.Now()
+	limit := now.Add(serv.cfg.SecretTTL)
+	if expiresAt.IsZero() || expiresAt.After(limit) {
+		expiresAt = limit
+	}
+	if !now.Before(expiresAt) {
+		return nil, ErrBadExpiry
+	}
+	secret := &store.Secret{
+		Owner:      userId,
+		Ciphertext: ciphertext,
+		MaxViews:   maxViews,
+		ExpiresAt:  expiresAt,
+	}
+	err := serv.store.AddSecret(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// RedeemSecret - ciphertext of secret, secret is destroyed after its last view
+func (serv *HandlerService) RedeemSecret(ctx context.Context, id string) (*store.Secret, error) {
+	secret, err := serv.store.TakeSecret(ctx, id, time.Now())
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	return secret, nil
+}
+
+// sweepSecrets - delete expired secrets not viewed
+func (serv *HandlerService) sweepSecrets(ctx context.Context) {
+	n, err := serv.store.DeleteExpiredSecrets(ctx, time.Now())
+	if err != nil {
+		serv.l.Error("expired secret sweep", zap.Error(err))
+		return
+	}
+	if n > 0 {
+		serv.l.Info("expired secret sweep", zap.Int("deleted", n))
+	}
+}
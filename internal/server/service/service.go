@@ -5,11 +5,18 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"path/filepath"
+	"time"
 
 	"github.com/4aleksei/gokeeper/internal/common/datafile"
 	"github.com/4aleksei/gokeeper/internal/common/interfaces/encoder"
 	"github.com/4aleksei/gokeeper/internal/common/interfaces/storage"
+	"github.com/4aleksei/gokeeper/internal/common/search"
 	"github.com/4aleksei/gokeeper/internal/common/store"
+	"github.com/4aleksei/gokeeper/internal/common/streams/compressors/codecs"
+	"github.com/4aleksei/gokeeper/internal/common/streams/sources/chunkstore"
+	"github.com/4aleksei/gokeeper/internal/common/streams/sources/replica"
+	"github.com/4aleksei/gokeeper/internal/common/streams/sources/s3object"
 	"github.com/4aleksei/gokeeper/internal/common/utils/random"
 	"github.com/4aleksei/gokeeper/internal/server/config"
 	"github.com/4aleksei/gokeeper/internal/server/jwtauth"
@@ -24,55 +31,151 @@ type (
 		auth    *jwtauth.AuthService
 		cfg     *config.Config
 		encoder encoder.ServerEncoder
+		chunks  *chunkstore.Store
+		objects *s3object.Client
+		replica replica.Store
+	}
+
+	// chunkIndex - reference count of chunks in storage
+	chunkIndex struct {
+		store storage.ServerStorage
 	}
 )
 
 var (
-	ErrPassIncorect   = errors.New("error, pass incorect")
+	ErrPassIncorect   = errors.New("error, name or pass incorect")
 	ErrIncorectUserId = errors.New("error, id user error")
+	ErrBadParent      = errors.New("error, parent item can not have attachments")
+	ErrBadOffset      = errors.New("error, offset is not committed offset of upload")
+	ErrSizeMismatch   = errors.New("error, uploaded size differs from declared size")
+	ErrBadRange       = errors.New("error, range out of data")
+	ErrChecksum       = errors.New("error, checksum of uploaded data differs from client checksum")
+	ErrNoChunkStore   = errors.New("error, chunk store is not enabled")
+	ErrNoObjectStore  = errors.New("error, object store is not enabled")
+	ErrNotFound       = errors.New("error, item not found")
+	ErrBadExpiry      = errors.New("error, expiry time is in the past")
 )
 
-func New(s storage.ServerStorage, enc encoder.ServerEncoder, l *zap.Logger, c *config.Config) *HandlerService {
-	return &HandlerService{
+func New(s storage.ServerStorage, enc encoder.ServerEncoder, l *zap.Logger, c *config.Config) (*HandlerService, error) {
+	serv := &HandlerService{
 		store:   s,
 		l:       l,
 		cfg:     c,
 		auth:    jwtauth.New(c),
 		encoder: enc,
 	}
+	if c.ChunkStore {
+		chunks, err := chunkstore.New(c.FilePath+".chunks", []byte(c.Key), chunkIndex{store: s}, c.ChunkSize)
+		if err != nil {
+			l.Error("chunk store init", zap.Error(err))
+			return nil, err
+		}
+		serv.chunks = chunks
+	}
+	if c.S3Endpoint != "" {
+		objects, err := s3object.New(s3object.Config{
+			Endpoint:  c.S3Endpoint,
+			Bucket:    c.S3Bucket,
+			Region:    c.S3Region,
+			AccessKey: c.S3AccessKey,
+			SecretKey: c.S3SecretKey,
+			PartSize:  c.S3PartSize,
+		})
+		if err != nil {
+			l.Error("object store init", zap.Error(err))
+			return nil, err
+		}
+		if c.ReplicaS3 {
+			serv.replica = replica.NewObjects(objects)
+		} else {
+			serv.objects = objects
+		}
+	}
+	if c.ReplicaDir != "" {
+		if serv.replica != nil {
+			return nil, config.ErrReplicaConflict
+		}
+		dir, err := replica.NewDir(c.ReplicaDir)
+		if err != nil {
+			l.Error("replica init", zap.Error(err))
+			return nil, err
+		}
+		serv.replica = dir
+	}
+	return serv, nil
+}
+
+func (c chunkIndex) Acquire(id string) (int64, error) {
+	return c.store.AcquireChunk(context.Background(), id)
+}
+
+func (c chunkIndex) Release(id string) (int64, error) {
+	return c.store.ReleaseChunk(context.Background(), id)
 }
 
 func (serv *HandlerService) LoginUser(ctx context.Context, user string, password string) (*store.User, error) {
+	// unknown user and wrong password are same error
+	pass := random.HashPass([]byte(password), serv.cfg.Key)
 	passValue, err := serv.store.GetUser(ctx, user)
 	if err != nil {
-		return nil, err
+		return nil, ErrPassIncorect
 	}
-	pass := random.HashPass([]byte(password), serv.cfg.Key)
 	if passValue.HashPass == hex.EncodeToString(pass) {
 		return passValue, nil
 	}
 	return nil, ErrPassIncorect
 }
 
-func (serv *HandlerService) RegisterUser(ctx context.Context, user string, password string) (*store.User, error) {
+func (serv *HandlerService) RegisterUser(ctx context.Context, user string, password string, vaultKey string) (*store.User, error) {
 	pass := random.HashPass([]byte(password), serv.cfg.Key)
-	value, err := serv.store.AddUser(ctx, user, hex.EncodeToString(pass))
-	if err != nil {
-		return nil, err
-	}
-	return value, nil
+	return serv.store.AddUser(ctx, user, hex.EncodeToString(pass), vaultKey)
 }
 
-func (serv *HandlerService) BuildToken(ctx context.Context, user *store.User) (string, error) {
-	return serv.auth.BuildJWT(user.Id)
-}
-
-func (serv *HandlerService) CheckToken(ctx context.Context, token string) (uint64, error) {
-	return serv.auth.GetUserID(token)
+// modifyUser - fn changes copy of user, copy replaces user if user is not changed meanwhile,
+// else fn is applied to user read again, concurrent changes are not lost
+func (serv *HandlerService) modifyUser(ctx context.Context, userId uint64, fn func(*store.User) error) (*store.User, error) {
+	for {
+		user, err := serv.store.GetUserByID(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+		updated := *user
+		err = fn(&updated)
+		if err != nil {
+			return nil, err
+		}
+		ok, err := serv.store.SwapUser(ctx, user, &updated)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return &updated, nil
+		}
+	}
 }
 
 func (serv *HandlerService) AddData(ctx context.Context, dataUser *store.UserData) (string, error) {
-	encDataUser, _, err := serv.encoder.Encrypt(dataUser)
+	err := checkExpiry(dataUser)
+	if err != nil {
+		return "", err
+	}
+	err = checkScope(ctx, dataUser)
+	if err != nil {
+		return "", err
+	}
+	err = serv.checkQuota(ctx, dataUser.Id, 1, int64(len(dataUser.UserData)+len(dataUser.MetaData)))
+	if err != nil {
+		return "", err
+	}
+	collectionKey, err := serv.collectionKey(ctx, dataUser)
+	if err != nil {
+		return "", err
+	}
+	encDataUser, key, err := serv.encoder.Encrypt(dataUser)
+	if err != nil {
+		return "", err
+	}
+	encDataUser.CollectionKey, err = wrapItemKey(collectionKey, key)
 	if err != nil {
 		return "", err
 	}
@@ -84,18 +187,174 @@ func (serv *HandlerService) AddData(ctx context.Context, dataUser *store.UserDat
 }
 
 func (serv *HandlerService) GetData(ctx context.Context, userId uint64, uuid string) (*store.UserData, error) {
+	dataEnc, err := serv.access(ctx, userId, uuid, store.PermRead)
+	if err != nil {
+		return nil, err
+	}
+	dataUser, _, err := serv.encoder.Decrypt(dataEnc)
+	if err != nil {
+		return nil, err
+	}
+	return dataUser, err
+}
+
+// GetAttachments - attachments of item, metadata is name of attachment
+func (serv *HandlerService) GetAttachments(ctx context.Context, userId uint64, uuid string) ([]*store.Attachment, error) {
+	item, err := serv.access(ctx, userId, uuid, store.PermRead)
+	if err != nil {
+		return nil, err
+	}
+	list, err := serv.related(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	var res []*store.Attachment
+	now := time.Now()
+	for _, dataEnc := range list {
+		if dataEnc.Parent != uuid || expired(dataEnc, now) {
+			continue
+		}
+		dataUser, _, err := serv.encoder.Decrypt(dataEnc)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, &store.Attachment{
+			Uuid:     dataEnc.Uuid,
+			MetaData: dataUser.MetaData,
+			Size:     dataEnc.Size,
+		})
+	}
+	return res, nil
+}
+
+// getOwnData - item of user or item of collection managed by user, expired item is not found,
+// api token changes items of its scope
+func (serv *HandlerService) getOwnData(ctx context.Context, userId uint64, uuid string) (*store.UserDataCrypt, error) {
 	dataEnc, err := serv.store.GetData(ctx, uuid)
 	if err != nil {
 		return nil, err
 	}
-	if dataEnc.Id != userId {
+	if !inScope(ctx, dataEnc, store.PermReadWrite) {
 		return nil, ErrIncorectUserId
 	}
-	dataUser, _, err := serv.encoder.Decrypt(dataEnc)
+	if dataEnc.Collection != "" && !serv.manages(ctx, userId, dataEnc) {
+		return nil, ErrIncorectUserId
+	}
+	if dataEnc.Collection == "" && dataEnc.Id != userId {
+		return nil, ErrIncorectUserId
+	}
+	if expired(dataEnc, time.Now()) {
+		return nil, ErrNotFound
+	}
+	return dataEnc, nil
+}
+
+// related - items among which attachments of item are: items of its owner or items of its collection,
+// members of collection attach to items of other members
+func (serv *HandlerService) related(ctx context.Context, dataEnc *store.UserDataCrypt) ([]*store.UserDataCrypt, error) {
+	if dataEnc.Collection != "" {
+		return serv.store.GetCollectionData(ctx, dataEnc.Collection)
+	}
+	return serv.store.GetListData(ctx, dataEnc.Id)
+}
+
+// checkParent - attachment expires not later than its parent
+func (serv *HandlerService) checkParent(ctx context.Context, dataUser *store.UserData) error {
+	if dataUser.Parent == "" {
+		return nil
+	}
+	parent, err := serv.getOwnData(ctx, dataUser.Id, dataUser.Parent)
+	if err != nil {
+		return err
+	}
+	if parent.Parent != "" {
+		return ErrBadParent
+	}
+	if dataUser.Collection != "" && dataUser.Collection != parent.Collection {
+		return ErrBadCollection
+	}
+	dataUser.Collection = parent.Collection
+	if !parent.ExpiresAt.IsZero() && (dataUser.ExpiresAt.IsZero() || parent.ExpiresAt.Before(dataUser.ExpiresAt)) {
+		dataUser.ExpiresAt = parent.ExpiresAt
+	}
+	return nil
+}
+
+func (serv *HandlerService) deleteOne(ctx context.Context, dataEnc *store.UserDataCrypt) error {
+	switch {
+	case dataEnc.Blob && dataEnc.Chunked:
+		if serv.chunks == nil {
+			return ErrNoChunkStore
+		}
+		err := serv.chunks.Release(dataEnc.Chunks)
+		if err != nil {
+			return err
+		}
+	case dataEnc.Blob:
+		dataUser, _, err := serv.encoder.Decrypt(dataEnc)
+		if err != nil {
+			return err
+		}
+		err = serv.removeFile(ctx, dataUser)
+		if err != nil {
+			return err
+		}
+	}
+	err := serv.deleteShares(ctx, dataEnc.Uuid)
+	if err != nil {
+		return err
+	}
+	return serv.store.DeleteData(ctx, dataEnc.Uuid)
+}
+
+// removeFile - blob file of item in local store or object store and its replica
+func (serv *HandlerService) removeFile(ctx context.Context, dataUser *store.UserData) error {
+	if serv.replica != nil {
+		err := serv.replica.Remove(ctx, filepath.Base(dataUser.UserData))
+		if err != nil {
+			return err
+		}
+	}
+	if !dataUser.Object {
+		return datafile.Remove(dataUser.UserData)
+	}
+	if serv.objects == nil {
+		return ErrNoObjectStore
+	}
+	return serv.objects.Remove(ctx, filepath.Base(dataUser.UserData))
+}
+
+// DeleteData - delete item with its attachments and blob files
+func (serv *HandlerService) DeleteData(ctx context.Context, userId uint64, uuid string) ([]string, error) {
+	dataEnc, err := serv.getOwnData(ctx, userId, uuid)
 	if err != nil {
 		return nil, err
 	}
-	return dataUser, err
+	return serv.deleteItem(ctx, dataEnc)
+}
+
+func (serv *HandlerService) deleteItem(ctx context.Context, dataEnc *store.UserDataCrypt) ([]string, error) {
+	uuid := dataEnc.Uuid
+	list, err := serv.related(ctx, dataEnc)
+	if err != nil {
+		return nil, err
+	}
+	var deleted []string
+	for _, child := range list {
+		if child.Parent != uuid {
+			continue
+		}
+		err = serv.deleteOne(ctx, child)
+		if err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, child.Uuid)
+	}
+	err = serv.deleteOne(ctx, dataEnc)
+	if err != nil {
+		return deleted, err
+	}
+	return append(deleted, uuid), nil
 }
 
 func (serv *HandlerService) genFileName() string {
@@ -103,15 +362,76 @@ func (serv *HandlerService) genFileName() string {
 	return name
 }
 
-func (serv *HandlerService) CreateDataStream(ctx context.Context, dataUser *store.UserData) (*datafile.LongtermfileWrite, *store.UserDataCrypt, error) {
-	nameFile := serv.genFileName()
-	dataUser.UserData = nameFile
+// CreateDataStream - writer of new blob, dataUser.Codec - requested compression,
+// head - first bytes of data, compressed formats are stored as is
+func (serv *HandlerService) CreateDataStream(ctx context.Context, dataUser *store.UserData, head []byte) (*datafile.LongtermfileWrite, *store.UserDataCrypt, error) {
+	return serv.createDataStream(ctx, dataUser, head, 0, false)
+}
+
+// createDataStream - size - declared size of data, 0 - unknown,
+// staged blob is written to local file, it is pushed to object store on commit
+func (serv *HandlerService) createDataStream(ctx context.Context, dataUser *store.UserData, head []byte,
+	size int64, staged bool) (*datafile.LongtermfileWrite, *store.UserDataCrypt, error) {
+	err := checkExpiry(dataUser)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = serv.checkParent(ctx, dataUser)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = checkScope(ctx, dataUser)
+	if err != nil {
+		return nil, nil, err
+	}
+	limit, err := serv.uploadLimit(ctx, dataUser.Id, size)
+	if err != nil {
+		return nil, nil, err
+	}
+	collectionKey, err := serv.collectionKey(ctx, dataUser)
+	if err != nil {
+		return nil, nil, err
+	}
+	compressible := dataUser.TypeData == store.TextType || dataUser.TypeData == store.BinaryType
+	dataUser.Codec, err = codecs.Choose(dataUser.Codec, compressible, head)
+	if err != nil {
+		return nil, nil, err
+	}
+	if serv.chunks != nil && dataUser.TypeData == store.BinaryType {
+		// chunks are deduplicated by plain content, not compressed
+		dataUser.Chunked = true
+		dataUser.Codec = ""
+	} else {
+		dataUser.UserData = serv.genFileName()
+		dataUser.Object = serv.objects != nil && !staged
+	}
 
 	encDataUser, key, err := serv.encoder.Encrypt(dataUser)
 	if err != nil {
 		return nil, nil, err
 	}
-	f := datafile.NewWrite(nameFile, key)
+	encDataUser.Blob = true
+	encDataUser.CollectionKey, err = wrapItemKey(collectionKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	var rep *datafile.Replication
+	if serv.replica != nil && !staged {
+		rep = &datafile.Replication{Store: serv.replica, Quorum: serv.cfg.ReplicaQuorum}
+	}
+	var f *datafile.LongtermfileWrite
+	switch {
+	case dataUser.Chunked:
+		f, err = datafile.NewChunkWrite(serv.chunks, nil, datafile.Position{})
+	case dataUser.Object:
+		f, err = datafile.NewObjectWrite(serv.objects, filepath.Base(dataUser.UserData), key, dataUser.Codec, rep)
+	default:
+		f, err = datafile.NewWrite(dataUser.UserData, key, dataUser.Codec, rep)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	f.Limit(limit)
 	err = f.OpenWriter()
 
 	if err != nil {
@@ -120,6 +440,30 @@ func (serv *HandlerService) CreateDataStream(ctx context.Context, dataUser *stor
 	return f, encDataUser, nil
 }
 
+// CommitDataStream - add item of complete stream, checksum of client is checked when given
+func (serv *HandlerService) CommitDataStream(ctx context.Context, encDataUser *store.UserDataCrypt,
+	f *datafile.LongtermfileWrite, checksum string) (string, error) {
+	f.Success()
+	err := f.CloseWrite()
+	if err != nil {
+		return "", err
+	}
+	if checksum != "" && checksum != f.Checksum() {
+		_ = f.Remove()
+		return "", ErrChecksum
+	}
+	// quota is checked again, concurrent uploads of user are not counted at start
+	err = serv.checkQuota(ctx, encDataUser.Id, 1, f.Size())
+	if err != nil {
+		_ = f.Remove()
+		return "", err
+	}
+	encDataUser.Size = f.Size()
+	encDataUser.Checksum = f.Checksum()
+	encDataUser.Chunks = f.Chunks()
+	return serv.AddDataStream(ctx, encDataUser)
+}
+
 func (serv *HandlerService) AddDataStream(ctx context.Context, encDataUser *store.UserDataCrypt) (string, error) {
 	err := serv.store.AddData(ctx, encDataUser)
 	if err != nil {
@@ -128,19 +472,215 @@ func (serv *HandlerService) AddDataStream(ctx context.Context, encDataUser *stor
 	return encDataUser.Uuid, nil
 }
 
-func (serv *HandlerService) GetDataStream(ctx context.Context, userId uint64, uuid string) (*store.UserData, *datafile.LongtermfileRead, error) {
-	dataEnc, err := serv.store.GetData(ctx, uuid)
+// GetDataStream - reader of blob range from offset, length zero - to end
+func (serv *HandlerService) GetDataStream(ctx context.Context, userId uint64, uuid string,
+	offset int64, length int64) (*store.UserData, *datafile.LongtermfileRead, error) {
+	dataEnc, err := serv.access(ctx, userId, uuid, store.PermRead)
 	if err != nil {
 		return nil, nil, err
 	}
-	if dataEnc.Id != userId {
-		return nil, nil, ErrIncorectUserId
+	if offset < 0 || offset > dataEnc.Size || length < 0 {
+		return nil, nil, ErrBadRange
 	}
 	dataUser, key, err := serv.encoder.Decrypt(dataEnc)
 	if err != nil {
 		return nil, nil, err
 	}
-	f := datafile.NewRead(dataUser.UserData, key)
+	var f *datafile.LongtermfileRead
+	switch {
+	case dataUser.Chunked && serv.chunks == nil:
+		err = ErrNoChunkStore
+	case dataUser.Chunked:
+		f = datafile.NewChunkReadAt(serv.chunks, dataUser.Chunks, offset, length)
+	case dataUser.Object && serv.objects == nil:
+		err = ErrNoObjectStore
+	case dataUser.Object:
+		f, err = datafile.NewObjectReadAt(serv.objects, filepath.Base(dataUser.UserData), key, dataUser.Codec, offset, length)
+	default:
+		f, err = datafile.NewReadAt(dataUser.UserData, key, dataUser.Codec, offset, length)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
 	err = f.OpenReader()
 	return dataUser, f, err
 }
+
+// Search - blind index match when tokens given (end-to-end mode), else match decrypted metadata
+func (serv *HandlerService) Search(ctx context.Context, userId uint64, query string, tokens []string) ([]*store.SearchResult, error) {
+	list, err := serv.store.GetListData(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	words := search.Normalize(query)
+	var res []*store.SearchResult
+	now := time.Now()
+	for _, dataEnc := range list {
+		if expired(dataEnc, now) || !inScope(ctx, dataEnc, store.PermRead) {
+			continue
+		}
+		if len(tokens) > 0 {
+			score := search.MatchTokens(dataEnc.Tokens, tokens)
+			if score == 0 {
+				continue
+			}
+			dataUser, _, err := serv.encoder.Decrypt(dataEnc)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, &store.SearchResult{
+				Uuid:     dataEnc.Uuid,
+				TypeData: dataEnc.TypeData,
+				MetaData: dataUser.MetaData,
+				Score:    score,
+				Fields:   []string{"metadata"},
+			})
+			continue
+		}
+		if len(words) == 0 {
+			continue
+		}
+		dataUser, _, err := serv.encoder.Decrypt(dataEnc)
+		if err != nil {
+			return nil, err
+		}
+		m := search.MatchText(dataUser.MetaData, words)
+		if m.Score == 0 {
+			continue
+		}
+		res = append(res, &store.SearchResult{
+			Uuid:     dataEnc.Uuid,
+			TypeData: dataEnc.TypeData,
+			MetaData: search.Highlight(dataUser.MetaData, m.Words),
+			Score:    m.Score,
+			Fields:   []string{"metadata"},
+		})
+	}
+	search.Rank(res, func(r *store.SearchResult) int { return r.Score })
+	return res, nil
+}
+
+// OpenUploadStream - writer of resumable upload session, session is created on zero offset
+func (serv *HandlerService) OpenUploadStream(ctx context.Context, dataUser *store.UserData, session string,
+	offset int64, size int64, head []byte) (*datafile.LongtermfileWrite, *store.UploadSession, error) {
+	upload, err := serv.store.GetUpload(ctx, dataUser.Id, session)
+	if err != nil {
+		if offset != 0 {
+			return nil, nil, ErrBadOffset
+		}
+		f, encDataUser, err := serv.createDataStream(ctx, dataUser, head, size, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		upload = &store.UploadSession{
+			Id:     session,
+			UserId: dataUser.Id,
+			Data:   encDataUser,
+			Size:   size,
+		}
+		err = serv.store.AddUpload(ctx, upload)
+		if err != nil {
+			_ = f.CloseWrite()
+			return nil, nil, err
+		}
+		f.Keep()
+		return f, upload, nil
+	}
+	if offset != upload.Offset {
+		return nil, nil, ErrBadOffset
+	}
+	limit, err := serv.uploadLimit(ctx, dataUser.Id, upload.Size)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, key, err := serv.encoder.Decrypt(upload.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+	pos := datafile.Position{
+		Size:      upload.Offset,
+		Stored:    upload.Stored,
+		HashState: upload.HashState,
+	}
+	var f *datafile.LongtermfileWrite
+	switch {
+	case !data.Chunked:
+		f, err = datafile.NewWriteAt(data.UserData, key, data.Codec, pos)
+	case serv.chunks == nil:
+		err = ErrNoChunkStore
+	default:
+		f, err = datafile.NewChunkWrite(serv.chunks, upload.Data.Chunks, pos)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	f.Keep()
+	f.Limit(limit)
+	err = f.OpenWriter()
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, upload, nil
+}
+
+// SuspendUpload - persist progress of broken upload stream
+func (serv *HandlerService) SuspendUpload(ctx context.Context, upload *store.UploadSession, f *datafile.LongtermfileWrite) error {
+	_ = f.CloseWrite()
+	setPosition(upload, f)
+	return serv.store.UpdateUpload(ctx, upload)
+}
+
+func setPosition(upload *store.UploadSession, f *datafile.LongtermfileWrite) {
+	pos := f.Position()
+	upload.Offset = pos.Size
+	upload.Stored = pos.Stored
+	upload.HashState = pos.HashState
+	upload.Data.Chunks = f.Chunks()
+}
+
+// CommitUpload - add item of complete upload, declared size and checksum of client are checked
+func (serv *HandlerService) CommitUpload(ctx context.Context, upload *store.UploadSession,
+	f *datafile.LongtermfileWrite, checksum string) (string, error) {
+	f.Success()
+	err := f.CloseWrite()
+	if err != nil {
+		return "", err
+	}
+	setPosition(upload, f)
+	if upload.Size > 0 && upload.Offset != upload.Size {
+		err = serv.store.UpdateUpload(ctx, upload)
+		if err != nil {
+			return "", err
+		}
+		return "", ErrSizeMismatch
+	}
+	if checksum != "" && checksum != f.Checksum() {
+		// corrupted data can not be resumed
+		_ = serv.store.DeleteUpload(ctx, upload.UserId, upload.Id)
+		_ = f.Remove()
+		return "", ErrChecksum
+	}
+	err = serv.checkQuota(ctx, upload.UserId, 1, upload.Offset)
+	if err != nil {
+		_ = serv.store.UpdateUpload(ctx, upload)
+		return "", err
+	}
+	if !upload.Data.Chunked {
+		err = serv.pushUpload(ctx, upload.Data, f)
+		if err != nil {
+			_ = serv.store.UpdateUpload(ctx, upload)
+			return "", err
+		}
+	}
+	upload.Data.Size = upload.Offset
+	upload.Data.Checksum = f.Checksum()
+	uuid, err := serv.AddDataStream(ctx, upload.Data)
+	if err != nil {
+		return "", err
+	}
+	return uuid, serv.store.DeleteUpload(ctx, upload.UserId, upload.Id)
+}
+
+func (serv *HandlerService) QueryUpload(ctx context.Context, userId uint64, session string) (*store.UploadSession, error) {
+	return serv.store.GetUpload(ctx, userId, session)
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4aleksei/gokeeper/internal/common/store"
+)
+
+var ErrNoSession = errors.New("error, session not found")
+
+// StartSession - login of user from device of client version at address
+func (serv *HandlerService) StartSession(ctx context.Context, userId uint64, device string, version string, address string) (*store.Session, error) {
+	session := &store.Session{
+		User:          userId,
+		Device:        device,
+		ClientVersion: version,
+		Address:       address,
+	}
+	err := serv.store.AddSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Sessions - sessions of user, current - session of token
+func (serv *HandlerService) Sessions(ctx context.Context, userId uint64, token string) ([]*store.Session, string, error) {
+	var current string
+	if claims, err := serv.auth.GetClaims(token); err == nil {
+		current = claims.Session
+	}
+	sessions, err := serv.store.GetSessions(ctx, userId)
+	if err != nil {
+		return nil, "", err
+	}
+	return sessions, current, nil
+}
+
+// RevokeSession - session of user is deleted, its access and refresh tokens are rejected
+func (serv *HandlerService) RevokeSession(ctx context.Context, userId uint64, id string) error {
+	session, err := serv.store.GetSession(ctx, id)
+	if err != nil || session.User != userId {
+		return ErrNoSession
+	}
+	return serv.store.DeleteSession(ctx, id)
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4aleksei/gokeeper/internal/common/cryptocerts"
+	"github.com/4aleksei/gokeeper/internal/common/store"
+)
+
+var (
+	ErrNoPublicKey   = errors.New("error, recipient has no public key")
+	ErrShareSelf     = errors.New("error, item is shared to its owner")
+	ErrBadPermission = errors.New("error, unknown permission")
+	ErrNoRecipient   = errors.New("error, recipient not found")
+	ErrBlobSealed    = errors.New("error, blob item is read by stream")
+)
+
+// access - item of owner or item shared to user with permission perm, attachments are shared with parent,
+// item of collection - by role of user in organization, read-only role reads only,
+// contact of approved emergency access reads items of owner, api token reads items of its scope
+func (serv *HandlerService) access(ctx context.Context, userId uint64, uuid string, perm int) (*store.UserDataCrypt, error) {
+	dataEnc, err := serv.store.GetData(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if expired(dataEnc, time.Now()) {
+		return nil, ErrNotFound
+	}
+	if !inScope(ctx, dataEnc, perm) {
+		return nil, ErrIncorectUserId
+	}
+	if dataEnc.Collection != "" {
+		own, err := serv.collectionRole(ctx, userId, dataEnc.Collection)
+		if err != nil || (perm == store.PermReadWrite && own > store.RoleMember) {
+			return nil, ErrIncorectUserId
+		}
+		return dataEnc, nil
+	}
+	if dataEnc.Id == userId {
+		return dataEnc, nil
+	}
+	shared := uuid
+	if dataEnc.Parent != "" {
+		shared = dataEnc.Parent
+	}
+	share, err := serv.store.GetShare(ctx, shared, userId)
+	if err == nil && share.Permission >= perm {
+		return dataEnc, nil
+	}
+	if perm == store.PermRead && serv.emergencyGranted(ctx, dataEnc.Id, userId) {
+		return dataEnc, nil
+	}
+	return nil, ErrIncorectUserId
+}
+
+// SetKeys - public key of user and private key wrapped by client
+func (serv *HandlerService) SetKeys(ctx context.Context, userId uint64, publicKey string, privateKey string) error {
+	_, err := cryptocerts.ParsePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+	_, err = serv.modifyUser(ctx, userId, func(user *store.User) error {
+		user.PublicKey = publicKey
+		user.PrivateKey = privateKey
+		return nil
+	})
+	return err
+}
+
+// ShareItem - share item of owner to recipient, item key is wrapped by public key of recipient
+func (serv *HandlerService) ShareItem(ctx context.Context, userId uint64, uuid string, recipient string, perm int) (*store.Share, error) {
+	if perm != store.PermRead && perm != store.PermReadWrite {
+		return nil, ErrBadPermission
+	}
+	dataEnc, err := serv.getOwnData(ctx, userId, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if dataEnc.Parent != "" {
+		return nil, ErrBadParent
+	}
+	user, err := serv.store.GetUser(ctx, recipient)
+	if err != nil {
+		return nil, ErrNoRecipient
+	}
+	if user.Id == userId {
+		return nil, ErrShareSelf
+	}
+	if user.PublicKey == "" {
+		return nil, ErrNoPublicKey
+	}
+	pub, err := cryptocerts.ParsePublicKey(user.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	_, key, err := serv.encoder.Decrypt(dataEnc)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := key.WrapFor(pub)
+	if err != nil {
+		return nil, err
+	}
+	share := &store.Share{
+		Uuid:       uuid,
+		Owner:      userId,
+		Recipient:  user.Id,
+		Permission: perm,
+		WrappedKey: wrapped,
+	}
+	err = serv.store.AddShare(ctx, share)
+	if err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+// Unshare - revoke share of item, by owner or by recipient itself
+func (serv *HandlerService) Unshare(ctx context.Context, userId uint64, uuid string, recipient string) error {
+	user, err := serv.store.GetUser(ctx, recipient)
+	if err != nil {
+		return ErrNoRecipient
+	}
+	if user.Id != userId {
+		_, err = serv.getOwnData(ctx, userId, uuid)
+		if err != nil {
+			return err
+		}
+	}
+	return serv.store.DeleteShare(ctx, uuid, user.Id)
+}
+
+// SealedData - item encrypted by its item key, reader of share or emergency access opens it
+// by item key wrapped for reader, server does not decrypt item for reader
+func (serv *HandlerService) SealedData(ctx context.Context, userId uint64, uuid string) (*store.UserDataCrypt, error) {
+	dataEnc, err := serv.access(ctx, userId, uuid, store.PermRead)
+	if err != nil {
+		return nil, err
+	}
+	if dataEnc.Blob {
+		return nil, ErrBlobSealed
+	}
+	return dataEnc, nil
+}
+
+// SharedWithMe - items shared to user
+func (serv *HandlerService) SharedWithMe(ctx context.Context, userId uint64) ([]*store.SharedItem, error) {
+	shares, err := serv.store.GetSharesTo(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var res []*store.SharedItem
+	for _, share := range shares {
+		dataEnc, err := serv.store.GetData(ctx, share.Uuid)
+		if err != nil || expired(dataEnc, now) || !inScope(ctx, dataEnc, store.PermRead) {
+			continue
+		}
+		dataUser, _, err := serv.encoder.Decrypt(dataEnc)
+		if err != nil {
+			return nil, err
+		}
+		var owner string
+		if user, err := serv.store.GetUserByID(ctx, share.Owner); err == nil {
+			owner = user.Name
+		}
+		res = append(res, &store.SharedItem{
+			Uuid:       share.Uuid,
+			Owner:      owner,
+			TypeData:   dataEnc.TypeData,
+			MetaData:   dataUser.MetaData,
+			Permission: share.Permission,
+			WrappedKey: share.WrappedKey,
+		})
+	}
+	return res, nil
+}
+
+// deleteShares - shares of deleted item
+func (serv *HandlerService) deleteShares(ctx context.Context, uuid string) error {
+	shares, err := serv.store.GetSharesOf(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	for _, share := range shares {
+		err = serv.store.DeleteShare(ctx, uuid, share.Recipient)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/4aleksei/gokeeper/internal/common/store"
+	"github.com/4aleksei/gokeeper/internal/common/utils/random"
+	"go.uber.org/zap"
+)
+
+const refreshTokenSize int = 32
+
+var (
+	ErrBadRefresh   = errors.New("error, refresh token is unknown, used or expired")
+	ErrTokenRevoked = errors.New("error, token is revoked")
+)
+
+// secretHash - stored hash of refresh token or recovery code, server keeps only hash of secret
+func secretHash(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+// CheckToken - user of token, tokens of revocation list, of older version of user and of deleted session are rejected,
+// last seen time of session is updated
+func (serv *HandlerService) CheckToken(ctx context.Context, token string) (uint64, error) {
+	claims, err := serv.auth.GetClaims(token)
+	if err != nil {
+		return 0, err
+	}
+	if claims.Partial {
+		return 0, ErrTokenRevoked
+	}
+	revoked, err := serv.store.IsTokenRevoked(ctx, claims.ID)
+	if err != nil {
+		return 0, err
+	}
+	if revoked {
+		return 0, ErrTokenRevoked
+	}
+	user, err := serv.store.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return 0, err
+	}
+	if claims.Version != user.TokenVersion {
+		return 0, ErrTokenRevoked
+	}
+	err = serv.store.TouchSession(ctx, claims.Session, time.Now())
+	if err != nil {
+		return 0, ErrTokenRevoked
+	}
+	return claims.UserID, nil
+}
+
+// IssueTokens - access token of TokenTTL and new refresh token of RefreshTTL of session
+func (serv *HandlerService) IssueTokens(ctx context.Context, userId uint64, session string) (*store.Tokens, error) {
+	user, err := serv.store.GetUserByID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := serv.auth.Expiry()
+	access, err := serv.auth.BuildJWT(userId, user.TokenVersion, session, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	b, err := random.GenerateRandom(refreshTokenSize)
+	if err != nil {
+		return nil, err
+	}
+	refresh := hex.EncodeToString(b)
+	err = serv.store.AddRefreshToken(ctx, &store.RefreshToken{
+		Id:        secretHash(refresh),
+		User:      userId,
+		Session:   session,
+		Version:   user.TokenVersion,
+		ExpiresAt: time.Now().Add(serv.cfg.RefreshTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &store.Tokens{Access: access, Refresh: refresh, ExpiresAt: expiresAt}, nil
+}
+
+// RefreshTokens - refresh token is rotated, it is used once and new pair of tokens is issued
+func (serv *HandlerService) RefreshTokens(ctx context.Context, refresh string) (*store.Tokens, error) {
+	t, err := serv.store.TakeRefreshToken(ctx, secretHash(refresh))
+	if err != nil || !time.Now().Before(t.ExpiresAt) {
+		return nil, ErrBadRefresh
+	}
+	user, err := serv.store.GetUserByID(ctx, t.User)
+	if err != nil || user.TokenVersion != t.Version {
+		return nil, ErrBadRefresh
+	}
+	err = serv.store.TouchSession(ctx, t.Session, time.Now())
+	if err != nil {
+		return nil, ErrBadRefresh
+	}
+	return serv.IssueTokens(ctx, t.User, t.Session)
+}
+
+// Logout - access token is revoked until its expiry, session and its refresh token are deleted
+func (serv *HandlerService) Logout(ctx context.Context, token string, refresh string) error {
+	claims, err := serv.auth.GetClaims(token)
+	if err != nil {
+		return err
+	}
+	err = serv.store.RevokeToken(ctx, claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		return err
+	}
+	if refresh != "" {
+		_, _ = serv.store.TakeRefreshToken(ctx, secretHash(refresh))
+	}
+	_ = serv.store.DeleteSession(ctx, claims.Session)
+	return nil
+}
+
+// RevokeAll - all access and refresh tokens issued to user before now are revoked, sessions are deleted
+func (serv *HandlerService) RevokeAll(ctx context.Context, userId uint64) error {
+	_, err := serv.modifyUser(ctx, userId, func(user *store.User) error {
+		user.TokenVersion++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sessions, err := serv.store.GetSessions(ctx, userId)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		_ = serv.store.DeleteSession(ctx, session.Id)
+	}
+	return nil
+}
+
+// sweepTokens - delete expired refresh tokens, revocations of expired tokens and sessions not seen for RefreshTTL
+func (serv *HandlerService) sweepTokens(ctx context.Context) {
+	now := time.Now()
+	n, err := serv.store.DeleteExpiredRefreshTokens(ctx, now)
+	if err != nil {
+		serv.l.Error("expired refresh token sweep", zap.Error(err))
+		return
+	}
+	if n > 0 {
+		serv.l.Info("expired refresh token sweep", zap.Int("deleted", n))
+	}
+	n, err = serv.store.DeleteExpiredRevocations(ctx, now)
+	if err != nil {
+		serv.l.Error("expired revocation sweep", zap.Error(err))
+		return
+	}
+	if n > 0 {
+		serv.l.Info("expired revocation sweep", zap.Int("deleted", n))
+	}
+	n, err = serv.store.DeleteStaleSessions(ctx, now.Add(-serv.cfg.RefreshTTL))
+	if err != nil {
+		serv.l.Error("stale session sweep", zap.Error(err))
+		return
+	}
+	if n > 0 {
+		serv.l.Info("stale session sweep", zap.Int("deleted", n))
+	}
+}
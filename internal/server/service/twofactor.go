@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/4aleksei/gokeeper/internal/common/store"
+	"github.com/4aleksei/gokeeper/internal/common/totp"
+	"github.com/4aleksei/gokeeper/internal/common/utils/random"
+)
+
+const (
+	totpIssuer        = "gokeeper"
+	challengeTTL      = 5 * time.Minute
+	recoveryCodeCount = 10
+	recoveryCodeSize  = 5
+)
+
+var (
+	ErrBadCode      = errors.New("error, one-time code or recovery code is not valid")
+	ErrNoEnrollment = errors.New("error, two-factor enrollment is not started")
+	ErrBadChallenge = errors.New("error, login challenge is not valid or expired")
+	ErrNoConfirm    = errors.New("error, second factor is enabled, current code or password is required")
+)
+
+// EnrollTOTP - new secret of second factor and its otpauth URI, it is enabled after its code is verified,
+// user with enabled second factor confirms it by current code or by password
+func (serv *HandlerService) EnrollTOTP(ctx context.Context, userId uint64, code string, password string) (string, string, error) {
+	user, err := serv.store.GetUserByID(ctx, userId)
+	if err != nil {
+		return "", "", err
+	}
+	passOk := false
+	if password != "" {
+		_, err = serv.LoginUser(ctx, user.Name, password)
+		passOk = err == nil
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+	user, err = serv.modifyUser(ctx, userId, func(user *store.User) error {
+		if user.TOTPSecret != "" && !passOk {
+			step, ok := totp.Validate(user.TOTPSecret, code, time.Now())
+			if !ok || step <= user.TOTPStep {
+				return ErrNoConfirm
+			}
+			user.TOTPStep = step
+		}
+		user.TOTPPending = secret
+		return nil
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return secret, totp.URI(totpIssuer, user.Name, secret), nil
+}
+
+// VerifyTOTP - code of pending secret enables second factor, new recovery codes are returned once, server keeps hashes
+func (serv *HandlerService) VerifyTOTP(ctx context.Context, userId uint64, code string) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		b, err := random.GenerateRandom(recoveryCodeSize)
+		if err != nil {
+			return nil, err
+		}
+		c := hex.EncodeToString(b)
+		c = c[:recoveryCodeSize] + "-" + c[recoveryCodeSize:]
+		codes = append(codes, c)
+		hashes = append(hashes, secretHash(c))
+	}
+	_, err := serv.modifyUser(ctx, userId, func(user *store.User) error {
+		if user.TOTPPending == "" {
+			return ErrNoEnrollment
+		}
+		step, ok := totp.Validate(user.TOTPPending, code, time.Now())
+		if !ok {
+			return ErrBadCode
+		}
+		user.TOTPSecret = user.TOTPPending
+		user.TOTPPending = ""
+		user.TOTPStep = step
+		user.RecoveryCodes = hashes
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Challenge - challenge of login of user with second factor, it is exchanged with code for tokens
+func (serv *HandlerService) Challenge(ctx context.Context, user *store.User) (string, error) {
+	return serv.auth.BuildChallenge(user.Id, user.TokenVersion, time.Now().Add(challengeTTL))
+}
+
+// ChallengeUser - user of challenge of login, challenge issued before tokens of user are revoked is not valid
+func (serv *HandlerService) ChallengeUser(ctx context.Context, challenge string) (*store.User, error) {
+	claims, err := serv.auth.GetClaims(challenge)
+	if err != nil || !claims.Partial {
+		return nil, ErrBadChallenge
+	}
+	user, err := serv.store.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Version != user.TokenVersion {
+		return nil, ErrBadChallenge
+	}
+	return user, nil
+}
+
+// SecondFactor - code of second factor, code is used once, recovery code is deleted after use,
+// concurrent logins with same code are not both accepted
+func (serv *HandlerService) SecondFactor(ctx context.Context, user *store.User, code string) error {
+	_, err := serv.modifyUser(ctx, user.Id, func(user *store.User) error {
+		step, ok := totp.Validate(user.TOTPSecret, code, time.Now())
+		switch {
+		case ok && step > user.TOTPStep:
+			user.TOTPStep = step
+		case ok:
+			return ErrBadCode
+		default:
+			i := slices.Index(user.RecoveryCodes, secretHash(strings.ToLower(strings.TrimSpace(code))))
+			if i < 0 {
+				return ErrBadCode
+			}
+			user.RecoveryCodes = slices.Delete(slices.Clone(user.RecoveryCodes), i, i+1)
+		}
+		return nil
+	})
+	return err
+}
@@ -73,10 +73,168 @@ func (TypeData) EnumDescriptor() ([]byte, []int) {
 	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{0}
 }
 
+type Permission int32
+
+const (
+	Permission_READ      Permission = 0
+	Permission_READWRITE Permission = 1
+)
+
+// Enum value maps for Permission.
+var (
+	Permission_name = map[int32]string{
+		0: "READ",
+		1: "READWRITE",
+	}
+	Permission_value = map[string]int32{
+		"READ":      0,
+		"READWRITE": 1,
+	}
+)
+
+func (x Permission) Enum() *Permission {
+	p := new(Permission)
+	*p = x
+	return p
+}
+
+func (x Permission) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Permission) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_gokeeper_proto_enumTypes[1].Descriptor()
+}
+
+func (Permission) Type() protoreflect.EnumType {
+	return &file_api_proto_gokeeper_proto_enumTypes[1]
+}
+
+func (x Permission) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Permission.Descriptor instead.
+func (Permission) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{1}
+}
+
+type Role int32
+
+const (
+	Role_OWNER    Role = 0
+	Role_ADMIN    Role = 1
+	Role_MEMBER   Role = 2
+	Role_READONLY Role = 3
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "OWNER",
+		1: "ADMIN",
+		2: "MEMBER",
+		3: "READONLY",
+	}
+	Role_value = map[string]int32{
+		"OWNER":    0,
+		"ADMIN":    1,
+		"MEMBER":   2,
+		"READONLY": 3,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_gokeeper_proto_enumTypes[2].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_api_proto_gokeeper_proto_enumTypes[2]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{2}
+}
+
+type EmergencyState int32
+
+const (
+	EmergencyState_INVITED   EmergencyState = 0
+	EmergencyState_REQUESTED EmergencyState = 1
+	EmergencyState_APPROVED  EmergencyState = 2
+	EmergencyState_DENIED    EmergencyState = 3
+	EmergencyState_EXPIRED   EmergencyState = 4
+)
+
+// Enum value maps for EmergencyState.
+var (
+	EmergencyState_name = map[int32]string{
+		0: "INVITED",
+		1: "REQUESTED",
+		2: "APPROVED",
+		3: "DENIED",
+		4: "EXPIRED",
+	}
+	EmergencyState_value = map[string]int32{
+		"INVITED":   0,
+		"REQUESTED": 1,
+		"APPROVED":  2,
+		"DENIED":    3,
+		"EXPIRED":   4,
+	}
+)
+
+func (x EmergencyState) Enum() *EmergencyState {
+	p := new(EmergencyState)
+	*p = x
+	return p
+}
+
+func (x EmergencyState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EmergencyState) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_gokeeper_proto_enumTypes[3].Descriptor()
+}
+
+func (EmergencyState) Type() protoreflect.EnumType {
+	return &file_api_proto_gokeeper_proto_enumTypes[3]
+}
+
+func (x EmergencyState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EmergencyState.Descriptor instead.
+func (EmergencyState) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{3}
+}
+
 type LoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	VaultKey      string                 `protobuf:"bytes,3,opt,name=vault_key,json=vaultKey,proto3" json:"vault_key,omitempty"`                // Optional: end-to-end vault key wrapped by client master key
+	Device        string                 `protobuf:"bytes,4,opt,name=device,proto3" json:"device,omitempty"`                                    // Optional: device name of session
+	ClientVersion string                 `protobuf:"bytes,5,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"` // Optional: version of client of session
+	Otp           string                 `protobuf:"bytes,6,opt,name=otp,proto3" json:"otp,omitempty"`                                          // Optional: totp code or recovery code of second factor
+	Challenge     string                 `protobuf:"bytes,7,opt,name=challenge,proto3" json:"challenge,omitempty"`                              // Optional: challenge of first step, it replaces name and password
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -125,9 +283,50 @@ func (x *LoginRequest) GetPassword() string {
 	return ""
 }
 
+func (x *LoginRequest) GetVaultKey() string {
+	if x != nil {
+		return x.VaultKey
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetOtp() string {
+	if x != nil {
+		return x.Otp
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetChallenge() string {
+	if x != nil {
+		return x.Challenge
+	}
+	return ""
+}
+
 type LoginResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // e.g., JWT
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                                   // e.g., JWT
+	VaultKey      string                 `protobuf:"bytes,2,opt,name=vault_key,json=vaultKey,proto3" json:"vault_key,omitempty"`             // Optional: wrapped vault key for end-to-end mode
+	PublicKey     string                 `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`          // Optional: hex PKIX rsa key of user, sharing
+	PrivateKey    string                 `protobuf:"bytes,4,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`       // Optional: private key wrapped by client master key
+	RefreshToken  string                 `protobuf:"bytes,5,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // used once for new pair of tokens
+	ExpiresAt     int64                  `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`         // unix time of expiry of token
+	Challenge     string                 `protobuf:"bytes,7,opt,name=challenge,proto3" json:"challenge,omitempty"`                           // not empty - second factor is required, token is not issued
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -169,29 +368,70 @@ func (x *LoginResponse) GetToken() string {
 	return ""
 }
 
-type UserData struct {
+func (x *LoginResponse) GetVaultKey() string {
+	if x != nil {
+		return x.VaultKey
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *LoginResponse) GetChallenge() string {
+	if x != nil {
+		return x.Challenge
+	}
+	return ""
+}
+
+type EnrollRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          TypeData               `protobuf:"varint,1,opt,name=type,proto3,enum=grpcgokeeper.TypeData" json:"type,omitempty"` // тип данных
-	Data          string                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	Metadata      string                 `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`         // current code, required with password missing when second factor is enabled
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"` // password, required with code missing when second factor is enabled
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserData) Reset() {
-	*x = UserData{}
+func (x *EnrollRequest) Reset() {
+	*x = EnrollRequest{}
 	mi := &file_api_proto_gokeeper_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserData) String() string {
+func (x *EnrollRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserData) ProtoMessage() {}
+func (*EnrollRequest) ProtoMessage() {}
 
-func (x *UserData) ProtoReflect() protoreflect.Message {
+func (x *EnrollRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_gokeeper_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -203,53 +443,47 @@ func (x *UserData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserData.ProtoReflect.Descriptor instead.
-func (*UserData) Descriptor() ([]byte, []int) {
+// Deprecated: Use EnrollRequest.ProtoReflect.Descriptor instead.
+func (*EnrollRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *UserData) GetType() TypeData {
-	if x != nil {
-		return x.Type
-	}
-	return TypeData_LOGINDATA
-}
-
-func (x *UserData) GetData() string {
+func (x *EnrollRequest) GetCode() string {
 	if x != nil {
-		return x.Data
+		return x.Code
 	}
 	return ""
 }
 
-func (x *UserData) GetMetadata() string {
+func (x *EnrollRequest) GetPassword() string {
 	if x != nil {
-		return x.Metadata
+		return x.Password
 	}
 	return ""
 }
 
-type ResponseAddData struct {
+type EnrollResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"` // base32 totp secret
+	Uri           string                 `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`       // otpauth URI
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ResponseAddData) Reset() {
-	*x = ResponseAddData{}
+func (x *EnrollResponse) Reset() {
+	*x = EnrollResponse{}
 	mi := &file_api_proto_gokeeper_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResponseAddData) String() string {
+func (x *EnrollResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResponseAddData) ProtoMessage() {}
+func (*EnrollResponse) ProtoMessage() {}
 
-func (x *ResponseAddData) ProtoReflect() protoreflect.Message {
+func (x *EnrollResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_gokeeper_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -261,38 +495,46 @@ func (x *ResponseAddData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResponseAddData.ProtoReflect.Descriptor instead.
-func (*ResponseAddData) Descriptor() ([]byte, []int) {
+// Deprecated: Use EnrollResponse.ProtoReflect.Descriptor instead.
+func (*EnrollResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *ResponseAddData) GetUuid() string {
+func (x *EnrollResponse) GetSecret() string {
 	if x != nil {
-		return x.Uuid
+		return x.Secret
 	}
 	return ""
 }
 
-type ListRequest struct {
+func (x *EnrollResponse) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+type VerifyTOTPRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListRequest) Reset() {
-	*x = ListRequest{}
+func (x *VerifyTOTPRequest) Reset() {
+	*x = VerifyTOTPRequest{}
 	mi := &file_api_proto_gokeeper_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRequest) String() string {
+func (x *VerifyTOTPRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRequest) ProtoMessage() {}
+func (*VerifyTOTPRequest) ProtoMessage() {}
 
-func (x *ListRequest) ProtoReflect() protoreflect.Message {
+func (x *VerifyTOTPRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_gokeeper_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -304,32 +546,39 @@ func (x *ListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
-func (*ListRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use VerifyTOTPRequest.ProtoReflect.Descriptor instead.
+func (*VerifyTOTPRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{4}
 }
 
-type DownloadRequest struct {
+func (x *VerifyTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type VerifyTOTPResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"` // shown once, server keeps hashes
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadRequest) Reset() {
-	*x = DownloadRequest{}
+func (x *VerifyTOTPResponse) Reset() {
+	*x = VerifyTOTPResponse{}
 	mi := &file_api_proto_gokeeper_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadRequest) String() string {
+func (x *VerifyTOTPResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadRequest) ProtoMessage() {}
+func (*VerifyTOTPResponse) ProtoMessage() {}
 
-func (x *DownloadRequest) ProtoReflect() protoreflect.Message {
+func (x *VerifyTOTPResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_gokeeper_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -341,43 +590,39 @@ func (x *DownloadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadRequest.ProtoReflect.Descriptor instead.
-func (*DownloadRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use VerifyTOTPResponse.ProtoReflect.Descriptor instead.
+func (*VerifyTOTPResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *DownloadRequest) GetUuid() string {
+func (x *VerifyTOTPResponse) GetRecoveryCodes() []string {
 	if x != nil {
-		return x.Uuid
+		return x.RecoveryCodes
 	}
-	return ""
+	return nil
 }
 
-type DataChunk struct {
+type RefreshRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`                             // The actual byte data for the chunk
-	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`                        // Optional: for tracking progress/resuming
-	Metadata      string                 `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`                     //Optional
-	Type          TypeData               `protobuf:"varint,4,opt,name=type,proto3,enum=grpcgokeeper.TypeData" json:"type,omitempty"` // тип данных
-	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DataChunk) Reset() {
-	*x = DataChunk{}
+func (x *RefreshRequest) Reset() {
+	*x = RefreshRequest{}
 	mi := &file_api_proto_gokeeper_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DataChunk) String() string {
+func (x *RefreshRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DataChunk) ProtoMessage() {}
+func (*RefreshRequest) ProtoMessage() {}
 
-func (x *DataChunk) ProtoReflect() protoreflect.Message {
+func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_gokeeper_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -389,86 +634,4846 @@ func (x *DataChunk) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DataChunk.ProtoReflect.Descriptor instead.
-func (*DataChunk) Descriptor() ([]byte, []int) {
+// Deprecated: Use RefreshRequest.ProtoReflect.Descriptor instead.
+func (*RefreshRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *DataChunk) GetData() []byte {
+func (x *RefreshRequest) GetRefreshToken() string {
 	if x != nil {
-		return x.Data
+		return x.RefreshToken
 	}
-	return nil
+	return ""
 }
 
-func (x *DataChunk) GetOffset() int64 {
-	if x != nil {
-		return x.Offset
-	}
-	return 0
+type LogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // Optional: refresh token of session is deleted too
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DataChunk) GetMetadata() string {
-	if x != nil {
-		return x.Metadata
-	}
-	return ""
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *DataChunk) GetType() TypeData {
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[7]
 	if x != nil {
-		return x.Type
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return TypeData_LOGINDATA
+	return mi.MessageOf(x)
 }
 
-func (x *DataChunk) GetSize() int64 {
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LogoutRequest) GetRefreshToken() string {
 	if x != nil {
-		return x.Size
+		return x.RefreshToken
 	}
-	return 0
+	return ""
 }
 
-var File_api_proto_gokeeper_proto protoreflect.FileDescriptor
+type RevokeAllRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_api_proto_gokeeper_proto_rawDesc = "" +
-	"\n" +
-	"\x18api/proto/gokeeper.proto\x12\fgrpcgokeeper\">\n" +
+func (x *RevokeAllRequest) Reset() {
+	*x = RevokeAllRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllRequest) ProtoMessage() {}
+
+func (x *RevokeAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAllRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{8}
+}
+
+type ResponseLogout struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseLogout) Reset() {
+	*x = ResponseLogout{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseLogout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseLogout) ProtoMessage() {}
+
+func (x *ResponseLogout) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseLogout.ProtoReflect.Descriptor instead.
+func (*ResponseLogout) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{9}
+}
+
+type SessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionsRequest) Reset() {
+	*x = SessionsRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionsRequest) ProtoMessage() {}
+
+func (x *SessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionsRequest.ProtoReflect.Descriptor instead.
+func (*SessionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{10}
+}
+
+type Session struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Device        string                 `protobuf:"bytes,2,opt,name=device,proto3" json:"device,omitempty"`
+	ClientVersion string                 `protobuf:"bytes,3,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	Address       string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"` // X-Real-IP of login
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastSeen      int64                  `protobuf:"varint,6,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Current       bool                   `protobuf:"varint,7,opt,name=current,proto3" json:"current,omitempty"` // session of request
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *Session) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *Session) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Session) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+func (x *Session) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type SessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionsResponse) Reset() {
+	*x = SessionsResponse{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionsResponse) ProtoMessage() {}
+
+func (x *SessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionsResponse.ProtoReflect.Descriptor instead.
+func (*SessionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RevokeSessionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ChangePassword - keys of user are wrapped by client by new master key, empty - user has no such key
+type ChangePasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldPassword   string                 `protobuf:"bytes,1,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	VaultKey      string                 `protobuf:"bytes,3,opt,name=vault_key,json=vaultKey,proto3" json:"vault_key,omitempty"`
+	PrivateKey    string                 `protobuf:"bytes,4,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	Device        string                 `protobuf:"bytes,5,opt,name=device,proto3" json:"device,omitempty"`
+	ClientVersion string                 `protobuf:"bytes,6,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ChangePasswordRequest) GetOldPassword() string {
+	if x != nil {
+		return x.OldPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetVaultKey() string {
+	if x != nil {
+		return x.VaultKey
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+// SetRecoveryRequest - recovery - secret derived from recovery key, keys are wrapped by recovery key
+type SetRecoveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recovery      string                 `protobuf:"bytes,1,opt,name=recovery,proto3" json:"recovery,omitempty"`
+	VaultKey      string                 `protobuf:"bytes,2,opt,name=vault_key,json=vaultKey,proto3" json:"vault_key,omitempty"`
+	PrivateKey    string                 `protobuf:"bytes,3,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRecoveryRequest) Reset() {
+	*x = SetRecoveryRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRecoveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRecoveryRequest) ProtoMessage() {}
+
+func (x *SetRecoveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRecoveryRequest.ProtoReflect.Descriptor instead.
+func (*SetRecoveryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetRecoveryRequest) GetRecovery() string {
+	if x != nil {
+		return x.Recovery
+	}
+	return ""
+}
+
+func (x *SetRecoveryRequest) GetVaultKey() string {
+	if x != nil {
+		return x.VaultKey
+	}
+	return ""
+}
+
+func (x *SetRecoveryRequest) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+type ResponseSetRecovery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseSetRecovery) Reset() {
+	*x = ResponseSetRecovery{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseSetRecovery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseSetRecovery) ProtoMessage() {}
+
+func (x *ResponseSetRecovery) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseSetRecovery.ProtoReflect.Descriptor instead.
+func (*ResponseSetRecovery) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{16}
+}
+
+// RecoveryRequest - OpenRecovery uses name and recovery, RecoverAccount sets new password and keys
+type RecoveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Recovery      string                 `protobuf:"bytes,2,opt,name=recovery,proto3" json:"recovery,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	VaultKey      string                 `protobuf:"bytes,4,opt,name=vault_key,json=vaultKey,proto3" json:"vault_key,omitempty"`
+	PrivateKey    string                 `protobuf:"bytes,5,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	Device        string                 `protobuf:"bytes,6,opt,name=device,proto3" json:"device,omitempty"`
+	ClientVersion string                 `protobuf:"bytes,7,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	Otp           string                 `protobuf:"bytes,8,opt,name=otp,proto3" json:"otp,omitempty"` // Optional: totp code or recovery code of second factor, required if it is enabled
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoveryRequest) Reset() {
+	*x = RecoveryRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryRequest) ProtoMessage() {}
+
+func (x *RecoveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoveryRequest.ProtoReflect.Descriptor instead.
+func (*RecoveryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RecoveryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RecoveryRequest) GetRecovery() string {
+	if x != nil {
+		return x.Recovery
+	}
+	return ""
+}
+
+func (x *RecoveryRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *RecoveryRequest) GetVaultKey() string {
+	if x != nil {
+		return x.VaultKey
+	}
+	return ""
+}
+
+func (x *RecoveryRequest) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+func (x *RecoveryRequest) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *RecoveryRequest) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *RecoveryRequest) GetOtp() string {
+	if x != nil {
+		return x.Otp
+	}
+	return ""
+}
+
+// RecoveryResponse - keys of user wrapped by recovery key
+type RecoveryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VaultKey      string                 `protobuf:"bytes,1,opt,name=vault_key,json=vaultKey,proto3" json:"vault_key,omitempty"`
+	PrivateKey    string                 `protobuf:"bytes,2,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoveryResponse) Reset() {
+	*x = RecoveryResponse{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoveryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryResponse) ProtoMessage() {}
+
+func (x *RecoveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoveryResponse.ProtoReflect.Descriptor instead.
+func (*RecoveryResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RecoveryResponse) GetVaultKey() string {
+	if x != nil {
+		return x.VaultKey
+	}
+	return ""
+}
+
+func (x *RecoveryResponse) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+type ExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRequest) ProtoMessage() {}
+
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{19}
+}
+
+// ExportChunk - first chunk of item has its fields and data of item or first bytes of blob,
+// next chunks of blob have uuid, blob and offset
+type ExportChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Type          TypeData               `protobuf:"varint,2,opt,name=type,proto3,enum=grpcgokeeper.TypeData" json:"type,omitempty"`
+	Data          string                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"` // data of item, empty for blob
+	Metadata      string                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Parent        string                 `protobuf:"bytes,5,opt,name=parent,proto3" json:"parent,omitempty"` // uuid of item, chunk is attachment
+	Collection    string                 `protobuf:"bytes,6,opt,name=collection,proto3" json:"collection,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // unix time of expiry, zero - never
+	IsBlob        bool                   `protobuf:"varint,8,opt,name=is_blob,json=isBlob,proto3" json:"is_blob,omitempty"`
+	Blob          []byte                 `protobuf:"bytes,9,opt,name=blob,proto3" json:"blob,omitempty"`
+	Offset        int64                  `protobuf:"varint,10,opt,name=offset,proto3" json:"offset,omitempty"`
+	Size          int64                  `protobuf:"varint,11,opt,name=size,proto3" json:"size,omitempty"`
+	Checksum      string                 `protobuf:"bytes,12,opt,name=checksum,proto3" json:"checksum,omitempty"` // hex sha256 of whole blob
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportChunk) Reset() {
+	*x = ExportChunk{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportChunk) ProtoMessage() {}
+
+func (x *ExportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportChunk.ProtoReflect.Descriptor instead.
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ExportChunk) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *ExportChunk) GetType() TypeData {
+	if x != nil {
+		return x.Type
+	}
+	return TypeData_LOGINDATA
+}
+
+func (x *ExportChunk) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *ExportChunk) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *ExportChunk) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *ExportChunk) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *ExportChunk) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *ExportChunk) GetIsBlob() bool {
+	if x != nil {
+		return x.IsBlob
+	}
+	return false
+}
+
+func (x *ExportChunk) GetBlob() []byte {
+	if x != nil {
+		return x.Blob
+	}
+	return nil
+}
+
+func (x *ExportChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ExportChunk) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ExportChunk) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+// DeleteAccountRequest - password confirms deletion
+type DeleteAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Password      string                 `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountRequest) ProtoMessage() {}
+
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteAccountRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type ResponseDeleteAccount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseDeleteAccount) Reset() {
+	*x = ResponseDeleteAccount{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseDeleteAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseDeleteAccount) ProtoMessage() {}
+
+func (x *ResponseDeleteAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseDeleteAccount.ProtoReflect.Descriptor instead.
+func (*ResponseDeleteAccount) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{22}
+}
+
+// APITokenRequest - items and collections restrict token, empty - all items of user
+type APITokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Permission    Permission             `protobuf:"varint,2,opt,name=permission,proto3,enum=grpcgokeeper.Permission" json:"permission,omitempty"`
+	Items         []string               `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Collections   []string               `protobuf:"bytes,4,rep,name=collections,proto3" json:"collections,omitempty"`
+	ExpiresIn     int64                  `protobuf:"varint,5,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"` // seconds, 0 - token does not expire
+	Tags          []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`                             // words of metadata of items, blind tokens of words in end-to-end mode
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APITokenRequest) Reset() {
+	*x = APITokenRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APITokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APITokenRequest) ProtoMessage() {}
+
+func (x *APITokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APITokenRequest.ProtoReflect.Descriptor instead.
+func (*APITokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *APITokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APITokenRequest) GetPermission() Permission {
+	if x != nil {
+		return x.Permission
+	}
+	return Permission_READ
+}
+
+func (x *APITokenRequest) GetItems() []string {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *APITokenRequest) GetCollections() []string {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+func (x *APITokenRequest) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *APITokenRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type APIToken struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Permission    Permission             `protobuf:"varint,2,opt,name=permission,proto3,enum=grpcgokeeper.Permission" json:"permission,omitempty"`
+	Items         []string               `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Collections   []string               `protobuf:"bytes,4,rep,name=collections,proto3" json:"collections,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // 0 - token does not expire
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsed      int64                  `protobuf:"varint,7,opt,name=last_used,json=lastUsed,proto3" json:"last_used,omitempty"`
+	Tags          []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIToken) Reset() {
+	*x = APIToken{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIToken) ProtoMessage() {}
+
+func (x *APIToken) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIToken.ProtoReflect.Descriptor instead.
+func (*APIToken) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *APIToken) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APIToken) GetPermission() Permission {
+	if x != nil {
+		return x.Permission
+	}
+	return Permission_READ
+}
+
+func (x *APIToken) GetItems() []string {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *APIToken) GetCollections() []string {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+func (x *APIToken) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *APIToken) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *APIToken) GetLastUsed() int64 {
+	if x != nil {
+		return x.LastUsed
+	}
+	return 0
+}
+
+func (x *APIToken) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type APITokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // shown once, server keeps its hash
+	Info          *APIToken              `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APITokenResponse) Reset() {
+	*x = APITokenResponse{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APITokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APITokenResponse) ProtoMessage() {}
+
+func (x *APITokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APITokenResponse.ProtoReflect.Descriptor instead.
+func (*APITokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *APITokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *APITokenResponse) GetInfo() *APIToken {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type APITokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APITokensRequest) Reset() {
+	*x = APITokensRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APITokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APITokensRequest) ProtoMessage() {}
+
+func (x *APITokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APITokensRequest.ProtoReflect.Descriptor instead.
+func (*APITokensRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{26}
+}
+
+type APITokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []*APIToken            `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APITokensResponse) Reset() {
+	*x = APITokensResponse{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APITokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APITokensResponse) ProtoMessage() {}
+
+func (x *APITokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APITokensResponse.ProtoReflect.Descriptor instead.
+func (*APITokensResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *APITokensResponse) GetTokens() []*APIToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type RevokeAPITokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPITokenRequest) Reset() {
+	*x = RevokeAPITokenRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPITokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPITokenRequest) ProtoMessage() {}
+
+func (x *RevokeAPITokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPITokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAPITokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RevokeAPITokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RefreshResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // refresh token is rotated, old one is not valid
+	ExpiresAt     int64                  `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshResponse) Reset() {
+	*x = RefreshResponse{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshResponse) ProtoMessage() {}
+
+func (x *RefreshResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshResponse.ProtoReflect.Descriptor instead.
+func (*RefreshResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RefreshResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RefreshResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *RefreshResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type UserData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          TypeData               `protobuf:"varint,1,opt,name=type,proto3,enum=grpcgokeeper.TypeData" json:"type,omitempty"` // тип данных
+	Data          string                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Metadata      string                 `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Tokens        []string               `protobuf:"bytes,4,rep,name=tokens,proto3" json:"tokens,omitempty"`                         // Optional: blind index of metadata (end-to-end mode)
+	Attachments   []*Attachment          `protobuf:"bytes,5,rep,name=attachments,proto3" json:"attachments,omitempty"`               // attachments of item, in response
+	ExpiresAt     int64                  `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Optional: unix time of expiry, zero - never
+	Collection    string                 `protobuf:"bytes,7,opt,name=collection,proto3" json:"collection,omitempty"`                 // Optional: uuid of collection, item of team vault
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserData) Reset() {
+	*x = UserData{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserData) ProtoMessage() {}
+
+func (x *UserData) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserData.ProtoReflect.Descriptor instead.
+func (*UserData) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UserData) GetType() TypeData {
+	if x != nil {
+		return x.Type
+	}
+	return TypeData_LOGINDATA
+}
+
+func (x *UserData) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *UserData) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *UserData) GetTokens() []string {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *UserData) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *UserData) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *UserData) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // metadata of attachment
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Attachment) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *Attachment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ResponseAddData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseAddData) Reset() {
+	*x = ResponseAddData{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseAddData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseAddData) ProtoMessage() {}
+
+func (x *ResponseAddData) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseAddData.ProtoReflect.Descriptor instead.
+func (*ResponseAddData) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ResponseAddData) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type ResponseDeleteData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuids         []string               `protobuf:"bytes,1,rep,name=uuids,proto3" json:"uuids,omitempty"` // item and its attachments
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseDeleteData) Reset() {
+	*x = ResponseDeleteData{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseDeleteData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseDeleteData) ProtoMessage() {}
+
+func (x *ResponseDeleteData) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseDeleteData.ProtoReflect.Descriptor instead.
+func (*ResponseDeleteData) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ResponseDeleteData) GetUuids() []string {
+	if x != nil {
+		return x.Uuids
+	}
+	return nil
+}
+
+type ListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{34}
+}
+
+type DownloadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // Optional: start of range, resumed download
+	Length        int64                  `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"` // Optional: length of range, zero - to end
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadRequest) Reset() {
+	*x = DownloadRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadRequest) ProtoMessage() {}
+
+func (x *DownloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadRequest.ProtoReflect.Descriptor instead.
+func (*DownloadRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DownloadRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *DownloadRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DownloadRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type DataChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`                             // The actual byte data for the chunk
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`                        // Optional: for tracking progress/resuming
+	Metadata      string                 `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`                     //Optional
+	Type          TypeData               `protobuf:"varint,4,opt,name=type,proto3,enum=grpcgokeeper.TypeData" json:"type,omitempty"` // тип данных
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	Tokens        []string               `protobuf:"bytes,6,rep,name=tokens,proto3" json:"tokens,omitempty"`                          // Optional: blind index of metadata (end-to-end mode)
+	Parent        string                 `protobuf:"bytes,7,opt,name=parent,proto3" json:"parent,omitempty"`                          // Optional: uuid of item, stream is attachment
+	Session       string                 `protobuf:"bytes,8,opt,name=session,proto3" json:"session,omitempty"`                        // Optional: upload session id, resumable upload
+	Checksum      string                 `protobuf:"bytes,9,opt,name=checksum,proto3" json:"checksum,omitempty"`                      // Optional: hex sha256 of whole data, last chunk of upload, first chunk of download
+	Compression   string                 `protobuf:"bytes,10,opt,name=compression,proto3" json:"compression,omitempty"`               // Optional: first chunk of upload, auto (default), none, gzip, gzip-fast, lz
+	ExpiresAt     int64                  `protobuf:"varint,11,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Optional: unix time of expiry, first chunk, zero - never
+	Collection    string                 `protobuf:"bytes,12,opt,name=collection,proto3" json:"collection,omitempty"`                 // Optional: uuid of collection, first chunk, item of team vault
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataChunk) Reset() {
+	*x = DataChunk{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataChunk) ProtoMessage() {}
+
+func (x *DataChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataChunk.ProtoReflect.Descriptor instead.
+func (*DataChunk) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DataChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DataChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DataChunk) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *DataChunk) GetType() TypeData {
+	if x != nil {
+		return x.Type
+	}
+	return TypeData_LOGINDATA
+}
+
+func (x *DataChunk) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *DataChunk) GetTokens() []string {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *DataChunk) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *DataChunk) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
+func (x *DataChunk) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *DataChunk) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+func (x *DataChunk) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *DataChunk) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+type QueryUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       string                 `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryUploadRequest) Reset() {
+	*x = QueryUploadRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryUploadRequest) ProtoMessage() {}
+
+func (x *QueryUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryUploadRequest.ProtoReflect.Descriptor instead.
+func (*QueryUploadRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *QueryUploadRequest) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
+type QueryUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       string                 `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // committed offset, upload resumes from it
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`     // declared size
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryUploadResponse) Reset() {
+	*x = QueryUploadResponse{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryUploadResponse) ProtoMessage() {}
+
+func (x *QueryUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryUploadResponse.ProtoReflect.Descriptor instead.
+func (*QueryUploadResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *QueryUploadResponse) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
+func (x *QueryUploadResponse) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *QueryUploadResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`   // plaintext query, server-encrypted mode
+	Tokens        []string               `protobuf:"bytes,2,rep,name=tokens,proto3" json:"tokens,omitempty"` // blind index query, end-to-end mode
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetTokens() []string {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Type          TypeData               `protobuf:"varint,2,opt,name=type,proto3,enum=grpcgokeeper.TypeData" json:"type,omitempty"`
+	Metadata      string                 `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"` // highlighted in server-encrypted mode
+	Score         int32                  `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	Fields        []string               `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty"` // matched fields
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SearchResult) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *SearchResult) GetType() TypeData {
+	if x != nil {
+		return x.Type
+	}
+	return TypeData_LOGINDATA
+}
+
+func (x *SearchResult) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *SearchResult) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchResult) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type UsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageRequest) Reset() {
+	*x = UsageRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageRequest) ProtoMessage() {}
+
+func (x *UsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageRequest.ProtoReflect.Descriptor instead.
+func (*UsageRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{41}
+}
+
+type UsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         int64                  `protobuf:"varint,1,opt,name=items,proto3" json:"items,omitempty"`
+	Bytes         int64                  `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`                             // plain size of stored data
+	QuotaItems    int64                  `protobuf:"varint,3,opt,name=quota_items,json=quotaItems,proto3" json:"quota_items,omitempty"` // zero - unlimited
+	QuotaBytes    int64                  `protobuf:"varint,4,opt,name=quota_bytes,json=quotaBytes,proto3" json:"quota_bytes,omitempty"` // zero - unlimited
+	MaxUpload     int64                  `protobuf:"varint,5,opt,name=max_upload,json=maxUpload,proto3" json:"max_upload,omitempty"`    // maximum size of one upload, zero - unlimited
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageResponse) Reset() {
+	*x = UsageResponse{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageResponse) ProtoMessage() {}
+
+func (x *UsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageResponse.ProtoReflect.Descriptor instead.
+func (*UsageResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *UsageResponse) GetItems() int64 {
+	if x != nil {
+		return x.Items
+	}
+	return 0
+}
+
+func (x *UsageResponse) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *UsageResponse) GetQuotaItems() int64 {
+	if x != nil {
+		return x.QuotaItems
+	}
+	return 0
+}
+
+func (x *UsageResponse) GetQuotaBytes() int64 {
+	if x != nil {
+		return x.QuotaBytes
+	}
+	return 0
+}
+
+func (x *UsageResponse) GetMaxUpload() int64 {
+	if x != nil {
+		return x.MaxUpload
+	}
+	return 0
+}
+
+type UpdateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Data          string                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Metadata      string                 `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"` // Optional: empty - metadata is kept
+	Tokens        []string               `protobuf:"bytes,4,rep,name=tokens,proto3" json:"tokens,omitempty"`     // Optional: blind index of new metadata (end-to-end mode)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRequest) Reset() {
+	*x = UpdateRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRequest) ProtoMessage() {}
+
+func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UpdateRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UpdateRequest) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *UpdateRequest) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *UpdateRequest) GetTokens() []string {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type ResponseUpdateData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseUpdateData) Reset() {
+	*x = ResponseUpdateData{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseUpdateData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseUpdateData) ProtoMessage() {}
+
+func (x *ResponseUpdateData) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseUpdateData.ProtoReflect.Descriptor instead.
+func (*ResponseUpdateData) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{44}
+}
+
+type RotationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`  // login item or collection, empty - default policy of user
+	Days          int32                  `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"` // rotation period, zero - no policy
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotationRequest) Reset() {
+	*x = RotationRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotationRequest) ProtoMessage() {}
+
+func (x *RotationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotationRequest.ProtoReflect.Descriptor instead.
+func (*RotationRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RotationRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *RotationRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type ResponseRotation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseRotation) Reset() {
+	*x = ResponseRotation{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseRotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseRotation) ProtoMessage() {}
+
+func (x *ResponseRotation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseRotation.ProtoReflect.Descriptor instead.
+func (*ResponseRotation) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{46}
+}
+
+type DueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	At            int64                  `protobuf:"varint,1,opt,name=at,proto3" json:"at,omitempty"` // Optional: unix time of report, zero - now
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DueRequest) Reset() {
+	*x = DueRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DueRequest) ProtoMessage() {}
+
+func (x *DueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DueRequest.ProtoReflect.Descriptor instead.
+func (*DueRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DueRequest) GetAt() int64 {
+	if x != nil {
+		return x.At
+	}
+	return 0
+}
+
+type DueItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Metadata      string                 `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ChangedAt     int64                  `protobuf:"varint,3,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"` // unix time of last change of data
+	DueAt         int64                  `protobuf:"varint,4,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`             // unix time rotation was due
+	Days          int32                  `protobuf:"varint,5,opt,name=days,proto3" json:"days,omitempty"`                            // rotation policy of item
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DueItem) Reset() {
+	*x = DueItem{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DueItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DueItem) ProtoMessage() {}
+
+func (x *DueItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DueItem.ProtoReflect.Descriptor instead.
+func (*DueItem) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *DueItem) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *DueItem) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *DueItem) GetChangedAt() int64 {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return 0
+}
+
+func (x *DueItem) GetDueAt() int64 {
+	if x != nil {
+		return x.DueAt
+	}
+	return 0
+}
+
+func (x *DueItem) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type DueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*DueItem             `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DueResponse) Reset() {
+	*x = DueResponse{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DueResponse) ProtoMessage() {}
+
+func (x *DueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DueResponse.ProtoReflect.Descriptor instead.
+func (*DueResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *DueResponse) GetItems() []*DueItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type SetKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey     string                 `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`    // hex PKIX rsa key
+	PrivateKey    string                 `protobuf:"bytes,2,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"` // private key wrapped by client master key, opaque for server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetKeysRequest) Reset() {
+	*x = SetKeysRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetKeysRequest) ProtoMessage() {}
+
+func (x *SetKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetKeysRequest.ProtoReflect.Descriptor instead.
+func (*SetKeysRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SetKeysRequest) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *SetKeysRequest) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+type ResponseSetKeys struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseSetKeys) Reset() {
+	*x = ResponseSetKeys{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseSetKeys) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseSetKeys) ProtoMessage() {}
+
+func (x *ResponseSetKeys) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseSetKeys.ProtoReflect.Descriptor instead.
+func (*ResponseSetKeys) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{51}
+}
+
+type ShareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Recipient     string                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"` // name of user
+	Permission    Permission             `protobuf:"varint,3,opt,name=permission,proto3,enum=grpcgokeeper.Permission" json:"permission,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareRequest) Reset() {
+	*x = ShareRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareRequest) ProtoMessage() {}
+
+func (x *ShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareRequest.ProtoReflect.Descriptor instead.
+func (*ShareRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ShareRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *ShareRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *ShareRequest) GetPermission() Permission {
+	if x != nil {
+		return x.Permission
+	}
+	return Permission_READ
+}
+
+type ResponseShare struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WrappedKey    string                 `protobuf:"bytes,1,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"` // item key wrapped by public key of recipient
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseShare) Reset() {
+	*x = ResponseShare{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseShare) ProtoMessage() {}
+
+func (x *ResponseShare) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseShare.ProtoReflect.Descriptor instead.
+func (*ResponseShare) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ResponseShare) GetWrappedKey() string {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return ""
+}
+
+type UnshareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Recipient     string                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"` // name of user
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareRequest) Reset() {
+	*x = UnshareRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareRequest) ProtoMessage() {}
+
+func (x *UnshareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareRequest.ProtoReflect.Descriptor instead.
+func (*UnshareRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *UnshareRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UnshareRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+type ResponseUnshare struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseUnshare) Reset() {
+	*x = ResponseUnshare{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseUnshare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseUnshare) ProtoMessage() {}
+
+func (x *ResponseUnshare) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseUnshare.ProtoReflect.Descriptor instead.
+func (*ResponseUnshare) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{55}
+}
+
+type SharedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SharedRequest) Reset() {
+	*x = SharedRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedRequest) ProtoMessage() {}
+
+func (x *SharedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedRequest.ProtoReflect.Descriptor instead.
+func (*SharedRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{56}
+}
+
+type SharedItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Owner         string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"` // name of owner
+	Type          TypeData               `protobuf:"varint,3,opt,name=type,proto3,enum=grpcgokeeper.TypeData" json:"type,omitempty"`
+	Metadata      string                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Permission    Permission             `protobuf:"varint,5,opt,name=permission,proto3,enum=grpcgokeeper.Permission" json:"permission,omitempty"`
+	WrappedKey    string                 `protobuf:"bytes,6,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SharedItem) Reset() {
+	*x = SharedItem{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedItem) ProtoMessage() {}
+
+func (x *SharedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedItem.ProtoReflect.Descriptor instead.
+func (*SharedItem) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SharedItem) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *SharedItem) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *SharedItem) GetType() TypeData {
+	if x != nil {
+		return x.Type
+	}
+	return TypeData_LOGINDATA
+}
+
+func (x *SharedItem) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *SharedItem) GetPermission() Permission {
+	if x != nil {
+		return x.Permission
+	}
+	return Permission_READ
+}
+
+func (x *SharedItem) GetWrappedKey() string {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return ""
+}
+
+type SharedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*SharedItem          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SharedResponse) Reset() {
+	*x = SharedResponse{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedResponse) ProtoMessage() {}
+
+func (x *SharedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedResponse.ProtoReflect.Descriptor instead.
+func (*SharedResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *SharedResponse) GetItems() []*SharedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// SealedItem - item encrypted by item key, reader opens item key wrapped by its public key
+type SealedItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Type          TypeData               `protobuf:"varint,2,opt,name=type,proto3,enum=grpcgokeeper.TypeData" json:"type,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Metadata      []byte                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SealedItem) Reset() {
+	*x = SealedItem{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SealedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SealedItem) ProtoMessage() {}
+
+func (x *SealedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SealedItem.ProtoReflect.Descriptor instead.
+func (*SealedItem) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *SealedItem) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *SealedItem) GetType() TypeData {
+	if x != nil {
+		return x.Type
+	}
+	return TypeData_LOGINDATA
+}
+
+func (x *SealedItem) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SealedItem) GetMetadata() []byte {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type OrgRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgRequest) Reset() {
+	*x = OrgRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgRequest) ProtoMessage() {}
+
+func (x *OrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgRequest.ProtoReflect.Descriptor instead.
+func (*OrgRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *OrgRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type OrgResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgResponse) Reset() {
+	*x = OrgResponse{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgResponse) ProtoMessage() {}
+
+func (x *OrgResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgResponse.ProtoReflect.Descriptor instead.
+func (*OrgResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *OrgResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type MemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Org           string                 `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	User          string                 `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`                         // name of user
+	Role          Role                   `protobuf:"varint,3,opt,name=role,proto3,enum=grpcgokeeper.Role" json:"role,omitempty"` // AddMember only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemberRequest) Reset() {
+	*x = MemberRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemberRequest) ProtoMessage() {}
+
+func (x *MemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemberRequest.ProtoReflect.Descriptor instead.
+func (*MemberRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *MemberRequest) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *MemberRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *MemberRequest) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_OWNER
+}
+
+type ResponseMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseMember) Reset() {
+	*x = ResponseMember{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseMember) ProtoMessage() {}
+
+func (x *ResponseMember) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseMember.ProtoReflect.Descriptor instead.
+func (*ResponseMember) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{63}
+}
+
+type MembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Org           string                 `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MembersRequest) Reset() {
+	*x = MembersRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MembersRequest) ProtoMessage() {}
+
+func (x *MembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MembersRequest.ProtoReflect.Descriptor instead.
+func (*MembersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *MembersRequest) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+type Member struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Role          Role                   `protobuf:"varint,2,opt,name=role,proto3,enum=grpcgokeeper.Role" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Member) Reset() {
+	*x = Member{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Member) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Member) ProtoMessage() {}
+
+func (x *Member) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Member.ProtoReflect.Descriptor instead.
+func (*Member) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *Member) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Member) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_OWNER
+}
+
+type MembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*Member              `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MembersResponse) Reset() {
+	*x = MembersResponse{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MembersResponse) ProtoMessage() {}
+
+func (x *MembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MembersResponse.ProtoReflect.Descriptor instead.
+func (*MembersResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *MembersResponse) GetMembers() []*Member {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type CollectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Org           string                 `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionRequest) Reset() {
+	*x = CollectionRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionRequest) ProtoMessage() {}
+
+func (x *CollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionRequest.ProtoReflect.Descriptor instead.
+func (*CollectionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *CollectionRequest) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *CollectionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CollectionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionResponse) Reset() {
+	*x = CollectionResponse{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionResponse) ProtoMessage() {}
+
+func (x *CollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionResponse.ProtoReflect.Descriptor instead.
+func (*CollectionResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *CollectionResponse) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type CollectionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionsRequest) Reset() {
+	*x = CollectionsRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionsRequest) ProtoMessage() {}
+
+func (x *CollectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionsRequest.ProtoReflect.Descriptor instead.
+func (*CollectionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{69}
+}
+
+type Collection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Org           string                 `protobuf:"bytes,2,opt,name=org,proto3" json:"org,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Role          Role                   `protobuf:"varint,4,opt,name=role,proto3,enum=grpcgokeeper.Role" json:"role,omitempty"`       // role of user in organization
+	Version       int32                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`                        // number of key rotations
+	WrappedKey    string                 `protobuf:"bytes,6,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"` // collection key wrapped by public key of user
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Collection) Reset() {
+	*x = Collection{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Collection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collection) ProtoMessage() {}
+
+func (x *Collection) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collection.ProtoReflect.Descriptor instead.
+func (*Collection) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *Collection) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *Collection) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *Collection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Collection) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_OWNER
+}
+
+func (x *Collection) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Collection) GetWrappedKey() string {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return ""
+}
+
+type CollectionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collections   []*Collection          `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionsResponse) Reset() {
+	*x = CollectionsResponse{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionsResponse) ProtoMessage() {}
+
+func (x *CollectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionsResponse.ProtoReflect.Descriptor instead.
+func (*CollectionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *CollectionsResponse) GetCollections() []*Collection {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+type CollectionItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionItemsRequest) Reset() {
+	*x = CollectionItemsRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionItemsRequest) ProtoMessage() {}
+
+func (x *CollectionItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionItemsRequest.ProtoReflect.Descriptor instead.
+func (*CollectionItemsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CollectionItemsRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type CollectionItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Owner         string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"` // name of user who added item
+	Type          TypeData               `protobuf:"varint,3,opt,name=type,proto3,enum=grpcgokeeper.TypeData" json:"type,omitempty"`
+	Metadata      string                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionItem) Reset() {
+	*x = CollectionItem{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionItem) ProtoMessage() {}
+
+func (x *CollectionItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionItem.ProtoReflect.Descriptor instead.
+func (*CollectionItem) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *CollectionItem) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *CollectionItem) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *CollectionItem) GetType() TypeData {
+	if x != nil {
+		return x.Type
+	}
+	return TypeData_LOGINDATA
+}
+
+func (x *CollectionItem) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+type CollectionItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*CollectionItem      `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionItemsResponse) Reset() {
+	*x = CollectionItemsResponse{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionItemsResponse) ProtoMessage() {}
+
+func (x *CollectionItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionItemsResponse.ProtoReflect.Descriptor instead.
+func (*CollectionItemsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *CollectionItemsResponse) GetItems() []*CollectionItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type SecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ciphertext    string                 `protobuf:"bytes,1,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`                 // secret sealed by key of link fragment
+	MaxViews      int32                  `protobuf:"varint,2,opt,name=max_views,json=maxViews,proto3" json:"max_views,omitempty"`    // Optional: zero - one view
+	ExpiresAt     int64                  `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Optional: unix time of expiry, zero - default lifetime of server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretRequest) Reset() {
+	*x = SecretRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretRequest) ProtoMessage() {}
+
+func (x *SecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretRequest.ProtoReflect.Descriptor instead.
+func (*SecretRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *SecretRequest) GetCiphertext() string {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return ""
+}
+
+func (x *SecretRequest) GetMaxViews() int32 {
+	if x != nil {
+		return x.MaxViews
+	}
+	return 0
+}
+
+func (x *SecretRequest) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type SecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretResponse) Reset() {
+	*x = SecretResponse{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretResponse) ProtoMessage() {}
+
+func (x *SecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretResponse.ProtoReflect.Descriptor instead.
+func (*SecretResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *SecretResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SecretResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type RedeemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemRequest) Reset() {
+	*x = RedeemRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemRequest) ProtoMessage() {}
+
+func (x *RedeemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemRequest.ProtoReflect.Descriptor instead.
+func (*RedeemRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *RedeemRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RedeemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ciphertext    string                 `protobuf:"bytes,1,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	ViewsLeft     int32                  `protobuf:"varint,2,opt,name=views_left,json=viewsLeft,proto3" json:"views_left,omitempty"` // zero - secret is destroyed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemResponse) Reset() {
+	*x = RedeemResponse{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemResponse) ProtoMessage() {}
+
+func (x *RedeemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemResponse.ProtoReflect.Descriptor instead.
+func (*RedeemResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *RedeemResponse) GetCiphertext() string {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return ""
+}
+
+func (x *RedeemResponse) GetViewsLeft() int32 {
+	if x != nil {
+		return x.ViewsLeft
+	}
+	return 0
+}
+
+type EmergencyInvite struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Contact       string                 `protobuf:"bytes,1,opt,name=contact,proto3" json:"contact,omitempty"`
+	WaitSeconds   int64                  `protobuf:"varint,2,opt,name=wait_seconds,json=waitSeconds,proto3" json:"wait_seconds,omitempty"` // Optional: waiting period of owner to deny request, zero - default of server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmergencyInvite) Reset() {
+	*x = EmergencyInvite{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmergencyInvite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmergencyInvite) ProtoMessage() {}
+
+func (x *EmergencyInvite) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmergencyInvite.ProtoReflect.Descriptor instead.
+func (*EmergencyInvite) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *EmergencyInvite) GetContact() string {
+	if x != nil {
+		return x.Contact
+	}
+	return ""
+}
+
+func (x *EmergencyInvite) GetWaitSeconds() int64 {
+	if x != nil {
+		return x.WaitSeconds
+	}
+	return 0
+}
+
+type EmergencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Approve       bool                   `protobuf:"varint,2,opt,name=approve,proto3" json:"approve,omitempty"` // ApproveEmergency: true - approve, false - deny
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmergencyRequest) Reset() {
+	*x = EmergencyRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmergencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmergencyRequest) ProtoMessage() {}
+
+func (x *EmergencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmergencyRequest.ProtoReflect.Descriptor instead.
+func (*EmergencyRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *EmergencyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EmergencyRequest) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
+type Emergency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Owner         string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Contact       string                 `protobuf:"bytes,3,opt,name=contact,proto3" json:"contact,omitempty"`
+	State         EmergencyState         `protobuf:"varint,4,opt,name=state,proto3,enum=grpcgokeeper.EmergencyState" json:"state,omitempty"`
+	WaitSeconds   int64                  `protobuf:"varint,5,opt,name=wait_seconds,json=waitSeconds,proto3" json:"wait_seconds,omitempty"`
+	RequestedAt   int64                  `protobuf:"varint,6,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"` // unix time of request, zero - not requested
+	GrantedAt     int64                  `protobuf:"varint,7,opt,name=granted_at,json=grantedAt,proto3" json:"granted_at,omitempty"`       // unix time of approval, zero - not approved
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Emergency) Reset() {
+	*x = Emergency{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Emergency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Emergency) ProtoMessage() {}
+
+func (x *Emergency) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Emergency.ProtoReflect.Descriptor instead.
+func (*Emergency) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *Emergency) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Emergency) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Emergency) GetContact() string {
+	if x != nil {
+		return x.Contact
+	}
+	return ""
+}
+
+func (x *Emergency) GetState() EmergencyState {
+	if x != nil {
+		return x.State
+	}
+	return EmergencyState_INVITED
+}
+
+func (x *Emergency) GetWaitSeconds() int64 {
+	if x != nil {
+		return x.WaitSeconds
+	}
+	return 0
+}
+
+func (x *Emergency) GetRequestedAt() int64 {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return 0
+}
+
+func (x *Emergency) GetGrantedAt() int64 {
+	if x != nil {
+		return x.GrantedAt
+	}
+	return 0
+}
+
+type EmergencyListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmergencyListRequest) Reset() {
+	*x = EmergencyListRequest{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmergencyListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmergencyListRequest) ProtoMessage() {}
+
+func (x *EmergencyListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmergencyListRequest.ProtoReflect.Descriptor instead.
+func (*EmergencyListRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{82}
+}
+
+type EmergencyList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Emergency     []*Emergency           `protobuf:"bytes,1,rep,name=emergency,proto3" json:"emergency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmergencyList) Reset() {
+	*x = EmergencyList{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmergencyList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmergencyList) ProtoMessage() {}
+
+func (x *EmergencyList) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmergencyList.ProtoReflect.Descriptor instead.
+func (*EmergencyList) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *EmergencyList) GetEmergency() []*Emergency {
+	if x != nil {
+		return x.Emergency
+	}
+	return nil
+}
+
+type EmergencyItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Type          TypeData               `protobuf:"varint,2,opt,name=type,proto3,enum=grpcgokeeper.TypeData" json:"type,omitempty"`
+	Metadata      string                 `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	WrappedKey    string                 `protobuf:"bytes,4,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"` // item key wrapped by public key of contact
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmergencyItem) Reset() {
+	*x = EmergencyItem{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmergencyItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmergencyItem) ProtoMessage() {}
+
+func (x *EmergencyItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmergencyItem.ProtoReflect.Descriptor instead.
+func (*EmergencyItem) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *EmergencyItem) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *EmergencyItem) GetType() TypeData {
+	if x != nil {
+		return x.Type
+	}
+	return TypeData_LOGINDATA
+}
+
+func (x *EmergencyItem) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *EmergencyItem) GetWrappedKey() string {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return ""
+}
+
+type EmergencyItems struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*EmergencyItem       `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmergencyItems) Reset() {
+	*x = EmergencyItems{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmergencyItems) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmergencyItems) ProtoMessage() {}
+
+func (x *EmergencyItems) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmergencyItems.ProtoReflect.Descriptor instead.
+func (*EmergencyItems) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *EmergencyItems) GetItems() []*EmergencyItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ResponseEmergency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseEmergency) Reset() {
+	*x = ResponseEmergency{}
+	mi := &file_api_proto_gokeeper_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseEmergency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseEmergency) ProtoMessage() {}
+
+func (x *ResponseEmergency) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gokeeper_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseEmergency.ProtoReflect.Descriptor instead.
+func (*ResponseEmergency) Descriptor() ([]byte, []int) {
+	return file_api_proto_gokeeper_proto_rawDescGZIP(), []int{86}
+}
+
+var File_api_proto_gokeeper_proto protoreflect.FileDescriptor
+
+const file_api_proto_gokeeper_proto_rawDesc = "" +
+	"\n" +
+	"\x18api/proto/gokeeper.proto\x12\fgrpcgokeeper\"\xca\x01\n" +
 	"\fLoginRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"%\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1b\n" +
+	"\tvault_key\x18\x03 \x01(\tR\bvaultKey\x12\x16\n" +
+	"\x06device\x18\x04 \x01(\tR\x06device\x12%\n" +
+	"\x0eclient_version\x18\x05 \x01(\tR\rclientVersion\x12\x10\n" +
+	"\x03otp\x18\x06 \x01(\tR\x03otp\x12\x1c\n" +
+	"\tchallenge\x18\a \x01(\tR\tchallenge\"\xe4\x01\n" +
 	"\rLoginResponse\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"f\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1b\n" +
+	"\tvault_key\x18\x02 \x01(\tR\bvaultKey\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x03 \x01(\tR\tpublicKey\x12\x1f\n" +
+	"\vprivate_key\x18\x04 \x01(\tR\n" +
+	"privateKey\x12#\n" +
+	"\rrefresh_token\x18\x05 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\x03R\texpiresAt\x12\x1c\n" +
+	"\tchallenge\x18\a \x01(\tR\tchallenge\"?\n" +
+	"\rEnrollRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\":\n" +
+	"\x0eEnrollResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x10\n" +
+	"\x03uri\x18\x02 \x01(\tR\x03uri\"'\n" +
+	"\x11VerifyTOTPRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\";\n" +
+	"\x12VerifyTOTPResponse\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes\"5\n" +
+	"\x0eRefreshRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"4\n" +
+	"\rLogoutRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\x12\n" +
+	"\x10RevokeAllRequest\"\x10\n" +
+	"\x0eResponseLogout\"\x11\n" +
+	"\x0fSessionsRequest\"\xc8\x01\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06device\x18\x02 \x01(\tR\x06device\x12%\n" +
+	"\x0eclient_version\x18\x03 \x01(\tR\rclientVersion\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\x1b\n" +
+	"\tlast_seen\x18\x06 \x01(\x03R\blastSeen\x12\x18\n" +
+	"\acurrent\x18\a \x01(\bR\acurrent\"E\n" +
+	"\x10SessionsResponse\x121\n" +
+	"\bsessions\x18\x01 \x03(\v2\x15.grpcgokeeper.SessionR\bsessions\"&\n" +
+	"\x14RevokeSessionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xd3\x01\n" +
+	"\x15ChangePasswordRequest\x12!\n" +
+	"\fold_password\x18\x01 \x01(\tR\voldPassword\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1b\n" +
+	"\tvault_key\x18\x03 \x01(\tR\bvaultKey\x12\x1f\n" +
+	"\vprivate_key\x18\x04 \x01(\tR\n" +
+	"privateKey\x12\x16\n" +
+	"\x06device\x18\x05 \x01(\tR\x06device\x12%\n" +
+	"\x0eclient_version\x18\x06 \x01(\tR\rclientVersion\"n\n" +
+	"\x12SetRecoveryRequest\x12\x1a\n" +
+	"\brecovery\x18\x01 \x01(\tR\brecovery\x12\x1b\n" +
+	"\tvault_key\x18\x02 \x01(\tR\bvaultKey\x12\x1f\n" +
+	"\vprivate_key\x18\x03 \x01(\tR\n" +
+	"privateKey\"\x15\n" +
+	"\x13ResponseSetRecovery\"\xec\x01\n" +
+	"\x0fRecoveryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\brecovery\x18\x02 \x01(\tR\brecovery\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x1b\n" +
+	"\tvault_key\x18\x04 \x01(\tR\bvaultKey\x12\x1f\n" +
+	"\vprivate_key\x18\x05 \x01(\tR\n" +
+	"privateKey\x12\x16\n" +
+	"\x06device\x18\x06 \x01(\tR\x06device\x12%\n" +
+	"\x0eclient_version\x18\a \x01(\tR\rclientVersion\x12\x10\n" +
+	"\x03otp\x18\b \x01(\tR\x03otp\"P\n" +
+	"\x10RecoveryResponse\x12\x1b\n" +
+	"\tvault_key\x18\x01 \x01(\tR\bvaultKey\x12\x1f\n" +
+	"\vprivate_key\x18\x02 \x01(\tR\n" +
+	"privateKey\"\x0f\n" +
+	"\rExportRequest\"\xc9\x02\n" +
+	"\vExportChunk\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12*\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x16.grpcgokeeper.TypeDataR\x04type\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\tR\x04data\x12\x1a\n" +
+	"\bmetadata\x18\x04 \x01(\tR\bmetadata\x12\x16\n" +
+	"\x06parent\x18\x05 \x01(\tR\x06parent\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x06 \x01(\tR\n" +
+	"collection\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\x03R\texpiresAt\x12\x17\n" +
+	"\ais_blob\x18\b \x01(\bR\x06isBlob\x12\x12\n" +
+	"\x04blob\x18\t \x01(\fR\x04blob\x12\x16\n" +
+	"\x06offset\x18\n" +
+	" \x01(\x03R\x06offset\x12\x12\n" +
+	"\x04size\x18\v \x01(\x03R\x04size\x12\x1a\n" +
+	"\bchecksum\x18\f \x01(\tR\bchecksum\"2\n" +
+	"\x14DeleteAccountRequest\x12\x1a\n" +
+	"\bpassword\x18\x01 \x01(\tR\bpassword\"\x17\n" +
+	"\x15ResponseDeleteAccount\"\xca\x01\n" +
+	"\x0fAPITokenRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x128\n" +
+	"\n" +
+	"permission\x18\x02 \x01(\x0e2\x18.grpcgokeeper.PermissionR\n" +
+	"permission\x12\x14\n" +
+	"\x05items\x18\x03 \x03(\tR\x05items\x12 \n" +
+	"\vcollections\x18\x04 \x03(\tR\vcollections\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x05 \x01(\x03R\texpiresIn\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\"\xff\x01\n" +
+	"\bAPIToken\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x128\n" +
+	"\n" +
+	"permission\x18\x02 \x01(\x0e2\x18.grpcgokeeper.PermissionR\n" +
+	"permission\x12\x14\n" +
+	"\x05items\x18\x03 \x03(\tR\x05items\x12 \n" +
+	"\vcollections\x18\x04 \x03(\tR\vcollections\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\x03R\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1b\n" +
+	"\tlast_used\x18\a \x01(\x03R\blastUsed\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\"T\n" +
+	"\x10APITokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12*\n" +
+	"\x04info\x18\x02 \x01(\v2\x16.grpcgokeeper.APITokenR\x04info\"\x12\n" +
+	"\x10APITokensRequest\"C\n" +
+	"\x11APITokensResponse\x12.\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x16.grpcgokeeper.APITokenR\x06tokens\"+\n" +
+	"\x15RevokeAPITokenRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"k\n" +
+	"\x0fRefreshResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\x03R\texpiresAt\"\xf9\x01\n" +
 	"\bUserData\x12*\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x16.grpcgokeeper.TypeDataR\x04type\x12\x12\n" +
 	"\x04data\x18\x02 \x01(\tR\x04data\x12\x1a\n" +
-	"\bmetadata\x18\x03 \x01(\tR\bmetadata\"%\n" +
+	"\bmetadata\x18\x03 \x01(\tR\bmetadata\x12\x16\n" +
+	"\x06tokens\x18\x04 \x03(\tR\x06tokens\x12:\n" +
+	"\vattachments\x18\x05 \x03(\v2\x18.grpcgokeeper.AttachmentR\vattachments\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\x03R\texpiresAt\x12\x1e\n" +
+	"\n" +
+	"collection\x18\a \x01(\tR\n" +
+	"collection\"H\n" +
+	"\n" +
+	"Attachment\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\"%\n" +
 	"\x0fResponseAddData\x12\x12\n" +
-	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\r\n" +
-	"\vListRequest\"%\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"*\n" +
+	"\x12ResponseDeleteData\x12\x14\n" +
+	"\x05uuids\x18\x01 \x03(\tR\x05uuids\"\r\n" +
+	"\vListRequest\"U\n" +
 	"\x0fDownloadRequest\x12\x12\n" +
-	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x93\x01\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x03R\x06length\"\xda\x02\n" +
 	"\tDataChunk\x12\x12\n" +
 	"\x04data\x18\x01 \x01(\fR\x04data\x12\x16\n" +
 	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x1a\n" +
 	"\bmetadata\x18\x03 \x01(\tR\bmetadata\x12*\n" +
 	"\x04type\x18\x04 \x01(\x0e2\x16.grpcgokeeper.TypeDataR\x04type\x12\x12\n" +
-	"\x04size\x18\x05 \x01(\x03R\x04size*E\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06tokens\x18\x06 \x03(\tR\x06tokens\x12\x16\n" +
+	"\x06parent\x18\a \x01(\tR\x06parent\x12\x18\n" +
+	"\asession\x18\b \x01(\tR\asession\x12\x1a\n" +
+	"\bchecksum\x18\t \x01(\tR\bchecksum\x12 \n" +
+	"\vcompression\x18\n" +
+	" \x01(\tR\vcompression\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\v \x01(\x03R\texpiresAt\x12\x1e\n" +
+	"\n" +
+	"collection\x18\f \x01(\tR\n" +
+	"collection\".\n" +
+	"\x12QueryUploadRequest\x12\x18\n" +
+	"\asession\x18\x01 \x01(\tR\asession\"[\n" +
+	"\x13QueryUploadResponse\x12\x18\n" +
+	"\asession\x18\x01 \x01(\tR\asession\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\"=\n" +
+	"\rSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x16\n" +
+	"\x06tokens\x18\x02 \x03(\tR\x06tokens\"\x98\x01\n" +
+	"\fSearchResult\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12*\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x16.grpcgokeeper.TypeDataR\x04type\x12\x1a\n" +
+	"\bmetadata\x18\x03 \x01(\tR\bmetadata\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x05R\x05score\x12\x16\n" +
+	"\x06fields\x18\x05 \x03(\tR\x06fields\"\x0e\n" +
+	"\fUsageRequest\"\x9c\x01\n" +
+	"\rUsageResponse\x12\x14\n" +
+	"\x05items\x18\x01 \x01(\x03R\x05items\x12\x14\n" +
+	"\x05bytes\x18\x02 \x01(\x03R\x05bytes\x12\x1f\n" +
+	"\vquota_items\x18\x03 \x01(\x03R\n" +
+	"quotaItems\x12\x1f\n" +
+	"\vquota_bytes\x18\x04 \x01(\x03R\n" +
+	"quotaBytes\x12\x1d\n" +
+	"\n" +
+	"max_upload\x18\x05 \x01(\x03R\tmaxUpload\"k\n" +
+	"\rUpdateRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\tR\x04data\x12\x1a\n" +
+	"\bmetadata\x18\x03 \x01(\tR\bmetadata\x12\x16\n" +
+	"\x06tokens\x18\x04 \x03(\tR\x06tokens\"\x14\n" +
+	"\x12ResponseUpdateData\"9\n" +
+	"\x0fRotationRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\"\x12\n" +
+	"\x10ResponseRotation\"\x1c\n" +
+	"\n" +
+	"DueRequest\x12\x0e\n" +
+	"\x02at\x18\x01 \x01(\x03R\x02at\"\x83\x01\n" +
+	"\aDueItem\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1a\n" +
+	"\bmetadata\x18\x02 \x01(\tR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\x03 \x01(\x03R\tchangedAt\x12\x15\n" +
+	"\x06due_at\x18\x04 \x01(\x03R\x05dueAt\x12\x12\n" +
+	"\x04days\x18\x05 \x01(\x05R\x04days\":\n" +
+	"\vDueResponse\x12+\n" +
+	"\x05items\x18\x01 \x03(\v2\x15.grpcgokeeper.DueItemR\x05items\"P\n" +
+	"\x0eSetKeysRequest\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\tR\tpublicKey\x12\x1f\n" +
+	"\vprivate_key\x18\x02 \x01(\tR\n" +
+	"privateKey\"\x11\n" +
+	"\x0fResponseSetKeys\"z\n" +
+	"\fShareRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\x128\n" +
+	"\n" +
+	"permission\x18\x03 \x01(\x0e2\x18.grpcgokeeper.PermissionR\n" +
+	"permission\"0\n" +
+	"\rResponseShare\x12\x1f\n" +
+	"\vwrapped_key\x18\x01 \x01(\tR\n" +
+	"wrappedKey\"B\n" +
+	"\x0eUnshareRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\"\x11\n" +
+	"\x0fResponseUnshare\"\x0f\n" +
+	"\rSharedRequest\"\xd9\x01\n" +
+	"\n" +
+	"SharedItem\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12*\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x16.grpcgokeeper.TypeDataR\x04type\x12\x1a\n" +
+	"\bmetadata\x18\x04 \x01(\tR\bmetadata\x128\n" +
+	"\n" +
+	"permission\x18\x05 \x01(\x0e2\x18.grpcgokeeper.PermissionR\n" +
+	"permission\x12\x1f\n" +
+	"\vwrapped_key\x18\x06 \x01(\tR\n" +
+	"wrappedKey\"@\n" +
+	"\x0eSharedResponse\x12.\n" +
+	"\x05items\x18\x01 \x03(\v2\x18.grpcgokeeper.SharedItemR\x05items\"|\n" +
+	"\n" +
+	"SealedItem\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12*\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x16.grpcgokeeper.TypeDataR\x04type\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x1a\n" +
+	"\bmetadata\x18\x04 \x01(\fR\bmetadata\" \n" +
+	"\n" +
+	"OrgRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x1d\n" +
+	"\vOrgResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"]\n" +
+	"\rMemberRequest\x12\x10\n" +
+	"\x03org\x18\x01 \x01(\tR\x03org\x12\x12\n" +
+	"\x04user\x18\x02 \x01(\tR\x04user\x12&\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x12.grpcgokeeper.RoleR\x04role\"\x10\n" +
+	"\x0eResponseMember\"\"\n" +
+	"\x0eMembersRequest\x12\x10\n" +
+	"\x03org\x18\x01 \x01(\tR\x03org\"D\n" +
+	"\x06Member\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12&\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x12.grpcgokeeper.RoleR\x04role\"A\n" +
+	"\x0fMembersResponse\x12.\n" +
+	"\amembers\x18\x01 \x03(\v2\x14.grpcgokeeper.MemberR\amembers\"9\n" +
+	"\x11CollectionRequest\x12\x10\n" +
+	"\x03org\x18\x01 \x01(\tR\x03org\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"(\n" +
+	"\x12CollectionResponse\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x14\n" +
+	"\x12CollectionsRequest\"\xa9\x01\n" +
+	"\n" +
+	"Collection\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x10\n" +
+	"\x03org\x18\x02 \x01(\tR\x03org\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12&\n" +
+	"\x04role\x18\x04 \x01(\x0e2\x12.grpcgokeeper.RoleR\x04role\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x05R\aversion\x12\x1f\n" +
+	"\vwrapped_key\x18\x06 \x01(\tR\n" +
+	"wrappedKey\"Q\n" +
+	"\x13CollectionsResponse\x12:\n" +
+	"\vcollections\x18\x01 \x03(\v2\x18.grpcgokeeper.CollectionR\vcollections\",\n" +
+	"\x16CollectionItemsRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x82\x01\n" +
+	"\x0eCollectionItem\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12*\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x16.grpcgokeeper.TypeDataR\x04type\x12\x1a\n" +
+	"\bmetadata\x18\x04 \x01(\tR\bmetadata\"M\n" +
+	"\x17CollectionItemsResponse\x122\n" +
+	"\x05items\x18\x01 \x03(\v2\x1c.grpcgokeeper.CollectionItemR\x05items\"k\n" +
+	"\rSecretRequest\x12\x1e\n" +
+	"\n" +
+	"ciphertext\x18\x01 \x01(\tR\n" +
+	"ciphertext\x12\x1b\n" +
+	"\tmax_views\x18\x02 \x01(\x05R\bmaxViews\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\x03R\texpiresAt\"?\n" +
+	"\x0eSecretResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\x03R\texpiresAt\"\x1f\n" +
+	"\rRedeemRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"O\n" +
+	"\x0eRedeemResponse\x12\x1e\n" +
+	"\n" +
+	"ciphertext\x18\x01 \x01(\tR\n" +
+	"ciphertext\x12\x1d\n" +
+	"\n" +
+	"views_left\x18\x02 \x01(\x05R\tviewsLeft\"N\n" +
+	"\x0fEmergencyInvite\x12\x18\n" +
+	"\acontact\x18\x01 \x01(\tR\acontact\x12!\n" +
+	"\fwait_seconds\x18\x02 \x01(\x03R\vwaitSeconds\"<\n" +
+	"\x10EmergencyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aapprove\x18\x02 \x01(\bR\aapprove\"\xe4\x01\n" +
+	"\tEmergency\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12\x18\n" +
+	"\acontact\x18\x03 \x01(\tR\acontact\x122\n" +
+	"\x05state\x18\x04 \x01(\x0e2\x1c.grpcgokeeper.EmergencyStateR\x05state\x12!\n" +
+	"\fwait_seconds\x18\x05 \x01(\x03R\vwaitSeconds\x12!\n" +
+	"\frequested_at\x18\x06 \x01(\x03R\vrequestedAt\x12\x1d\n" +
+	"\n" +
+	"granted_at\x18\a \x01(\x03R\tgrantedAt\"\x16\n" +
+	"\x14EmergencyListRequest\"F\n" +
+	"\rEmergencyList\x125\n" +
+	"\temergency\x18\x01 \x03(\v2\x17.grpcgokeeper.EmergencyR\temergency\"\x8c\x01\n" +
+	"\rEmergencyItem\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12*\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x16.grpcgokeeper.TypeDataR\x04type\x12\x1a\n" +
+	"\bmetadata\x18\x03 \x01(\tR\bmetadata\x12\x1f\n" +
+	"\vwrapped_key\x18\x04 \x01(\tR\n" +
+	"wrappedKey\"C\n" +
+	"\x0eEmergencyItems\x121\n" +
+	"\x05items\x18\x01 \x03(\v2\x1b.grpcgokeeper.EmergencyItemR\x05items\"\x13\n" +
+	"\x11ResponseEmergency*E\n" +
 	"\bTypeData\x12\r\n" +
 	"\tLOGINDATA\x10\x00\x12\f\n" +
 	"\bCARDDATA\x10\x01\x12\f\n" +
 	"\bTEXTDATA\x10\x02\x12\x0e\n" +
 	"\n" +
-	"BINARYDATA\x10\x032\xf4\x03\n" +
+	"BINARYDATA\x10\x03*%\n" +
+	"\n" +
+	"Permission\x12\b\n" +
+	"\x04READ\x10\x00\x12\r\n" +
+	"\tREADWRITE\x10\x01*6\n" +
+	"\x04Role\x12\t\n" +
+	"\x05OWNER\x10\x00\x12\t\n" +
+	"\x05ADMIN\x10\x01\x12\n" +
+	"\n" +
+	"\x06MEMBER\x10\x02\x12\f\n" +
+	"\bREADONLY\x10\x03*S\n" +
+	"\x0eEmergencyState\x12\v\n" +
+	"\aINVITED\x10\x00\x12\r\n" +
+	"\tREQUESTED\x10\x01\x12\f\n" +
+	"\bAPPROVED\x10\x02\x12\n" +
+	"\n" +
+	"\x06DENIED\x10\x03\x12\v\n" +
+	"\aEXPIRED\x10\x042\x94\x1e\n" +
 	"\rKeeperService\x12D\n" +
 	"\tLoginUser\x12\x1a.grpcgokeeper.LoginRequest\x1a\x1b.grpcgokeeper.LoginResponse\x12G\n" +
-	"\fRegisterUser\x12\x1a.grpcgokeeper.LoginRequest\x1a\x1b.grpcgokeeper.LoginResponse\x12@\n" +
+	"\fRegisterUser\x12\x1a.grpcgokeeper.LoginRequest\x1a\x1b.grpcgokeeper.LoginResponse\x12K\n" +
+	"\fRefreshToken\x12\x1c.grpcgokeeper.RefreshRequest\x1a\x1d.grpcgokeeper.RefreshResponse\x12C\n" +
+	"\x06Logout\x12\x1b.grpcgokeeper.LogoutRequest\x1a\x1c.grpcgokeeper.ResponseLogout\x12Q\n" +
+	"\x11RevokeAllSessions\x12\x1e.grpcgokeeper.RevokeAllRequest\x1a\x1c.grpcgokeeper.ResponseLogout\x12M\n" +
+	"\fListSessions\x12\x1d.grpcgokeeper.SessionsRequest\x1a\x1e.grpcgokeeper.SessionsResponse\x12G\n" +
+	"\n" +
+	"EnrollTOTP\x12\x1b.grpcgokeeper.EnrollRequest\x1a\x1c.grpcgokeeper.EnrollResponse\x12O\n" +
+	"\n" +
+	"VerifyTOTP\x12\x1f.grpcgokeeper.VerifyTOTPRequest\x1a .grpcgokeeper.VerifyTOTPResponse\x12Q\n" +
+	"\rRevokeSession\x12\".grpcgokeeper.RevokeSessionRequest\x1a\x1c.grpcgokeeper.ResponseLogout\x12R\n" +
+	"\x0eChangePassword\x12#.grpcgokeeper.ChangePasswordRequest\x1a\x1b.grpcgokeeper.LoginResponse\x12R\n" +
+	"\vSetRecovery\x12 .grpcgokeeper.SetRecoveryRequest\x1a!.grpcgokeeper.ResponseSetRecovery\x12M\n" +
+	"\fOpenRecovery\x12\x1d.grpcgokeeper.RecoveryRequest\x1a\x1e.grpcgokeeper.RecoveryResponse\x12L\n" +
+	"\x0eRecoverAccount\x12\x1d.grpcgokeeper.RecoveryRequest\x1a\x1b.grpcgokeeper.LoginResponse\x12I\n" +
+	"\rExportAccount\x12\x1b.grpcgokeeper.ExportRequest\x1a\x19.grpcgokeeper.ExportChunk0\x01\x12X\n" +
+	"\rDeleteAccount\x12\".grpcgokeeper.DeleteAccountRequest\x1a#.grpcgokeeper.ResponseDeleteAccount\x12O\n" +
+	"\x0eCreateAPIToken\x12\x1d.grpcgokeeper.APITokenRequest\x1a\x1e.grpcgokeeper.APITokenResponse\x12P\n" +
+	"\rListAPITokens\x12\x1e.grpcgokeeper.APITokensRequest\x1a\x1f.grpcgokeeper.APITokensResponse\x12S\n" +
+	"\x0eRevokeAPIToken\x12#.grpcgokeeper.RevokeAPITokenRequest\x1a\x1c.grpcgokeeper.ResponseLogout\x12@\n" +
 	"\aAddData\x12\x16.grpcgokeeper.UserData\x1a\x1d.grpcgokeeper.ResponseAddData\x12@\n" +
-	"\aGetData\x12\x1d.grpcgokeeper.DownloadRequest\x1a\x16.grpcgokeeper.UserData\x12F\n" +
+	"\aGetData\x12\x1d.grpcgokeeper.DownloadRequest\x1a\x16.grpcgokeeper.UserData\x12M\n" +
+	"\n" +
+	"DeleteData\x12\x1d.grpcgokeeper.DownloadRequest\x1a .grpcgokeeper.ResponseDeleteData\x12F\n" +
 	"\n" +
 	"UploadData\x12\x17.grpcgokeeper.DataChunk\x1a\x1d.grpcgokeeper.ResponseAddData(\x01\x12H\n" +
-	"\fDownloadData\x12\x1d.grpcgokeeper.DownloadRequest\x1a\x17.grpcgokeeper.DataChunk0\x01\x12>\n" +
-	"\aGetList\x12\x19.grpcgokeeper.ListRequest\x1a\x16.grpcgokeeper.UserData0\x01B,Z*github.com/4aleksei/gokeeper/pkg/api/protob\x06proto3"
+	"\fDownloadData\x12\x1d.grpcgokeeper.DownloadRequest\x1a\x17.grpcgokeeper.DataChunk0\x01\x12R\n" +
+	"\vQueryUpload\x12 .grpcgokeeper.QueryUploadRequest\x1a!.grpcgokeeper.QueryUploadResponse\x12>\n" +
+	"\aGetList\x12\x19.grpcgokeeper.ListRequest\x1a\x16.grpcgokeeper.UserData0\x01\x12C\n" +
+	"\x06Search\x12\x1b.grpcgokeeper.SearchRequest\x1a\x1a.grpcgokeeper.SearchResult0\x01\x12C\n" +
+	"\bGetUsage\x12\x1a.grpcgokeeper.UsageRequest\x1a\x1b.grpcgokeeper.UsageResponse\x12K\n" +
+	"\n" +
+	"UpdateData\x12\x1b.grpcgokeeper.UpdateRequest\x1a .grpcgokeeper.ResponseUpdateData\x12L\n" +
+	"\vSetRotation\x12\x1d.grpcgokeeper.RotationRequest\x1a\x1e.grpcgokeeper.ResponseRotation\x12B\n" +
+	"\vDueRotation\x12\x18.grpcgokeeper.DueRequest\x1a\x19.grpcgokeeper.DueResponse\x12F\n" +
+	"\aSetKeys\x12\x1c.grpcgokeeper.SetKeysRequest\x1a\x1d.grpcgokeeper.ResponseSetKeys\x12D\n" +
+	"\tShareItem\x12\x1a.grpcgokeeper.ShareRequest\x1a\x1b.grpcgokeeper.ResponseShare\x12F\n" +
+	"\aUnshare\x12\x1c.grpcgokeeper.UnshareRequest\x1a\x1d.grpcgokeeper.ResponseUnshare\x12I\n" +
+	"\fSharedWithMe\x12\x1b.grpcgokeeper.SharedRequest\x1a\x1c.grpcgokeeper.SharedResponse\x12H\n" +
+	"\rGetSealedData\x12\x1d.grpcgokeeper.DownloadRequest\x1a\x18.grpcgokeeper.SealedItem\x12@\n" +
+	"\tCreateOrg\x12\x18.grpcgokeeper.OrgRequest\x1a\x19.grpcgokeeper.OrgResponse\x12F\n" +
+	"\tAddMember\x12\x1b.grpcgokeeper.MemberRequest\x1a\x1c.grpcgokeeper.ResponseMember\x12I\n" +
+	"\fRemoveMember\x12\x1b.grpcgokeeper.MemberRequest\x1a\x1c.grpcgokeeper.ResponseMember\x12J\n" +
+	"\vListMembers\x12\x1c.grpcgokeeper.MembersRequest\x1a\x1d.grpcgokeeper.MembersResponse\x12U\n" +
+	"\x10CreateCollection\x12\x1f.grpcgokeeper.CollectionRequest\x1a .grpcgokeeper.CollectionResponse\x12V\n" +
+	"\x0fListCollections\x12 .grpcgokeeper.CollectionsRequest\x1a!.grpcgokeeper.CollectionsResponse\x12^\n" +
+	"\x0fCollectionItems\x12$.grpcgokeeper.CollectionItemsRequest\x1a%.grpcgokeeper.CollectionItemsResponse\x12N\n" +
+	"\x11CreateSecretShare\x12\x1b.grpcgokeeper.SecretRequest\x1a\x1c.grpcgokeeper.SecretResponse\x12H\n" +
+	"\vRedeemShare\x12\x1b.grpcgokeeper.RedeemRequest\x1a\x1c.grpcgokeeper.RedeemResponse\x12I\n" +
+	"\x0fInviteEmergency\x12\x1d.grpcgokeeper.EmergencyInvite\x1a\x17.grpcgokeeper.Emergency\x12K\n" +
+	"\x10RequestEmergency\x12\x1e.grpcgokeeper.EmergencyRequest\x1a\x17.grpcgokeeper.Emergency\x12K\n" +
+	"\x10ApproveEmergency\x12\x1e.grpcgokeeper.EmergencyRequest\x1a\x17.grpcgokeeper.Emergency\x12R\n" +
+	"\x0fRevokeEmergency\x12\x1e.grpcgokeeper.EmergencyRequest\x1a\x1f.grpcgokeeper.ResponseEmergency\x12P\n" +
+	"\rListEmergency\x12\".grpcgokeeper.EmergencyListRequest\x1a\x1b.grpcgokeeper.EmergencyList\x12O\n" +
+	"\x0fEmergencyAccess\x12\x1e.grpcgokeeper.EmergencyRequest\x1a\x1c.grpcgokeeper.EmergencyItemsB,Z*github.com/4aleksei/gokeeper/pkg/api/protob\x06proto3"
 
 var (
 	file_api_proto_gokeeper_proto_rawDescOnce sync.Once
@@ -482,40 +5487,234 @@ func file_api_proto_gokeeper_proto_rawDescGZIP() []byte {
 	return file_api_proto_gokeeper_proto_rawDescData
 }
 
-var file_api_proto_gokeeper_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_proto_gokeeper_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_proto_gokeeper_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_api_proto_gokeeper_proto_msgTypes = make([]protoimpl.MessageInfo, 87)
 var file_api_proto_gokeeper_proto_goTypes = []any{
-	(TypeData)(0),           // 0: grpcgokeeper.TypeData
-	(*LoginRequest)(nil),    // 1: grpcgokeeper.LoginRequest
-	(*LoginResponse)(nil),   // 2: grpcgokeeper.LoginResponse
-	(*UserData)(nil),        // 3: grpcgokeeper.UserData
-	(*ResponseAddData)(nil), // 4: grpcgokeeper.ResponseAddData
-	(*ListRequest)(nil),     // 5: grpcgokeeper.ListRequest
-	(*DownloadRequest)(nil), // 6: grpcgokeeper.DownloadRequest
-	(*DataChunk)(nil),       // 7: grpcgokeeper.DataChunk
+	(TypeData)(0),                   // 0: grpcgokeeper.TypeData
+	(Permission)(0),                 // 1: grpcgokeeper.Permission
+	(Role)(0),                       // 2: grpcgokeeper.Role
+	(EmergencyState)(0),             // 3: grpcgokeeper.EmergencyState
+	(*LoginRequest)(nil),            // 4: grpcgokeeper.LoginRequest
+	(*LoginResponse)(nil),           // 5: grpcgokeeper.LoginResponse
+	(*EnrollRequest)(nil),           // 6: grpcgokeeper.EnrollRequest
+	(*EnrollResponse)(nil),          // 7: grpcgokeeper.EnrollResponse
+	(*VerifyTOTPRequest)(nil),       // 8: grpcgokeeper.VerifyTOTPRequest
+	(*VerifyTOTPResponse)(nil),      // 9: grpcgokeeper.VerifyTOTPResponse
+	(*RefreshRequest)(nil),          // 10: grpcgokeeper.RefreshRequest
+	(*LogoutRequest)(nil),           // 11: grpcgokeeper.LogoutRequest
+	(*RevokeAllRequest)(nil),        // 12: grpcgokeeper.RevokeAllRequest
+	(*ResponseLogout)(nil),          // 13: grpcgokeeper.ResponseLogout
+	(*SessionsRequest)(nil),         // 14: grpcgokeeper.SessionsRequest
+	(*Session)(nil),                 // 15: grpcgokeeper.Session
+	(*SessionsResponse)(nil),        // 16: grpcgokeeper.SessionsResponse
+	(*RevokeSessionRequest)(nil),    // 17: grpcgokeeper.RevokeSessionRequest
+	(*ChangePasswordRequest)(nil),   // 18: grpcgokeeper.ChangePasswordRequest
+	(*SetRecoveryRequest)(nil),      // 19: grpcgokeeper.SetRecoveryRequest
+	(*ResponseSetRecovery)(nil),     // 20: grpcgokeeper.ResponseSetRecovery
+	(*RecoveryRequest)(nil),         // 21: grpcgokeeper.RecoveryRequest
+	(*RecoveryResponse)(nil),        // 22: grpcgokeeper.RecoveryResponse
+	(*ExportRequest)(nil),           // 23: grpcgokeeper.ExportRequest
+	(*ExportChunk)(nil),             // 24: grpcgokeeper.ExportChunk
+	(*DeleteAccountRequest)(nil),    // 25: grpcgokeeper.DeleteAccountRequest
+	(*ResponseDeleteAccount)(nil),   // 26: grpcgokeeper.ResponseDeleteAccount
+	(*APITokenRequest)(nil),         // 27: grpcgokeeper.APITokenRequest
+	(*APIToken)(nil),                // 28: grpcgokeeper.APIToken
+	(*APITokenResponse)(nil),        // 29: grpcgokeeper.APITokenResponse
+	(*APITokensRequest)(nil),        // 30: grpcgokeeper.APITokensRequest
+	(*APITokensResponse)(nil),       // 31: grpcgokeeper.APITokensResponse
+	(*RevokeAPITokenRequest)(nil),   // 32: grpcgokeeper.RevokeAPITokenRequest
+	(*RefreshResponse)(nil),         // 33: grpcgokeeper.RefreshResponse
+	(*UserData)(nil),                // 34: grpcgokeeper.UserData
+	(*Attachment)(nil),              // 35: grpcgokeeper.Attachment
+	(*ResponseAddData)(nil),         // 36: grpcgokeeper.ResponseAddData
+	(*ResponseDeleteData)(nil),      // 37: grpcgokeeper.ResponseDeleteData
+	(*ListRequest)(nil),             // 38: grpcgokeeper.ListRequest
+	(*DownloadRequest)(nil),         // 39: grpcgokeeper.DownloadRequest
+	(*DataChunk)(nil),               // 40: grpcgokeeper.DataChunk
+	(*QueryUploadRequest)(nil),      // 41: grpcgokeeper.QueryUploadRequest
+	(*QueryUploadResponse)(nil),     // 42: grpcgokeeper.QueryUploadResponse
+	(*SearchRequest)(nil),           // 43: grpcgokeeper.SearchRequest
+	(*SearchResult)(nil),            // 44: grpcgokeeper.SearchResult
+	(*UsageRequest)(nil),            // 45: grpcgokeeper.UsageRequest
+	(*UsageResponse)(nil),           // 46: grpcgokeeper.UsageResponse
+	(*UpdateRequest)(nil),           // 47: grpcgokeeper.UpdateRequest
+	(*ResponseUpdateData)(nil),      // 48: grpcgokeeper.ResponseUpdateData
+	(*RotationRequest)(nil),         // 49: grpcgokeeper.RotationRequest
+	(*ResponseRotation)(nil),        // 50: grpcgokeeper.ResponseRotation
+	(*DueRequest)(nil),              // 51: grpcgokeeper.DueRequest
+	(*DueItem)(nil),                 // 52: grpcgokeeper.DueItem
+	(*DueResponse)(nil),             // 53: grpcgokeeper.DueResponse
+	(*SetKeysRequest)(nil),          // 54: grpcgokeeper.SetKeysRequest
+	(*ResponseSetKeys)(nil),         // 55: grpcgokeeper.ResponseSetKeys
+	(*ShareRequest)(nil),            // 56: grpcgokeeper.ShareRequest
+	(*ResponseShare)(nil),           // 57: grpcgokeeper.ResponseShare
+	(*UnshareRequest)(nil),          // 58: grpcgokeeper.UnshareRequest
+	(*ResponseUnshare)(nil),         // 59: grpcgokeeper.ResponseUnshare
+	(*SharedRequest)(nil),           // 60: grpcgokeeper.SharedRequest
+	(*SharedItem)(nil),              // 61: grpcgokeeper.SharedItem
+	(*SharedResponse)(nil),          // 62: grpcgokeeper.SharedResponse
+	(*SealedItem)(nil),              // 63: grpcgokeeper.SealedItem
+	(*OrgRequest)(nil),              // 64: grpcgokeeper.OrgRequest
+	(*OrgResponse)(nil),             // 65: grpcgokeeper.OrgResponse
+	(*MemberRequest)(nil),           // 66: grpcgokeeper.MemberRequest
+	(*ResponseMember)(nil),          // 67: grpcgokeeper.ResponseMember
+	(*MembersRequest)(nil),          // 68: grpcgokeeper.MembersRequest
+	(*Member)(nil),                  // 69: grpcgokeeper.Member
+	(*MembersResponse)(nil),         // 70: grpcgokeeper.MembersResponse
+	(*CollectionRequest)(nil),       // 71: grpcgokeeper.CollectionRequest
+	(*CollectionResponse)(nil),      // 72: grpcgokeeper.CollectionResponse
+	(*CollectionsRequest)(nil),      // 73: grpcgokeeper.CollectionsRequest
+	(*Collection)(nil),              // 74: grpcgokeeper.Collection
+	(*CollectionsResponse)(nil),     // 75: grpcgokeeper.CollectionsResponse
+	(*CollectionItemsRequest)(nil),  // 76: grpcgokeeper.CollectionItemsRequest
+	(*CollectionItem)(nil),          // 77: grpcgokeeper.CollectionItem
+	(*CollectionItemsResponse)(nil), // 78: grpcgokeeper.CollectionItemsResponse
+	(*SecretRequest)(nil),           // 79: grpcgokeeper.SecretRequest
+	(*SecretResponse)(nil),          // 80: grpcgokeeper.SecretResponse
+	(*RedeemRequest)(nil),           // 81: grpcgokeeper.RedeemRequest
+	(*RedeemResponse)(nil),          // 82: grpcgokeeper.RedeemResponse
+	(*EmergencyInvite)(nil),         // 83: grpcgokeeper.EmergencyInvite
+	(*EmergencyRequest)(nil),        // 84: grpcgokeeper.EmergencyRequest
+	(*Emergency)(nil),               // 85: grpcgokeeper.Emergency
+	(*EmergencyListRequest)(nil),    // 86: grpcgokeeper.EmergencyListRequest
+	(*EmergencyList)(nil),           // 87: grpcgokeeper.EmergencyList
+	(*EmergencyItem)(nil),           // 88: grpcgokeeper.EmergencyItem
+	(*EmergencyItems)(nil),          // 89: grpcgokeeper.EmergencyItems
+	(*ResponseEmergency)(nil),       // 90: grpcgokeeper.ResponseEmergency
 }
 var file_api_proto_gokeeper_proto_depIdxs = []int32{
-	0, // 0: grpcgokeeper.UserData.type:type_name -> grpcgokeeper.TypeData
-	0, // 1: grpcgokeeper.DataChunk.type:type_name -> grpcgokeeper.TypeData
-	1, // 2: grpcgokeeper.KeeperService.LoginUser:input_type -> grpcgokeeper.LoginRequest
-	1, // 3: grpcgokeeper.KeeperService.RegisterUser:input_type -> grpcgokeeper.LoginRequest
-	3, // 4: grpcgokeeper.KeeperService.AddData:input_type -> grpcgokeeper.UserData
-	6, // 5: grpcgokeeper.KeeperService.GetData:input_type -> grpcgokeeper.DownloadRequest
-	7, // 6: grpcgokeeper.KeeperService.UploadData:input_type -> grpcgokeeper.DataChunk
-	6, // 7: grpcgokeeper.KeeperService.DownloadData:input_type -> grpcgokeeper.DownloadRequest
-	5, // 8: grpcgokeeper.KeeperService.GetList:input_type -> grpcgokeeper.ListRequest
-	2, // 9: grpcgokeeper.KeeperService.LoginUser:output_type -> grpcgokeeper.LoginResponse
-	2, // 10: grpcgokeeper.KeeperService.RegisterUser:output_type -> grpcgokeeper.LoginResponse
-	4, // 11: grpcgokeeper.KeeperService.AddData:output_type -> grpcgokeeper.ResponseAddData
-	3, // 12: grpcgokeeper.KeeperService.GetData:output_type -> grpcgokeeper.UserData
-	4, // 13: grpcgokeeper.KeeperService.UploadData:output_type -> grpcgokeeper.ResponseAddData
-	7, // 14: grpcgokeeper.KeeperService.DownloadData:output_type -> grpcgokeeper.DataChunk
-	3, // 15: grpcgokeeper.KeeperService.GetList:output_type -> grpcgokeeper.UserData
-	9, // [9:16] is the sub-list for method output_type
-	2, // [2:9] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	15, // 0: grpcgokeeper.SessionsResponse.sessions:type_name -> grpcgokeeper.Session
+	0,  // 1: grpcgokeeper.ExportChunk.type:type_name -> grpcgokeeper.TypeData
+	1,  // 2: grpcgokeeper.APITokenRequest.permission:type_name -> grpcgokeeper.Permission
+	1,  // 3: grpcgokeeper.APIToken.permission:type_name -> grpcgokeeper.Permission
+	28, // 4: grpcgokeeper.APITokenResponse.info:type_name -> grpcgokeeper.APIToken
+	28, // 5: grpcgokeeper.APITokensResponse.tokens:type_name -> grpcgokeeper.APIToken
+	0,  // 6: grpcgokeeper.UserData.type:type_name -> grpcgokeeper.TypeData
+	35, // 7: grpcgokeeper.UserData.attachments:type_name -> grpcgokeeper.Attachment
+	0,  // 8: grpcgokeeper.DataChunk.type:type_name -> grpcgokeeper.TypeData
+	0,  // 9: grpcgokeeper.SearchResult.type:type_name -> grpcgokeeper.TypeData
+	52, // 10: grpcgokeeper.DueResponse.items:type_name -> grpcgokeeper.DueItem
+	1,  // 11: grpcgokeeper.ShareRequest.permission:type_name -> grpcgokeeper.Permission
+	0,  // 12: grpcgokeeper.SharedItem.type:type_name -> grpcgokeeper.TypeData
+	1,  // 13: grpcgokeeper.SharedItem.permission:type_name -> grpcgokeeper.Permission
+	61, // 14: grpcgokeeper.SharedResponse.items:type_name -> grpcgokeeper.SharedItem
+	0,  // 15: grpcgokeeper.SealedItem.type:type_name -> grpcgokeeper.TypeData
+	2,  // 16: grpcgokeeper.MemberRequest.role:type_name -> grpcgokeeper.Role
+	2,  // 17: grpcgokeeper.Member.role:type_name -> grpcgokeeper.Role
+	69, // 18: grpcgokeeper.MembersResponse.members:type_name -> grpcgokeeper.Member
+	2,  // 19: grpcgokeeper.Collection.role:type_name -> grpcgokeeper.Role
+	74, // 20: grpcgokeeper.CollectionsResponse.collections:type_name -> grpcgokeeper.Collection
+	0,  // 21: grpcgokeeper.CollectionItem.type:type_name -> grpcgokeeper.TypeData
+	77, // 22: grpcgokeeper.CollectionItemsResponse.items:type_name -> grpcgokeeper.CollectionItem
+	3,  // 23: grpcgokeeper.Emergency.state:type_name -> grpcgokeeper.EmergencyState
+	85, // 24: grpcgokeeper.EmergencyList.emergency:type_name -> grpcgokeeper.Emergency
+	0,  // 25: grpcgokeeper.EmergencyItem.type:type_name -> grpcgokeeper.TypeData
+	88, // 26: grpcgokeeper.EmergencyItems.items:type_name -> grpcgokeeper.EmergencyItem
+	4,  // 27: grpcgokeeper.KeeperService.LoginUser:input_type -> grpcgokeeper.LoginRequest
+	4,  // 28: grpcgokeeper.KeeperService.RegisterUser:input_type -> grpcgokeeper.LoginRequest
+	10, // 29: grpcgokeeper.KeeperService.RefreshToken:input_type -> grpcgokeeper.RefreshRequest
+	11, // 30: grpcgokeeper.KeeperService.Logout:input_type -> grpcgokeeper.LogoutRequest
+	12, // 31: grpcgokeeper.KeeperService.RevokeAllSessions:input_type -> grpcgokeeper.RevokeAllRequest
+	14, // 32: grpcgokeeper.KeeperService.ListSessions:input_type -> grpcgokeeper.SessionsRequest
+	6,  // 33: grpcgokeeper.KeeperService.EnrollTOTP:input_type -> grpcgokeeper.EnrollRequest
+	8,  // 34: grpcgokeeper.KeeperService.VerifyTOTP:input_type -> grpcgokeeper.VerifyTOTPRequest
+	17, // 35: grpcgokeeper.KeeperService.RevokeSession:input_type -> grpcgokeeper.RevokeSessionRequest
+	18, // 36: grpcgokeeper.KeeperService.ChangePassword:input_type -> grpcgokeeper.ChangePasswordRequest
+	19, // 37: grpcgokeeper.KeeperService.SetRecovery:input_type -> grpcgokeeper.SetRecoveryRequest
+	21, // 38: grpcgokeeper.KeeperService.OpenRecovery:input_type -> grpcgokeeper.RecoveryRequest
+	21, // 39: grpcgokeeper.KeeperService.RecoverAccount:input_type -> grpcgokeeper.RecoveryRequest
+	23, // 40: grpcgokeeper.KeeperService.ExportAccount:input_type -> grpcgokeeper.ExportRequest
+	25, // 41: grpcgokeeper.KeeperService.DeleteAccount:input_type -> grpcgokeeper.DeleteAccountRequest
+	27, // 42: grpcgokeeper.KeeperService.CreateAPIToken:input_type -> grpcgokeeper.APITokenRequest
+	30, // 43: grpcgokeeper.KeeperService.ListAPITokens:input_type -> grpcgokeeper.APITokensRequest
+	32, // 44: grpcgokeeper.KeeperService.RevokeAPIToken:input_type -> grpcgokeeper.RevokeAPITokenRequest
+	34, // 45: grpcgokeeper.KeeperService.AddData:input_type -> grpcgokeeper.UserData
+	39, // 46: grpcgokeeper.KeeperService.GetData:input_type -> grpcgokeeper.DownloadRequest
+	39, // 47: grpcgokeeper.KeeperService.DeleteData:input_type -> grpcgokeeper.DownloadRequest
+	40, // 48: grpcgokeeper.KeeperService.UploadData:input_type -> grpcgokeeper.DataChunk
+	39, // 49: grpcgokeeper.KeeperService.DownloadData:input_type -> grpcgokeeper.DownloadRequest
+	41, // 50: grpcgokeeper.KeeperService.QueryUpload:input_type -> grpcgokeeper.QueryUploadRequest
+	38, // 51: grpcgokeeper.KeeperService.GetList:input_type -> grpcgokeeper.ListRequest
+	43, // 52: grpcgokeeper.KeeperService.Search:input_type -> grpcgokeeper.SearchRequest
+	45, // 53: grpcgokeeper.KeeperService.GetUsage:input_type -> grpcgokeeper.UsageRequest
+	47, // 54: grpcgokeeper.KeeperService.UpdateData:input_type -> grpcgokeeper.UpdateRequest
+	49, // 55: grpcgokeeper.KeeperService.SetRotation:input_type -> grpcgokeeper.RotationRequest
+	51, // 56: grpcgokeeper.KeeperService.DueRotation:input_type -> grpcgokeeper.DueRequest
+	54, // 57: grpcgokeeper.KeeperService.SetKeys:input_type -> grpcgokeeper.SetKeysRequest
+	56, // 58: grpcgokeeper.KeeperService.ShareItem:input_type -> grpcgokeeper.ShareRequest
+	58, // 59: grpcgokeeper.KeeperService.Unshare:input_type -> grpcgokeeper.UnshareRequest
+	60, // 60: grpcgokeeper.KeeperService.SharedWithMe:input_type -> grpcgokeeper.SharedRequest
+	39, // 61: grpcgokeeper.KeeperService.GetSealedData:input_type -> grpcgokeeper.DownloadRequest
+	64, // 62: grpcgokeeper.KeeperService.CreateOrg:input_type -> grpcgokeeper.OrgRequest
+	66, // 63: grpcgokeeper.KeeperService.AddMember:input_type -> grpcgokeeper.MemberRequest
+	66, // 64: grpcgokeeper.KeeperService.RemoveMember:input_type -> grpcgokeeper.MemberRequest
+	68, // 65: grpcgokeeper.KeeperService.ListMembers:input_type -> grpcgokeeper.MembersRequest
+	71, // 66: grpcgokeeper.KeeperService.CreateCollection:input_type -> grpcgokeeper.CollectionRequest
+	73, // 67: grpcgokeeper.KeeperService.ListCollections:input_type -> grpcgokeeper.CollectionsRequest
+	76, // 68: grpcgokeeper.KeeperService.CollectionItems:input_type -> grpcgokeeper.CollectionItemsRequest
+	79, // 69: grpcgokeeper.KeeperService.CreateSecretShare:input_type -> grpcgokeeper.SecretRequest
+	81, // 70: grpcgokeeper.KeeperService.RedeemShare:input_type -> grpcgokeeper.RedeemRequest
+	83, // 71: grpcgokeeper.KeeperService.InviteEmergency:input_type -> grpcgokeeper.EmergencyInvite
+	84, // 72: grpcgokeeper.KeeperService.RequestEmergency:input_type -> grpcgokeeper.EmergencyRequest
+	84, // 73: grpcgokeeper.KeeperService.ApproveEmergency:input_type -> grpcgokeeper.EmergencyRequest
+	84, // 74: grpcgokeeper.KeeperService.RevokeEmergency:input_type -> grpcgokeeper.EmergencyRequest
+	86, // 75: grpcgokeeper.KeeperService.ListEmergency:input_type -> grpcgokeeper.EmergencyListRequest
+	84, // 76: grpcgokeeper.KeeperService.EmergencyAccess:input_type -> grpcgokeeper.EmergencyRequest
+	5,  // 77: grpcgokeeper.KeeperService.LoginUser:output_type -> grpcgokeeper.LoginResponse
+	5,  // 78: grpcgokeeper.KeeperService.RegisterUser:output_type -> grpcgokeeper.LoginResponse
+	33, // 79: grpcgokeeper.KeeperService.RefreshToken:output_type -> grpcgokeeper.RefreshResponse
+	13, // 80: grpcgokeeper.KeeperService.Logout:output_type -> grpcgokeeper.ResponseLogout
+	13, // 81: grpcgokeeper.KeeperService.RevokeAllSessions:output_type -> grpcgokeeper.ResponseLogout
+	16, // 82: grpcgokeeper.KeeperService.ListSessions:output_type -> grpcgokeeper.SessionsResponse
+	7,  // 83: grpcgokeeper.KeeperService.EnrollTOTP:output_type -> grpcgokeeper.EnrollResponse
+	9,  // 84: grpcgokeeper.KeeperService.VerifyTOTP:output_type -> grpcgokeeper.VerifyTOTPResponse
+	13, // 85: grpcgokeeper.KeeperService.RevokeSession:output_type -> grpcgokeeper.ResponseLogout
+	5,  // 86: grpcgokeeper.KeeperService.ChangePassword:output_type -> grpcgokeeper.LoginResponse
+	20, // 87: grpcgokeeper.KeeperService.SetRecovery:output_type -> grpcgokeeper.ResponseSetRecovery
+	22, // 88: grpcgokeeper.KeeperService.OpenRecovery:output_type -> grpcgokeeper.RecoveryResponse
+	5,  // 89: grpcgokeeper.KeeperService.RecoverAccount:output_type -> grpcgokeeper.LoginResponse
+	24, // 90: grpcgokeeper.KeeperService.ExportAccount:output_type -> grpcgokeeper.ExportChunk
+	26, // 91: grpcgokeeper.KeeperService.DeleteAccount:output_type -> grpcgokeeper.ResponseDeleteAccount
+	29, // 92: grpcgokeeper.KeeperService.CreateAPIToken:output_type -> grpcgokeeper.APITokenResponse
+	31, // 93: grpcgokeeper.KeeperService.ListAPITokens:output_type -> grpcgokeeper.APITokensResponse
+	13, // 94: grpcgokeeper.KeeperService.RevokeAPIToken:output_type -> grpcgokeeper.ResponseLogout
+	36, // 95: grpcgokeeper.KeeperService.AddData:output_type -> grpcgokeeper.ResponseAddData
+	34, // 96: grpcgokeeper.KeeperService.GetData:output_type -> grpcgokeeper.UserData
+	37, // 97: grpcgokeeper.KeeperService.DeleteData:output_type -> grpcgokeeper.ResponseDeleteData
+	36, // 98: grpcgokeeper.KeeperService.UploadData:output_type -> grpcgokeeper.ResponseAddData
+	40, // 99: grpcgokeeper.KeeperService.DownloadData:output_type -> grpcgokeeper.DataChunk
+	42, // 100: grpcgokeeper.KeeperService.QueryUpload:output_type -> grpcgokeeper.QueryUploadResponse
+	34, // 101: grpcgokeeper.KeeperService.GetList:output_type -> grpcgokeeper.UserData
+	44, // 102: grpcgokeeper.KeeperService.Search:output_type -> grpcgokeeper.SearchResult
+	46, // 103: grpcgokeeper.KeeperService.GetUsage:output_type -> grpcgokeeper.UsageResponse
+	48, // 104: grpcgokeeper.KeeperService.UpdateData:output_type -> grpcgokeeper.ResponseUpdateData
+	50, // 105: grpcgokeeper.KeeperService.SetRotation:output_type -> grpcgokeeper.ResponseRotation
+	53, // 106: grpcgokeeper.KeeperService.DueRotation:output_type -> grpcgokeeper.DueResponse
+	55, // 107: grpcgokeeper.KeeperService.SetKeys:output_type -> grpcgokeeper.ResponseSetKeys
+	57, // 108: grpcgokeeper.KeeperService.ShareItem:output_type -> grpcgokeeper.ResponseShare
+	59, // 109: grpcgokeeper.KeeperService.Unshare:output_type -> grpcgokeeper.ResponseUnshare
+	62, // 110: grpcgokeeper.KeeperService.SharedWithMe:output_type -> grpcgokeeper.SharedResponse
+	63, // 111: grpcgokeeper.KeeperService.GetSealedData:output_type -> grpcgokeeper.SealedItem
+	65, // 112: grpcgokeeper.KeeperService.CreateOrg:output_type -> grpcgokeeper.OrgResponse
+	67, // 113: grpcgokeeper.KeeperService.AddMember:output_type -> grpcgokeeper.ResponseMember
+	67, // 114: grpcgokeeper.KeeperService.RemoveMember:output_type -> grpcgokeeper.ResponseMember
+	70, // 115: grpcgokeeper.KeeperService.ListMembers:output_type -> grpcgokeeper.MembersResponse
+	72, // 116: grpcgokeeper.KeeperService.CreateCollection:output_type -> grpcgokeeper.CollectionResponse
+	75, // 117: grpcgokeeper.KeeperService.ListCollections:output_type -> grpcgokeeper.CollectionsResponse
+	78, // 118: grpcgokeeper.KeeperService.CollectionItems:output_type -> grpcgokeeper.CollectionItemsResponse
+	80, // 119: grpcgokeeper.KeeperService.CreateSecretShare:output_type -> grpcgokeeper.SecretResponse
+	82, // 120: grpcgokeeper.KeeperService.RedeemShare:output_type -> grpcgokeeper.RedeemResponse
+	85, // 121: grpcgokeeper.KeeperService.InviteEmergency:output_type -> grpcgokeeper.Emergency
+	85, // 122: grpcgokeeper.KeeperService.RequestEmergency:output_type -> grpcgokeeper.Emergency
+	85, // 123: grpcgokeeper.KeeperService.ApproveEmergency:output_type -> grpcgokeeper.Emergency
+	90, // 124: grpcgokeeper.KeeperService.RevokeEmergency:output_type -> grpcgokeeper.ResponseEmergency
+	87, // 125: grpcgokeeper.KeeperService.ListEmergency:output_type -> grpcgokeeper.EmergencyList
+	89, // 126: grpcgokeeper.KeeperService.EmergencyAccess:output_type -> grpcgokeeper.EmergencyItems
+	77, // [77:127] is the sub-list for method output_type
+	27, // [27:77] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_gokeeper_proto_init() }
@@ -528,8 +5727,8 @@ func file_api_proto_gokeeper_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_gokeeper_proto_rawDesc), len(file_api_proto_gokeeper_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   7,
+			NumEnums:      4,
+			NumMessages:   87,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
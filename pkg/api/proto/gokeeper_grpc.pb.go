@@ -19,13 +19,56 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	KeeperService_LoginUser_FullMethodName    = "/grpcgokeeper.KeeperService/LoginUser"
-	KeeperService_RegisterUser_FullMethodName = "/grpcgokeeper.KeeperService/RegisterUser"
-	KeeperService_AddData_FullMethodName      = "/grpcgokeeper.KeeperService/AddData"
-	KeeperService_GetData_FullMethodName      = "/grpcgokeeper.KeeperService/GetData"
-	KeeperService_UploadData_FullMethodName   = "/grpcgokeeper.KeeperService/UploadData"
-	KeeperService_DownloadData_FullMethodName = "/grpcgokeeper.KeeperService/DownloadData"
-	KeeperService_GetList_FullMethodName      = "/grpcgokeeper.KeeperService/GetList"
+	KeeperService_LoginUser_FullMethodName         = "/grpcgokeeper.KeeperService/LoginUser"
+	KeeperService_RegisterUser_FullMethodName      = "/grpcgokeeper.KeeperService/RegisterUser"
+	KeeperService_RefreshToken_FullMethodName      = "/grpcgokeeper.KeeperService/RefreshToken"
+	KeeperService_Logout_FullMethodName            = "/grpcgokeeper.KeeperService/Logout"
+	KeeperService_RevokeAllSessions_FullMethodName = "/grpcgokeeper.KeeperService/RevokeAllSessions"
+	KeeperService_ListSessions_FullMethodName      = "/grpcgokeeper.KeeperService/ListSessions"
+	KeeperService_EnrollTOTP_FullMethodName        = "/grpcgokeeper.KeeperService/EnrollTOTP"
+	KeeperService_VerifyTOTP_FullMethodName        = "/grpcgokeeper.KeeperService/VerifyTOTP"
+	KeeperService_RevokeSession_FullMethodName     = "/grpcgokeeper.KeeperService/RevokeSession"
+	KeeperService_ChangePassword_FullMethodName    = "/grpcgokeeper.KeeperService/ChangePassword"
+	KeeperService_SetRecovery_FullMethodName       = "/grpcgokeeper.KeeperService/SetRecovery"
+	KeeperService_OpenRecovery_FullMethodName      = "/grpcgokeeper.KeeperService/OpenRecovery"
+	KeeperService_RecoverAccount_FullMethodName    = "/grpcgokeeper.KeeperService/RecoverAccount"
+	KeeperService_ExportAccount_FullMethodName     = "/grpcgokeeper.KeeperService/ExportAccount"
+	KeeperService_DeleteAccount_FullMethodName     = "/grpcgokeeper.KeeperService/DeleteAccount"
+	KeeperService_CreateAPIToken_FullMethodName    = "/grpcgokeeper.KeeperService/CreateAPIToken"
+	KeeperService_ListAPITokens_FullMethodName     = "/grpcgokeeper.KeeperService/ListAPITokens"
+	KeeperService_RevokeAPIToken_FullMethodName    = "/grpcgokeeper.KeeperService/RevokeAPIToken"
+	KeeperService_AddData_FullMethodName           = "/grpcgokeeper.KeeperService/AddData"
+	KeeperService_GetData_FullMethodName           = "/grpcgokeeper.KeeperService/GetData"
+	KeeperService_DeleteData_FullMethodName        = "/grpcgokeeper.KeeperService/DeleteData"
+	KeeperService_UploadData_FullMethodName        = "/grpcgokeeper.KeeperService/UploadData"
+	KeeperService_DownloadData_FullMethodName      = "/grpcgokeeper.KeeperService/DownloadData"
+	KeeperService_QueryUpload_FullMethodName       = "/grpcgokeeper.KeeperService/QueryUpload"
+	KeeperService_GetList_FullMethodName           = "/grpcgokeeper.KeeperService/GetList"
+	KeeperService_Search_FullMethodName            = "/grpcgokeeper.KeeperService/Search"
+	KeeperService_GetUsage_FullMethodName          = "/grpcgokeeper.KeeperService/GetUsage"
+	KeeperService_UpdateData_FullMethodName        = "/grpcgokeeper.KeeperService/UpdateData"
+	KeeperService_SetRotation_FullMethodName       = "/grpcgokeeper.KeeperService/SetRotation"
+	KeeperService_DueRotation_FullMethodName       = "/grpcgokeeper.KeeperService/DueRotation"
+	KeeperService_SetKeys_FullMethodName           = "/grpcgokeeper.KeeperService/SetKeys"
+	KeeperService_ShareItem_FullMethodName         = "/grpcgokeeper.KeeperService/ShareItem"
+	KeeperService_Unshare_FullMethodName           = "/grpcgokeeper.KeeperService/Unshare"
+	KeeperService_SharedWithMe_FullMethodName      = "/grpcgokeeper.KeeperService/SharedWithMe"
+	KeeperService_GetSealedData_FullMethodName     = "/grpcgokeeper.KeeperService/GetSealedData"
+	KeeperService_CreateOrg_FullMethodName         = "/grpcgokeeper.KeeperService/CreateOrg"
+	KeeperService_AddMember_FullMethodName         = "/grpcgokeeper.KeeperService/AddMember"
+	KeeperService_RemoveMember_FullMethodName      = "/grpcgokeeper.KeeperService/RemoveMember"
+	KeeperService_ListMembers_FullMethodName       = "/grpcgokeeper.KeeperService/ListMembers"
+	KeeperService_CreateCollection_FullMethodName  = "/grpcgokeeper.KeeperService/CreateCollection"
+	KeeperService_ListCollections_FullMethodName   = "/grpcgokeeper.KeeperService/ListCollections"
+	KeeperService_CollectionItems_FullMethodName   = "/grpcgokeeper.KeeperService/CollectionItems"
+	KeeperService_CreateSecretShare_FullMethodName = "/grpcgokeeper.KeeperService/CreateSecretShare"
+	KeeperService_RedeemShare_FullMethodName       = "/grpcgokeeper.KeeperService/RedeemShare"
+	KeeperService_InviteEmergency_FullMethodName   = "/grpcgokeeper.KeeperService/InviteEmergency"
+	KeeperService_RequestEmergency_FullMethodName  = "/grpcgokeeper.KeeperService/RequestEmergency"
+	KeeperService_ApproveEmergency_FullMethodName  = "/grpcgokeeper.KeeperService/ApproveEmergency"
+	KeeperService_RevokeEmergency_FullMethodName   = "/grpcgokeeper.KeeperService/RevokeEmergency"
+	KeeperService_ListEmergency_FullMethodName     = "/grpcgokeeper.KeeperService/ListEmergency"
+	KeeperService_EmergencyAccess_FullMethodName   = "/grpcgokeeper.KeeperService/EmergencyAccess"
 )
 
 // KeeperServiceClient is the client API for KeeperService service.
@@ -34,11 +77,54 @@ const (
 type KeeperServiceClient interface {
 	LoginUser(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	RegisterUser(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	RefreshToken(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResponse, error)
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*ResponseLogout, error)
+	RevokeAllSessions(ctx context.Context, in *RevokeAllRequest, opts ...grpc.CallOption) (*ResponseLogout, error)
+	ListSessions(ctx context.Context, in *SessionsRequest, opts ...grpc.CallOption) (*SessionsResponse, error)
+	EnrollTOTP(ctx context.Context, in *EnrollRequest, opts ...grpc.CallOption) (*EnrollResponse, error)
+	VerifyTOTP(ctx context.Context, in *VerifyTOTPRequest, opts ...grpc.CallOption) (*VerifyTOTPResponse, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*ResponseLogout, error)
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	SetRecovery(ctx context.Context, in *SetRecoveryRequest, opts ...grpc.CallOption) (*ResponseSetRecovery, error)
+	OpenRecovery(ctx context.Context, in *RecoveryRequest, opts ...grpc.CallOption) (*RecoveryResponse, error)
+	RecoverAccount(ctx context.Context, in *RecoveryRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	ExportAccount(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error)
+	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*ResponseDeleteAccount, error)
+	CreateAPIToken(ctx context.Context, in *APITokenRequest, opts ...grpc.CallOption) (*APITokenResponse, error)
+	ListAPITokens(ctx context.Context, in *APITokensRequest, opts ...grpc.CallOption) (*APITokensResponse, error)
+	RevokeAPIToken(ctx context.Context, in *RevokeAPITokenRequest, opts ...grpc.CallOption) (*ResponseLogout, error)
 	AddData(ctx context.Context, in *UserData, opts ...grpc.CallOption) (*ResponseAddData, error)
 	GetData(ctx context.Context, in *DownloadRequest, opts ...grpc.CallOption) (*UserData, error)
+	DeleteData(ctx context.Context, in *DownloadRequest, opts ...grpc.CallOption) (*ResponseDeleteData, error)
 	UploadData(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[DataChunk, ResponseAddData], error)
 	DownloadData(ctx context.Context, in *DownloadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DataChunk], error)
+	QueryUpload(ctx context.Context, in *QueryUploadRequest, opts ...grpc.CallOption) (*QueryUploadResponse, error)
 	GetList(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserData], error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SearchResult], error)
+	GetUsage(ctx context.Context, in *UsageRequest, opts ...grpc.CallOption) (*UsageResponse, error)
+	UpdateData(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*ResponseUpdateData, error)
+	SetRotation(ctx context.Context, in *RotationRequest, opts ...grpc.CallOption) (*ResponseRotation, error)
+	DueRotation(ctx context.Context, in *DueRequest, opts ...grpc.CallOption) (*DueResponse, error)
+	SetKeys(ctx context.Context, in *SetKeysRequest, opts ...grpc.CallOption) (*ResponseSetKeys, error)
+	ShareItem(ctx context.Context, in *ShareRequest, opts ...grpc.CallOption) (*ResponseShare, error)
+	Unshare(ctx context.Context, in *UnshareRequest, opts ...grpc.CallOption) (*ResponseUnshare, error)
+	SharedWithMe(ctx context.Context, in *SharedRequest, opts ...grpc.CallOption) (*SharedResponse, error)
+	GetSealedData(ctx context.Context, in *DownloadRequest, opts ...grpc.CallOption) (*SealedItem, error)
+	CreateOrg(ctx context.Context, in *OrgRequest, opts ...grpc.CallOption) (*OrgResponse, error)
+	AddMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*ResponseMember, error)
+	RemoveMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*ResponseMember, error)
+	ListMembers(ctx context.Context, in *MembersRequest, opts ...grpc.CallOption) (*MembersResponse, error)
+	CreateCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*CollectionResponse, error)
+	ListCollections(ctx context.Context, in *CollectionsRequest, opts ...grpc.CallOption) (*CollectionsResponse, error)
+	CollectionItems(ctx context.Context, in *CollectionItemsRequest, opts ...grpc.CallOption) (*CollectionItemsResponse, error)
+	CreateSecretShare(ctx context.Context, in *SecretRequest, opts ...grpc.CallOption) (*SecretResponse, error)
+	RedeemShare(ctx context.Context, in *RedeemRequest, opts ...grpc.CallOption) (*RedeemResponse, error)
+	InviteEmergency(ctx context.Context, in *EmergencyInvite, opts ...grpc.CallOption) (*Emergency, error)
+	RequestEmergency(ctx context.Context, in *EmergencyRequest, opts ...grpc.CallOption) (*Emergency, error)
+	ApproveEmergency(ctx context.Context, in *EmergencyRequest, opts ...grpc.CallOption) (*Emergency, error)
+	RevokeEmergency(ctx context.Context, in *EmergencyRequest, opts ...grpc.CallOption) (*ResponseEmergency, error)
+	ListEmergency(ctx context.Context, in *EmergencyListRequest, opts ...grpc.CallOption) (*EmergencyList, error)
+	EmergencyAccess(ctx context.Context, in *EmergencyRequest, opts ...grpc.CallOption) (*EmergencyItems, error)
 }
 
 type keeperServiceClient struct {
@@ -69,6 +155,175 @@ func (c *keeperServiceClient) RegisterUser(ctx context.Context, in *LoginRequest
 	return out, nil
 }
 
+func (c *keeperServiceClient) RefreshToken(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshResponse)
+	err := c.cc.Invoke(ctx, KeeperService_RefreshToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*ResponseLogout, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseLogout)
+	err := c.cc.Invoke(ctx, KeeperService_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) RevokeAllSessions(ctx context.Context, in *RevokeAllRequest, opts ...grpc.CallOption) (*ResponseLogout, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseLogout)
+	err := c.cc.Invoke(ctx, KeeperService_RevokeAllSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) ListSessions(ctx context.Context, in *SessionsRequest, opts ...grpc.CallOption) (*SessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionsResponse)
+	err := c.cc.Invoke(ctx, KeeperService_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) EnrollTOTP(ctx context.Context, in *EnrollRequest, opts ...grpc.CallOption) (*EnrollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollResponse)
+	err := c.cc.Invoke(ctx, KeeperService_EnrollTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) VerifyTOTP(ctx context.Context, in *VerifyTOTPRequest, opts ...grpc.CallOption) (*VerifyTOTPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyTOTPResponse)
+	err := c.cc.Invoke(ctx, KeeperService_VerifyTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*ResponseLogout, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseLogout)
+	err := c.cc.Invoke(ctx, KeeperService_RevokeSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, KeeperService_ChangePassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) SetRecovery(ctx context.Context, in *SetRecoveryRequest, opts ...grpc.CallOption) (*ResponseSetRecovery, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseSetRecovery)
+	err := c.cc.Invoke(ctx, KeeperService_SetRecovery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) OpenRecovery(ctx context.Context, in *RecoveryRequest, opts ...grpc.CallOption) (*RecoveryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecoveryResponse)
+	err := c.cc.Invoke(ctx, KeeperService_OpenRecovery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) RecoverAccount(ctx context.Context, in *RecoveryRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, KeeperService_RecoverAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) ExportAccount(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KeeperService_ServiceDesc.Streams[0], KeeperService_ExportAccount_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportRequest, ExportChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KeeperService_ExportAccountClient = grpc.ServerStreamingClient[ExportChunk]
+
+func (c *keeperServiceClient) DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*ResponseDeleteAccount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseDeleteAccount)
+	err := c.cc.Invoke(ctx, KeeperService_DeleteAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) CreateAPIToken(ctx context.Context, in *APITokenRequest, opts ...grpc.CallOption) (*APITokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APITokenResponse)
+	err := c.cc.Invoke(ctx, KeeperService_CreateAPIToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) ListAPITokens(ctx context.Context, in *APITokensRequest, opts ...grpc.CallOption) (*APITokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APITokensResponse)
+	err := c.cc.Invoke(ctx, KeeperService_ListAPITokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) RevokeAPIToken(ctx context.Context, in *RevokeAPITokenRequest, opts ...grpc.CallOption) (*ResponseLogout, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseLogout)
+	err := c.cc.Invoke(ctx, KeeperService_RevokeAPIToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *keeperServiceClient) AddData(ctx context.Context, in *UserData, opts ...grpc.CallOption) (*ResponseAddData, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ResponseAddData)
@@ -89,221 +344,1432 @@ func (c *keeperServiceClient) GetData(ctx context.Context, in *DownloadRequest,
 	return out, nil
 }
 
+func (c *keeperServiceClient) DeleteData(ctx context.Context, in *DownloadRequest, opts ...grpc.CallOption) (*ResponseDeleteData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseDeleteData)
+	err := c.cc.Invoke(ctx, KeeperService_DeleteData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *keeperServiceClient) UploadData(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[DataChunk, ResponseAddData], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &KeeperService_ServiceDesc.Streams[0], KeeperService_UploadData_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &KeeperService_ServiceDesc.Streams[1], KeeperService_UploadData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DataChunk, ResponseAddData]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KeeperService_UploadDataClient = grpc.ClientStreamingClient[DataChunk, ResponseAddData]
+
+func (c *keeperServiceClient) DownloadData(ctx context.Context, in *DownloadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DataChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KeeperService_ServiceDesc.Streams[2], KeeperService_DownloadData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadRequest, DataChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KeeperService_DownloadDataClient = grpc.ServerStreamingClient[DataChunk]
+
+func (c *keeperServiceClient) QueryUpload(ctx context.Context, in *QueryUploadRequest, opts ...grpc.CallOption) (*QueryUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryUploadResponse)
+	err := c.cc.Invoke(ctx, KeeperService_QueryUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) GetList(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserData], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KeeperService_ServiceDesc.Streams[3], KeeperService_GetList_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListRequest, UserData]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KeeperService_GetListClient = grpc.ServerStreamingClient[UserData]
+
+func (c *keeperServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SearchResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KeeperService_ServiceDesc.Streams[4], KeeperService_Search_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SearchRequest, SearchResult]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KeeperService_SearchClient = grpc.ServerStreamingClient[SearchResult]
+
+func (c *keeperServiceClient) GetUsage(ctx context.Context, in *UsageRequest, opts ...grpc.CallOption) (*UsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsageResponse)
+	err := c.cc.Invoke(ctx, KeeperService_GetUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) UpdateData(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*ResponseUpdateData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseUpdateData)
+	err := c.cc.Invoke(ctx, KeeperService_UpdateData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) SetRotation(ctx context.Context, in *RotationRequest, opts ...grpc.CallOption) (*ResponseRotation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseRotation)
+	err := c.cc.Invoke(ctx, KeeperService_SetRotation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) DueRotation(ctx context.Context, in *DueRequest, opts ...grpc.CallOption) (*DueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DueResponse)
+	err := c.cc.Invoke(ctx, KeeperService_DueRotation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) SetKeys(ctx context.Context, in *SetKeysRequest, opts ...grpc.CallOption) (*ResponseSetKeys, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseSetKeys)
+	err := c.cc.Invoke(ctx, KeeperService_SetKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) ShareItem(ctx context.Context, in *ShareRequest, opts ...grpc.CallOption) (*ResponseShare, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseShare)
+	err := c.cc.Invoke(ctx, KeeperService_ShareItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) Unshare(ctx context.Context, in *UnshareRequest, opts ...grpc.CallOption) (*ResponseUnshare, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseUnshare)
+	err := c.cc.Invoke(ctx, KeeperService_Unshare_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[DataChunk, ResponseAddData]{ClientStream: stream}
-	return x, nil
+	return out, nil
+}
+
+func (c *keeperServiceClient) SharedWithMe(ctx context.Context, in *SharedRequest, opts ...grpc.CallOption) (*SharedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SharedResponse)
+	err := c.cc.Invoke(ctx, KeeperService_SharedWithMe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) GetSealedData(ctx context.Context, in *DownloadRequest, opts ...grpc.CallOption) (*SealedItem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SealedItem)
+	err := c.cc.Invoke(ctx, KeeperService_GetSealedData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) CreateOrg(ctx context.Context, in *OrgRequest, opts ...grpc.CallOption) (*OrgResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrgResponse)
+	err := c.cc.Invoke(ctx, KeeperService_CreateOrg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) AddMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*ResponseMember, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseMember)
+	err := c.cc.Invoke(ctx, KeeperService_AddMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) RemoveMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*ResponseMember, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseMember)
+	err := c.cc.Invoke(ctx, KeeperService_RemoveMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) ListMembers(ctx context.Context, in *MembersRequest, opts ...grpc.CallOption) (*MembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MembersResponse)
+	err := c.cc.Invoke(ctx, KeeperService_ListMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) CreateCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*CollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionResponse)
+	err := c.cc.Invoke(ctx, KeeperService_CreateCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) ListCollections(ctx context.Context, in *CollectionsRequest, opts ...grpc.CallOption) (*CollectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionsResponse)
+	err := c.cc.Invoke(ctx, KeeperService_ListCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) CollectionItems(ctx context.Context, in *CollectionItemsRequest, opts ...grpc.CallOption) (*CollectionItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionItemsResponse)
+	err := c.cc.Invoke(ctx, KeeperService_CollectionItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) CreateSecretShare(ctx context.Context, in *SecretRequest, opts ...grpc.CallOption) (*SecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecretResponse)
+	err := c.cc.Invoke(ctx, KeeperService_CreateSecretShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) RedeemShare(ctx context.Context, in *RedeemRequest, opts ...grpc.CallOption) (*RedeemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RedeemResponse)
+	err := c.cc.Invoke(ctx, KeeperService_RedeemShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) InviteEmergency(ctx context.Context, in *EmergencyInvite, opts ...grpc.CallOption) (*Emergency, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Emergency)
+	err := c.cc.Invoke(ctx, KeeperService_InviteEmergency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) RequestEmergency(ctx context.Context, in *EmergencyRequest, opts ...grpc.CallOption) (*Emergency, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Emergency)
+	err := c.cc.Invoke(ctx, KeeperService_RequestEmergency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) ApproveEmergency(ctx context.Context, in *EmergencyRequest, opts ...grpc.CallOption) (*Emergency, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Emergency)
+	err := c.cc.Invoke(ctx, KeeperService_ApproveEmergency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) RevokeEmergency(ctx context.Context, in *EmergencyRequest, opts ...grpc.CallOption) (*ResponseEmergency, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseEmergency)
+	err := c.cc.Invoke(ctx, KeeperService_RevokeEmergency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) ListEmergency(ctx context.Context, in *EmergencyListRequest, opts ...grpc.CallOption) (*EmergencyList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmergencyList)
+	err := c.cc.Invoke(ctx, KeeperService_ListEmergency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceClient) EmergencyAccess(ctx context.Context, in *EmergencyRequest, opts ...grpc.CallOption) (*EmergencyItems, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmergencyItems)
+	err := c.cc.Invoke(ctx, KeeperService_EmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KeeperServiceServer is the server API for KeeperService service.
+// All implementations must embed UnimplementedKeeperServiceServer
+// for forward compatibility.
+type KeeperServiceServer interface {
+	LoginUser(context.Context, *LoginRequest) (*LoginResponse, error)
+	RegisterUser(context.Context, *LoginRequest) (*LoginResponse, error)
+	RefreshToken(context.Context, *RefreshRequest) (*RefreshResponse, error)
+	Logout(context.Context, *LogoutRequest) (*ResponseLogout, error)
+	RevokeAllSessions(context.Context, *RevokeAllRequest) (*ResponseLogout, error)
+	ListSessions(context.Context, *SessionsRequest) (*SessionsResponse, error)
+	EnrollTOTP(context.Context, *EnrollRequest) (*EnrollResponse, error)
+	VerifyTOTP(context.Context, *VerifyTOTPRequest) (*VerifyTOTPResponse, error)
+	RevokeSession(context.Context, *RevokeSessionRequest) (*ResponseLogout, error)
+	ChangePassword(context.Context, *ChangePasswordRequest) (*LoginResponse, error)
+	SetRecovery(context.Context, *SetRecoveryRequest) (*ResponseSetRecovery, error)
+	OpenRecovery(context.Context, *RecoveryRequest) (*RecoveryResponse, error)
+	RecoverAccount(context.Context, *RecoveryRequest) (*LoginResponse, error)
+	ExportAccount(*ExportRequest, grpc.ServerStreamingServer[ExportChunk]) error
+	DeleteAccount(context.Context, *DeleteAccountRequest) (*ResponseDeleteAccount, error)
+	CreateAPIToken(context.Context, *APITokenRequest) (*APITokenResponse, error)
+	ListAPITokens(context.Context, *APITokensRequest) (*APITokensResponse, error)
+	RevokeAPIToken(context.Context, *RevokeAPITokenRequest) (*ResponseLogout, error)
+	AddData(context.Context, *UserData) (*ResponseAddData, error)
+	GetData(context.Context, *DownloadRequest) (*UserData, error)
+	DeleteData(context.Context, *DownloadRequest) (*ResponseDeleteData, error)
+	UploadData(grpc.ClientStreamingServer[DataChunk, ResponseAddData]) error
+	DownloadData(*DownloadRequest, grpc.ServerStreamingServer[DataChunk]) error
+	QueryUpload(context.Context, *QueryUploadRequest) (*QueryUploadResponse, error)
+	GetList(*ListRequest, grpc.ServerStreamingServer[UserData]) error
+	Search(*SearchRequest, grpc.ServerStreamingServer[SearchResult]) error
+	GetUsage(context.Context, *UsageRequest) (*UsageResponse, error)
+	UpdateData(context.Context, *UpdateRequest) (*ResponseUpdateData, error)
+	SetRotation(context.Context, *RotationRequest) (*ResponseRotation, error)
+	DueRotation(context.Context, *DueRequest) (*DueResponse, error)
+	SetKeys(context.Context, *SetKeysRequest) (*ResponseSetKeys, error)
+	ShareItem(context.Context, *ShareRequest) (*ResponseShare, error)
+	Unshare(context.Context, *UnshareRequest) (*ResponseUnshare, error)
+	SharedWithMe(context.Context, *SharedRequest) (*SharedResponse, error)
+	GetSealedData(context.Context, *DownloadRequest) (*SealedItem, error)
+	CreateOrg(context.Context, *OrgRequest) (*OrgResponse, error)
+	AddMember(context.Context, *MemberRequest) (*ResponseMember, error)
+	RemoveMember(context.Context, *MemberRequest) (*ResponseMember, error)
+	ListMembers(context.Context, *MembersRequest) (*MembersResponse, error)
+	CreateCollection(context.Context, *CollectionRequest) (*CollectionResponse, error)
+	ListCollections(context.Context, *CollectionsRequest) (*CollectionsResponse, error)
+	CollectionItems(context.Context, *CollectionItemsRequest) (*CollectionItemsResponse, error)
+	CreateSecretShare(context.Context, *SecretRequest) (*SecretResponse, error)
+	RedeemShare(context.Context, *RedeemRequest) (*RedeemResponse, error)
+	InviteEmergency(context.Context, *EmergencyInvite) (*Emergency, error)
+	RequestEmergency(context.Context, *EmergencyRequest) (*Emergency, error)
+	ApproveEmergency(context.Context, *EmergencyRequest) (*Emergency, error)
+	RevokeEmergency(context.Context, *EmergencyRequest) (*ResponseEmergency, error)
+	ListEmergency(context.Context, *EmergencyListRequest) (*EmergencyList, error)
+	EmergencyAccess(context.Context, *EmergencyRequest) (*EmergencyItems, error)
+	mustEmbedUnimplementedKeeperServiceServer()
+}
+
+// UnimplementedKeeperServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedKeeperServiceServer struct{}
+
+func (UnimplementedKeeperServiceServer) LoginUser(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginUser not implemented")
+}
+func (UnimplementedKeeperServiceServer) RegisterUser(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterUser not implemented")
+}
+func (UnimplementedKeeperServiceServer) RefreshToken(context.Context, *RefreshRequest) (*RefreshResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedKeeperServiceServer) Logout(context.Context, *LogoutRequest) (*ResponseLogout, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedKeeperServiceServer) RevokeAllSessions(context.Context, *RevokeAllRequest) (*ResponseLogout, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAllSessions not implemented")
+}
+func (UnimplementedKeeperServiceServer) ListSessions(context.Context, *SessionsRequest) (*SessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedKeeperServiceServer) EnrollTOTP(context.Context, *EnrollRequest) (*EnrollResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollTOTP not implemented")
+}
+func (UnimplementedKeeperServiceServer) VerifyTOTP(context.Context, *VerifyTOTPRequest) (*VerifyTOTPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyTOTP not implemented")
+}
+func (UnimplementedKeeperServiceServer) RevokeSession(context.Context, *RevokeSessionRequest) (*ResponseLogout, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedKeeperServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedKeeperServiceServer) SetRecovery(context.Context, *SetRecoveryRequest) (*ResponseSetRecovery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRecovery not implemented")
+}
+func (UnimplementedKeeperServiceServer) OpenRecovery(context.Context, *RecoveryRequest) (*RecoveryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenRecovery not implemented")
+}
+func (UnimplementedKeeperServiceServer) RecoverAccount(context.Context, *RecoveryRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecoverAccount not implemented")
+}
+func (UnimplementedKeeperServiceServer) ExportAccount(*ExportRequest, grpc.ServerStreamingServer[ExportChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAccount not implemented")
+}
+func (UnimplementedKeeperServiceServer) DeleteAccount(context.Context, *DeleteAccountRequest) (*ResponseDeleteAccount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
+func (UnimplementedKeeperServiceServer) CreateAPIToken(context.Context, *APITokenRequest) (*APITokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIToken not implemented")
+}
+func (UnimplementedKeeperServiceServer) ListAPITokens(context.Context, *APITokensRequest) (*APITokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAPITokens not implemented")
+}
+func (UnimplementedKeeperServiceServer) RevokeAPIToken(context.Context, *RevokeAPITokenRequest) (*ResponseLogout, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPIToken not implemented")
+}
+func (UnimplementedKeeperServiceServer) AddData(context.Context, *UserData) (*ResponseAddData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddData not implemented")
+}
+func (UnimplementedKeeperServiceServer) GetData(context.Context, *DownloadRequest) (*UserData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetData not implemented")
+}
+func (UnimplementedKeeperServiceServer) DeleteData(context.Context, *DownloadRequest) (*ResponseDeleteData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteData not implemented")
+}
+func (UnimplementedKeeperServiceServer) UploadData(grpc.ClientStreamingServer[DataChunk, ResponseAddData]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadData not implemented")
+}
+func (UnimplementedKeeperServiceServer) DownloadData(*DownloadRequest, grpc.ServerStreamingServer[DataChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadData not implemented")
+}
+func (UnimplementedKeeperServiceServer) QueryUpload(context.Context, *QueryUploadRequest) (*QueryUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryUpload not implemented")
+}
+func (UnimplementedKeeperServiceServer) GetList(*ListRequest, grpc.ServerStreamingServer[UserData]) error {
+	return status.Errorf(codes.Unimplemented, "method GetList not implemented")
+}
+func (UnimplementedKeeperServiceServer) Search(*SearchRequest, grpc.ServerStreamingServer[SearchResult]) error {
+	return status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedKeeperServiceServer) GetUsage(context.Context, *UsageRequest) (*UsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedKeeperServiceServer) UpdateData(context.Context, *UpdateRequest) (*ResponseUpdateData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateData not implemented")
+}
+func (UnimplementedKeeperServiceServer) SetRotation(context.Context, *RotationRequest) (*ResponseRotation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRotation not implemented")
+}
+func (UnimplementedKeeperServiceServer) DueRotation(context.Context, *DueRequest) (*DueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DueRotation not implemented")
+}
+func (UnimplementedKeeperServiceServer) SetKeys(context.Context, *SetKeysRequest) (*ResponseSetKeys, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetKeys not implemented")
+}
+func (UnimplementedKeeperServiceServer) ShareItem(context.Context, *ShareRequest) (*ResponseShare, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareItem not implemented")
+}
+func (UnimplementedKeeperServiceServer) Unshare(context.Context, *UnshareRequest) (*ResponseUnshare, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unshare not implemented")
+}
+func (UnimplementedKeeperServiceServer) SharedWithMe(context.Context, *SharedRequest) (*SharedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SharedWithMe not implemented")
+}
+func (UnimplementedKeeperServiceServer) GetSealedData(context.Context, *DownloadRequest) (*SealedItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSealedData not implemented")
+}
+func (UnimplementedKeeperServiceServer) CreateOrg(context.Context, *OrgRequest) (*OrgResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrg not implemented")
+}
+func (UnimplementedKeeperServiceServer) AddMember(context.Context, *MemberRequest) (*ResponseMember, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMember not implemented")
+}
+func (UnimplementedKeeperServiceServer) RemoveMember(context.Context, *MemberRequest) (*ResponseMember, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
+}
+func (UnimplementedKeeperServiceServer) ListMembers(context.Context, *MembersRequest) (*MembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMembers not implemented")
+}
+func (UnimplementedKeeperServiceServer) CreateCollection(context.Context, *CollectionRequest) (*CollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedKeeperServiceServer) ListCollections(context.Context, *CollectionsRequest) (*CollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollections not implemented")
+}
+func (UnimplementedKeeperServiceServer) CollectionItems(context.Context, *CollectionItemsRequest) (*CollectionItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CollectionItems not implemented")
+}
+func (UnimplementedKeeperServiceServer) CreateSecretShare(context.Context, *SecretRequest) (*SecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSecretShare not implemented")
+}
+func (UnimplementedKeeperServiceServer) RedeemShare(context.Context, *RedeemRequest) (*RedeemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemShare not implemented")
+}
+func (UnimplementedKeeperServiceServer) InviteEmergency(context.Context, *EmergencyInvite) (*Emergency, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteEmergency not implemented")
+}
+func (UnimplementedKeeperServiceServer) RequestEmergency(context.Context, *EmergencyRequest) (*Emergency, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestEmergency not implemented")
+}
+func (UnimplementedKeeperServiceServer) ApproveEmergency(context.Context, *EmergencyRequest) (*Emergency, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveEmergency not implemented")
+}
+func (UnimplementedKeeperServiceServer) RevokeEmergency(context.Context, *EmergencyRequest) (*ResponseEmergency, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeEmergency not implemented")
+}
+func (UnimplementedKeeperServiceServer) ListEmergency(context.Context, *EmergencyListRequest) (*EmergencyList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEmergency not implemented")
+}
+func (UnimplementedKeeperServiceServer) EmergencyAccess(context.Context, *EmergencyRequest) (*EmergencyItems, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EmergencyAccess not implemented")
+}
+func (UnimplementedKeeperServiceServer) mustEmbedUnimplementedKeeperServiceServer() {}
+func (UnimplementedKeeperServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeKeeperServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KeeperServiceServer will
+// result in compilation errors.
+type UnsafeKeeperServiceServer interface {
+	mustEmbedUnimplementedKeeperServiceServer()
+}
+
+func RegisterKeeperServiceServer(s grpc.ServiceRegistrar, srv KeeperServiceServer) {
+	// If the following call pancis, it indicates UnimplementedKeeperServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&KeeperService_ServiceDesc, srv)
+}
+
+func _KeeperService_LoginUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).LoginUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_LoginUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).LoginUser(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_RegisterUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).RegisterUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_RegisterUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).RegisterUser(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_RefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).RefreshToken(ctx, req.(*RefreshRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_RevokeAllSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).RevokeAllSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_RevokeAllSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).RevokeAllSessions(ctx, req.(*RevokeAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).ListSessions(ctx, req.(*SessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_EnrollTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).EnrollTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_EnrollTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).EnrollTOTP(ctx, req.(*EnrollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_VerifyTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyTOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).VerifyTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_VerifyTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).VerifyTOTP(ctx, req.(*VerifyTOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_RevokeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_SetRecovery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRecoveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).SetRecovery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_SetRecovery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).SetRecovery(ctx, req.(*SetRecoveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_OpenRecovery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).OpenRecovery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_OpenRecovery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).OpenRecovery(ctx, req.(*RecoveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_RecoverAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).RecoverAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_RecoverAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).RecoverAccount(ctx, req.(*RecoveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_ExportAccount_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KeeperServiceServer).ExportAccount(m, &grpc.GenericServerStream[ExportRequest, ExportChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KeeperService_ExportAccountServer = grpc.ServerStreamingServer[ExportChunk]
+
+func _KeeperService_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_DeleteAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_CreateAPIToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APITokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).CreateAPIToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_CreateAPIToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).CreateAPIToken(ctx, req.(*APITokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_ListAPITokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APITokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).ListAPITokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_ListAPITokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).ListAPITokens(ctx, req.(*APITokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_RevokeAPIToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAPITokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).RevokeAPIToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_RevokeAPIToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).RevokeAPIToken(ctx, req.(*RevokeAPITokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_AddData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).AddData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_AddData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).AddData(ctx, req.(*UserData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_GetData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).GetData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_GetData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).GetData(ctx, req.(*DownloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_DeleteData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).DeleteData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_DeleteData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).DeleteData(ctx, req.(*DownloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_UploadData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(KeeperServiceServer).UploadData(&grpc.GenericServerStream[DataChunk, ResponseAddData]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KeeperService_UploadDataServer = grpc.ClientStreamingServer[DataChunk, ResponseAddData]
+
+func _KeeperService_DownloadData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KeeperServiceServer).DownloadData(m, &grpc.GenericServerStream[DownloadRequest, DataChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KeeperService_DownloadDataServer = grpc.ServerStreamingServer[DataChunk]
+
+func _KeeperService_QueryUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).QueryUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_QueryUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).QueryUpload(ctx, req.(*QueryUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_GetList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KeeperServiceServer).GetList(m, &grpc.GenericServerStream[ListRequest, UserData]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KeeperService_GetListServer = grpc.ServerStreamingServer[UserData]
+
+func _KeeperService_Search_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KeeperServiceServer).Search(m, &grpc.GenericServerStream[SearchRequest, SearchResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KeeperService_SearchServer = grpc.ServerStreamingServer[SearchResult]
+
+func _KeeperService_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_GetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).GetUsage(ctx, req.(*UsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_UpdateData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).UpdateData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_UpdateData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).UpdateData(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_SetRotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).SetRotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_SetRotation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).SetRotation(ctx, req.(*RotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_DueRotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).DueRotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_DueRotation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).DueRotation(ctx, req.(*DueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_SetKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).SetKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_SetKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).SetKeys(ctx, req.(*SetKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_ShareItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).ShareItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_ShareItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).ShareItem(ctx, req.(*ShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_Unshare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).Unshare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_Unshare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).Unshare(ctx, req.(*UnshareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_SharedWithMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).SharedWithMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_SharedWithMe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).SharedWithMe(ctx, req.(*SharedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_GetSealedData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).GetSealedData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_GetSealedData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).GetSealedData(ctx, req.(*DownloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_CreateOrg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrgRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).CreateOrg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_CreateOrg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).CreateOrg(ctx, req.(*OrgRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_AddMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).AddMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_AddMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).AddMember(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).RemoveMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_RemoveMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).RemoveMember(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_ListMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).ListMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_ListMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).ListMembers(ctx, req.(*MembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_CreateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).CreateCollection(ctx, req.(*CollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_ListCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).ListCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_ListCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).ListCollections(ctx, req.(*CollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperService_CollectionItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).CollectionItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_CollectionItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).CollectionItems(ctx, req.(*CollectionItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type KeeperService_UploadDataClient = grpc.ClientStreamingClient[DataChunk, ResponseAddData]
-
-func (c *keeperServiceClient) DownloadData(ctx context.Context, in *DownloadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DataChunk], error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &KeeperService_ServiceDesc.Streams[1], KeeperService_DownloadData_FullMethodName, cOpts...)
-	if err != nil {
+func _KeeperService_CreateSecretShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[DownloadRequest, DataChunk]{ClientStream: stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).CreateSecretShare(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_CreateSecretShare_FullMethodName,
 	}
-	return x, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).CreateSecretShare(ctx, req.(*SecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type KeeperService_DownloadDataClient = grpc.ServerStreamingClient[DataChunk]
-
-func (c *keeperServiceClient) GetList(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserData], error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &KeeperService_ServiceDesc.Streams[2], KeeperService_GetList_FullMethodName, cOpts...)
-	if err != nil {
+func _KeeperService_RedeemShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[ListRequest, UserData]{ClientStream: stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).RedeemShare(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_RedeemShare_FullMethodName,
 	}
-	return x, nil
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type KeeperService_GetListClient = grpc.ServerStreamingClient[UserData]
-
-// KeeperServiceServer is the server API for KeeperService service.
-// All implementations must embed UnimplementedKeeperServiceServer
-// for forward compatibility.
-type KeeperServiceServer interface {
-	LoginUser(context.Context, *LoginRequest) (*LoginResponse, error)
-	RegisterUser(context.Context, *LoginRequest) (*LoginResponse, error)
-	AddData(context.Context, *UserData) (*ResponseAddData, error)
-	GetData(context.Context, *DownloadRequest) (*UserData, error)
-	UploadData(grpc.ClientStreamingServer[DataChunk, ResponseAddData]) error
-	DownloadData(*DownloadRequest, grpc.ServerStreamingServer[DataChunk]) error
-	GetList(*ListRequest, grpc.ServerStreamingServer[UserData]) error
-	mustEmbedUnimplementedKeeperServiceServer()
-}
-
-// UnimplementedKeeperServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedKeeperServiceServer struct{}
-
-func (UnimplementedKeeperServiceServer) LoginUser(context.Context, *LoginRequest) (*LoginResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LoginUser not implemented")
-}
-func (UnimplementedKeeperServiceServer) RegisterUser(context.Context, *LoginRequest) (*LoginResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RegisterUser not implemented")
-}
-func (UnimplementedKeeperServiceServer) AddData(context.Context, *UserData) (*ResponseAddData, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddData not implemented")
-}
-func (UnimplementedKeeperServiceServer) GetData(context.Context, *DownloadRequest) (*UserData, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetData not implemented")
-}
-func (UnimplementedKeeperServiceServer) UploadData(grpc.ClientStreamingServer[DataChunk, ResponseAddData]) error {
-	return status.Errorf(codes.Unimplemented, "method UploadData not implemented")
-}
-func (UnimplementedKeeperServiceServer) DownloadData(*DownloadRequest, grpc.ServerStreamingServer[DataChunk]) error {
-	return status.Errorf(codes.Unimplemented, "method DownloadData not implemented")
-}
-func (UnimplementedKeeperServiceServer) GetList(*ListRequest, grpc.ServerStreamingServer[UserData]) error {
-	return status.Errorf(codes.Unimplemented, "method GetList not implemented")
-}
-func (UnimplementedKeeperServiceServer) mustEmbedUnimplementedKeeperServiceServer() {}
-func (UnimplementedKeeperServiceServer) testEmbeddedByValue()                       {}
-
-// UnsafeKeeperServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to KeeperServiceServer will
-// result in compilation errors.
-type UnsafeKeeperServiceServer interface {
-	mustEmbedUnimplementedKeeperServiceServer()
-}
-
-func RegisterKeeperServiceServer(s grpc.ServiceRegistrar, srv KeeperServiceServer) {
-	// If the following call pancis, it indicates UnimplementedKeeperServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).RedeemShare(ctx, req.(*RedeemRequest))
 	}
-	s.RegisterService(&KeeperService_ServiceDesc, srv)
+	return interceptor(ctx, in, info, handler)
 }
 
-func _KeeperService_LoginUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoginRequest)
+func _KeeperService_InviteEmergency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmergencyInvite)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeeperServiceServer).LoginUser(ctx, in)
+		return srv.(KeeperServiceServer).InviteEmergency(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeeperService_LoginUser_FullMethodName,
+		FullMethod: KeeperService_InviteEmergency_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeeperServiceServer).LoginUser(ctx, req.(*LoginRequest))
+		return srv.(KeeperServiceServer).InviteEmergency(ctx, req.(*EmergencyInvite))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeeperService_RegisterUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoginRequest)
+func _KeeperService_RequestEmergency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmergencyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeeperServiceServer).RegisterUser(ctx, in)
+		return srv.(KeeperServiceServer).RequestEmergency(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeeperService_RegisterUser_FullMethodName,
+		FullMethod: KeeperService_RequestEmergency_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeeperServiceServer).RegisterUser(ctx, req.(*LoginRequest))
+		return srv.(KeeperServiceServer).RequestEmergency(ctx, req.(*EmergencyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeeperService_AddData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserData)
+func _KeeperService_ApproveEmergency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmergencyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeeperServiceServer).AddData(ctx, in)
+		return srv.(KeeperServiceServer).ApproveEmergency(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeeperService_AddData_FullMethodName,
+		FullMethod: KeeperService_ApproveEmergency_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeeperServiceServer).AddData(ctx, req.(*UserData))
+		return srv.(KeeperServiceServer).ApproveEmergency(ctx, req.(*EmergencyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeeperService_GetData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DownloadRequest)
+func _KeeperService_RevokeEmergency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmergencyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeeperServiceServer).GetData(ctx, in)
+		return srv.(KeeperServiceServer).RevokeEmergency(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeeperService_GetData_FullMethodName,
+		FullMethod: KeeperService_RevokeEmergency_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeeperServiceServer).GetData(ctx, req.(*DownloadRequest))
+		return srv.(KeeperServiceServer).RevokeEmergency(ctx, req.(*EmergencyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeeperService_UploadData_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(KeeperServiceServer).UploadData(&grpc.GenericServerStream[DataChunk, ResponseAddData]{ServerStream: stream})
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type KeeperService_UploadDataServer = grpc.ClientStreamingServer[DataChunk, ResponseAddData]
-
-func _KeeperService_DownloadData_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(DownloadRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _KeeperService_ListEmergency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmergencyListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(KeeperServiceServer).DownloadData(m, &grpc.GenericServerStream[DownloadRequest, DataChunk]{ServerStream: stream})
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).ListEmergency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_ListEmergency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).ListEmergency(ctx, req.(*EmergencyListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type KeeperService_DownloadDataServer = grpc.ServerStreamingServer[DataChunk]
-
-func _KeeperService_GetList_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ListRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _KeeperService_EmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmergencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(KeeperServiceServer).GetList(m, &grpc.GenericServerStream[ListRequest, UserData]{ServerStream: stream})
+	if interceptor == nil {
+		return srv.(KeeperServiceServer).EmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperService_EmergencyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceServer).EmergencyAccess(ctx, req.(*EmergencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type KeeperService_GetListServer = grpc.ServerStreamingServer[UserData]
-
 // KeeperService_ServiceDesc is the grpc.ServiceDesc for KeeperService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -319,6 +1785,66 @@ var KeeperService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RegisterUser",
 			Handler:    _KeeperService_RegisterUser_Handler,
 		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _KeeperService_RefreshToken_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _KeeperService_Logout_Handler,
+		},
+		{
+			MethodName: "RevokeAllSessions",
+			Handler:    _KeeperService_RevokeAllSessions_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _KeeperService_ListSessions_Handler,
+		},
+		{
+			MethodName: "EnrollTOTP",
+			Handler:    _KeeperService_EnrollTOTP_Handler,
+		},
+		{
+			MethodName: "VerifyTOTP",
+			Handler:    _KeeperService_VerifyTOTP_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _KeeperService_RevokeSession_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _KeeperService_ChangePassword_Handler,
+		},
+		{
+			MethodName: "SetRecovery",
+			Handler:    _KeeperService_SetRecovery_Handler,
+		},
+		{
+			MethodName: "OpenRecovery",
+			Handler:    _KeeperService_OpenRecovery_Handler,
+		},
+		{
+			MethodName: "RecoverAccount",
+			Handler:    _KeeperService_RecoverAccount_Handler,
+		},
+		{
+			MethodName: "DeleteAccount",
+			Handler:    _KeeperService_DeleteAccount_Handler,
+		},
+		{
+			MethodName: "CreateAPIToken",
+			Handler:    _KeeperService_CreateAPIToken_Handler,
+		},
+		{
+			MethodName: "ListAPITokens",
+			Handler:    _KeeperService_ListAPITokens_Handler,
+		},
+		{
+			MethodName: "RevokeAPIToken",
+			Handler:    _KeeperService_RevokeAPIToken_Handler,
+		},
 		{
 			MethodName: "AddData",
 			Handler:    _KeeperService_AddData_Handler,
@@ -327,8 +1853,117 @@ var KeeperService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetData",
 			Handler:    _KeeperService_GetData_Handler,
 		},
+		{
+			MethodName: "DeleteData",
+			Handler:    _KeeperService_DeleteData_Handler,
+		},
+		{
+			MethodName: "QueryUpload",
+			Handler:    _KeeperService_QueryUpload_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _KeeperService_GetUsage_Handler,
+		},
+		{
+			MethodName: "UpdateData",
+			Handler:    _KeeperService_UpdateData_Handler,
+		},
+		{
+			MethodName: "SetRotation",
+			Handler:    _KeeperService_SetRotation_Handler,
+		},
+		{
+			MethodName: "DueRotation",
+			Handler:    _KeeperService_DueRotation_Handler,
+		},
+		{
+			MethodName: "SetKeys",
+			Handler:    _KeeperService_SetKeys_Handler,
+		},
+		{
+			MethodName: "ShareItem",
+			Handler:    _KeeperService_ShareItem_Handler,
+		},
+		{
+			MethodName: "Unshare",
+			Handler:    _KeeperService_Unshare_Handler,
+		},
+		{
+			MethodName: "SharedWithMe",
+			Handler:    _KeeperService_SharedWithMe_Handler,
+		},
+		{
+			MethodName: "GetSealedData",
+			Handler:    _KeeperService_GetSealedData_Handler,
+		},
+		{
+			MethodName: "CreateOrg",
+			Handler:    _KeeperService_CreateOrg_Handler,
+		},
+		{
+			MethodName: "AddMember",
+			Handler:    _KeeperService_AddMember_Handler,
+		},
+		{
+			MethodName: "RemoveMember",
+			Handler:    _KeeperService_RemoveMember_Handler,
+		},
+		{
+			MethodName: "ListMembers",
+			Handler:    _KeeperService_ListMembers_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _KeeperService_CreateCollection_Handler,
+		},
+		{
+			MethodName: "ListCollections",
+			Handler:    _KeeperService_ListCollections_Handler,
+		},
+		{
+			MethodName: "CollectionItems",
+			Handler:    _KeeperService_CollectionItems_Handler,
+		},
+		{
+			MethodName: "CreateSecretShare",
+			Handler:    _KeeperService_CreateSecretShare_Handler,
+		},
+		{
+			MethodName: "RedeemShare",
+			Handler:    _KeeperService_RedeemShare_Handler,
+		},
+		{
+			MethodName: "InviteEmergency",
+			Handler:    _KeeperService_InviteEmergency_Handler,
+		},
+		{
+			MethodName: "RequestEmergency",
+			Handler:    _KeeperService_RequestEmergency_Handler,
+		},
+		{
+			MethodName: "ApproveEmergency",
+			Handler:    _KeeperService_ApproveEmergency_Handler,
+		},
+		{
+			MethodName: "RevokeEmergency",
+			Handler:    _KeeperService_RevokeEmergency_Handler,
+		},
+		{
+			MethodName: "ListEmergency",
+			Handler:    _KeeperService_ListEmergency_Handler,
+		},
+		{
+			MethodName: "EmergencyAccess",
+			Handler:    _KeeperService_EmergencyAccess_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportAccount",
+			Handler:       _KeeperService_ExportAccount_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "UploadData",
 			Handler:       _KeeperService_UploadData_Handler,
@@ -344,6 +1979,11 @@ var KeeperService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _KeeperService_GetList_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Search",
+			Handler:       _KeeperService_Search_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api/proto/gokeeper.proto",
 }